@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"assignment-golang-backend/database"
 	"assignment-golang-backend/internal/config"
 	"assignment-golang-backend/internal/handler"
+	"assignment-golang-backend/internal/helper"
 	"assignment-golang-backend/internal/repository"
+	"assignment-golang-backend/internal/scheduler"
 	"assignment-golang-backend/internal/usecase"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +18,7 @@ import (
 func main() {
 	config.LoadEnv()
 	database.Connect()
+	helper.LoadKeySet()
 
 	r := gin.Default()
 
@@ -22,6 +26,9 @@ func main() {
 	s := usecase.New(rp)
 	h := handler.New(s)
 
+	go scheduler.New(s.Schedule, scheduler.IntervalFromEnv()).
+		Start(context.Background())
+
 	h.InitAPI(r)
 	err := r.Run()
 	if err != nil {
@@ -0,0 +1,103 @@
+// Command reconcile audits every wallet balance against the balance
+// recomputed from the transactions table and reports the wallets that do
+// not match.
+//
+// Usage:
+//
+//	go run ./cmd/reconcile [-format json|csv] [-repair]
+//
+// It exits with status 1 when mismatches are found and left unrepaired.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+
+	"assignment-golang-backend/database"
+	"assignment-golang-backend/internal/config"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+	"assignment-golang-backend/internal/usecase"
+)
+
+func main() {
+	format := flag.String("format", "json", "report format, json or csv")
+	repair := flag.Bool(
+		"repair",
+		false,
+		"overwrite mismatched balances with the recomputed balance",
+	)
+	flag.Parse()
+
+	if *format != "json" && *format != "csv" {
+		log.Fatalf("unknown format %q, use json or csv", *format)
+	}
+
+	config.LoadEnv()
+	database.Connect()
+
+	rp := repository.New(database.Get())
+	s := usecase.New(rp)
+
+	mismatches, err := s.Reconciliation.ReconcileWallets(*repair)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if *format == "csv" {
+		err = writeCSV(os.Stdout, mismatches)
+	} else {
+		err = writeJSON(os.Stdout, mismatches)
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	for _, mismatch := range mismatches {
+		if !mismatch.Repaired {
+			os.Exit(1)
+		}
+	}
+}
+
+func writeJSON(w io.Writer, mismatches []*entity.BalanceReconciliation) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(mismatches)
+}
+
+func writeCSV(w io.Writer, mismatches []*entity.BalanceReconciliation) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{
+		"wallet_number",
+		"wallet_balance",
+		"transaction_balance",
+		"difference",
+		"repaired",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, mismatch := range mismatches {
+		err = writer.Write([]string{
+			strconv.Itoa(mismatch.WalletNumber),
+			strconv.Itoa(mismatch.WalletBalance),
+			strconv.Itoa(mismatch.TransactionBalance),
+			strconv.Itoa(mismatch.Difference),
+			fmt.Sprint(mismatch.Repaired),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
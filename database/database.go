@@ -6,13 +6,137 @@ import (
 
 	"assignment-golang-backend/internal/config"
 	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/ledger"
+	"assignment-golang-backend/internal/topup"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var db *gorm.DB
 
+// defaultSourcesOfFunds are seeded in this order so they keep the IDs the
+// top ups made before the sources_of_funds table existed refer to.
+var defaultSourcesOfFunds = []*entity.SourceOfFunds{
+	{
+		Name:           "Bank Transfer",
+		Provider:       topup.FAKE_PROVIDER_NAME,
+		Enabled:        true,
+		MinTopupAmount: 50000,
+		MaxTopupAmount: 10000000,
+	},
+	{
+		Name:           "Credit Card",
+		Provider:       topup.FAKE_PROVIDER_NAME,
+		Enabled:        true,
+		MinTopupAmount: 50000,
+		MaxTopupAmount: 10000000,
+	},
+	{
+		Name:           "Cash",
+		Provider:       topup.FAKE_PROVIDER_NAME,
+		Enabled:        true,
+		MinTopupAmount: 50000,
+		MaxTopupAmount: 10000000,
+	},
+}
+
+// defaultTransactionLimits are the limits of each user tier in each
+// currency, in its minor unit. VERIFIED users have passed KYC. The limits of
+// the other currencies are close to the IDR ones at the rates of
+// asset/fx_rates.json.
+var defaultTransactionLimits = []*entity.TransactionLimit{
+	{
+		Tier:                 entity.BasicTier,
+		Currency:             entity.IDR,
+		MinTransferAmount:    1000,
+		MaxTransferAmount:    10000000,
+		MinWithdrawalAmount:  10000,
+		MaxWithdrawalAmount:  10000000,
+		DailyOutgoingLimit:   10000000,
+		MonthlyOutgoingLimit: 20000000,
+		MaxBalance:           20000000,
+	},
+	{
+		Tier:                 entity.VerifiedTier,
+		Currency:             entity.IDR,
+		MinTransferAmount:    1000,
+		MaxTransferAmount:    50000000,
+		MinWithdrawalAmount:  10000,
+		MaxWithdrawalAmount:  50000000,
+		DailyOutgoingLimit:   100000000,
+		MonthlyOutgoingLimit: 500000000,
+		MaxBalance:           500000000,
+	},
+	{
+		Tier:                 entity.BasicTier,
+		Currency:             entity.USD,
+		MinTransferAmount:    100,
+		MaxTransferAmount:    65000,
+		MinWithdrawalAmount:  1000,
+		MaxWithdrawalAmount:  65000,
+		DailyOutgoingLimit:   65000,
+		MonthlyOutgoingLimit: 130000,
+		MaxBalance:           130000,
+	},
+	{
+		Tier:                 entity.VerifiedTier,
+		Currency:             entity.USD,
+		MinTransferAmount:    100,
+		MaxTransferAmount:    325000,
+		MinWithdrawalAmount:  1000,
+		MaxWithdrawalAmount:  325000,
+		DailyOutgoingLimit:   650000,
+		MonthlyOutgoingLimit: 3250000,
+		MaxBalance:           3250000,
+	},
+	{
+		Tier:                 entity.BasicTier,
+		Currency:             entity.EUR,
+		MinTransferAmount:    100,
+		MaxTransferAmount:    60000,
+		MinWithdrawalAmount:  1000,
+		MaxWithdrawalAmount:  60000,
+		DailyOutgoingLimit:   60000,
+		MonthlyOutgoingLimit: 120000,
+		MaxBalance:           120000,
+	},
+	{
+		Tier:                 entity.VerifiedTier,
+		Currency:             entity.EUR,
+		MinTransferAmount:    100,
+		MaxTransferAmount:    300000,
+		MinWithdrawalAmount:  1000,
+		MaxWithdrawalAmount:  300000,
+		DailyOutgoingLimit:   600000,
+		MonthlyOutgoingLimit: 3000000,
+		MaxBalance:           3000000,
+	},
+	{
+		Tier:                 entity.BasicTier,
+		Currency:             entity.SGD,
+		MinTransferAmount:    100,
+		MaxTransferAmount:    87000,
+		MinWithdrawalAmount:  1000,
+		MaxWithdrawalAmount:  87000,
+		DailyOutgoingLimit:   87000,
+		MonthlyOutgoingLimit: 174000,
+		MaxBalance:           174000,
+	},
+	{
+		Tier:                 entity.VerifiedTier,
+		Currency:             entity.SGD,
+		MinTransferAmount:    100,
+		MaxTransferAmount:    435000,
+		MinWithdrawalAmount:  1000,
+		MaxWithdrawalAmount:  435000,
+		DailyOutgoingLimit:   870000,
+		MonthlyOutgoingLimit: 4350000,
+		MaxBalance:           4350000,
+	},
+}
+
 type DBConfig struct {
 	HOST string
 	PORT string
@@ -48,14 +172,236 @@ func Connect() {
 		log.Fatalln(err)
 	}
 
+	err = db.AutoMigrate(&entity.SourceOfFunds{})
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	err = seedSourcesOfFunds()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
 	err = db.AutoMigrate(
 		&entity.User{},
 		&entity.Wallet{},
 		&entity.Transaction{},
+		&entity.IdempotencyKey{},
+		&entity.JournalEntry{},
+		&entity.Posting{},
+		&entity.RefreshToken{},
+		&entity.RevokedToken{},
+		&entity.PaymentRequest{},
+		&entity.ScheduledTransfer{},
+		&entity.ScheduledTransferRun{},
+		&entity.BankAccount{},
+		&entity.TransactionLimit{},
+		&entity.KycSubmission{},
+		&entity.FeeRule{},
 	)
 	if err != nil {
 		log.Fatalln(err)
 	}
+
+	err = seedTransactionLimits()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	err = seedHouseWallets()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	err = migrateWalletUsers()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	err = migrateLedgerHistory()
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// seedSourcesOfFunds adds the default sources of funds that are missing.
+// Sources that exist are left as they are, so they can be changed in the
+// database.
+func seedSourcesOfFunds() error {
+	for _, source := range defaultSourcesOfFunds {
+		err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoNothing: true,
+		}).Create(source).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// seedTransactionLimits adds the limits of the tiers that have none, the
+// same way seedSourcesOfFunds does.
+func seedTransactionLimits() error {
+	for _, limit := range defaultTransactionLimits {
+		err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tier"}, {Name: "currency"}},
+			DoNothing: true,
+		}).Create(limit).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// seedHouseWallets adds the wallets fees are collected into that are
+// missing, one of each currency.
+func seedHouseWallets() error {
+	for _, currency := range entity.SupportedCurrencies() {
+		err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "number"}},
+			DoNothing: true,
+		}).Create(&entity.Wallet{
+			Number:   currency.HouseWalletNumber(),
+			Currency: currency,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateWalletUsers gives the wallets made before wallets had a user to
+// the user they belong to.
+func migrateWalletUsers() error {
+	return db.Exec(
+		"UPDATE wallets SET user_id = users.id FROM users " +
+			"WHERE users.wallet_number = wallets.number AND wallets.user_id IS NULL",
+	).Error
+}
+
+// migrateLedgerHistory posts the journal entry of every transaction made
+// before the ledger existed, dated when the transaction was made, so the
+// statement of any period agrees with the ledger. Whatever the transactions
+// do not explain of a wallet's balance is posted as its opening balance
+// when the wallet was created. The opening balances earlier versions posted
+// on the day of the migration are replaced once the history is posted.
+func migrateLedgerHistory() error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var transactions []*entity.Transaction
+		err := tx.Where(
+			"NOT EXISTS (?)",
+			tx.Model(&entity.JournalEntry{}).
+				Select("1").
+				Where("journal_entries.transaction_id = transactions.id"),
+		).
+			Order("datetime, id").
+			Find(&transactions).Error
+		if err != nil {
+			return err
+		}
+
+		walletNumbers := []int{}
+		for _, transaction := range transactions {
+			journalEntry, ok := ledger.NewTransactionEntry(transaction)
+			if !ok {
+				continue
+			}
+
+			err = tx.Create(journalEntry).Error
+			if err != nil {
+				return err
+			}
+
+			for _, posting := range journalEntry.Postings {
+				if posting.AccountType == entity.WalletAccount {
+					walletNumbers = append(walletNumbers, posting.AccountNumber)
+				}
+			}
+		}
+
+		if len(walletNumbers) > 0 {
+			err = deleteOpeningBalances(tx, walletNumbers)
+			if err != nil {
+				return err
+			}
+		}
+
+		query := tx.Where(
+			"balance <> 0 AND NOT EXISTS (?)",
+			tx.Model(&entity.Posting{}).
+				Select("1").
+				Where("postings.account_type = ?", entity.WalletAccount).
+				Where("postings.account_number = wallets.number"),
+		)
+		if len(walletNumbers) > 0 {
+			query = query.Or("number IN ?", walletNumbers)
+		}
+
+		var wallets []*entity.Wallet
+		err = query.Find(&wallets).Error
+		if err != nil {
+			return err
+		}
+
+		for _, wallet := range wallets {
+			var posted int64
+			err = tx.Model(&entity.Posting{}).
+				Select(
+					"COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0)",
+					entity.Credit,
+				).
+				Where(
+					"account_type = ? AND account_number = ?",
+					entity.WalletAccount,
+					wallet.Number,
+				).
+				Scan(&posted).Error
+			if err != nil {
+				return err
+			}
+
+			unexplained := wallet.Balance - int(posted)
+			if unexplained == 0 {
+				continue
+			}
+
+			err = tx.Create(ledger.NewOpeningBalanceEntry(
+				&entity.Wallet{Number: wallet.Number, Balance: unexplained},
+				wallet.CreatedAt,
+			)).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// deleteOpeningBalances deletes the opening balance entries of the wallets.
+func deleteOpeningBalances(tx *gorm.DB, walletNumbers []int) error {
+	var journalEntryIDs []int
+	err := tx.Model(&entity.Posting{}).
+		Where("account_type = ?", entity.OpeningBalanceAccount).
+		Where("account_number IN ?", walletNumbers).
+		Pluck("journal_entry_id", &journalEntryIDs).Error
+	if err != nil || len(journalEntryIDs) == 0 {
+		return err
+	}
+
+	err = tx.Where("journal_entry_id IN ?", journalEntryIDs).
+		Delete(&entity.Posting{}).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Delete(&entity.JournalEntry{}, journalEntryIDs).Error
 }
 
 func Get() *gorm.DB {
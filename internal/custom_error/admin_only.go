@@ -0,0 +1,8 @@
+package custom_error
+
+type AdminOnly struct {
+}
+
+func (e AdminOnly) Error() string {
+	return "Only admins can access this"
+}
@@ -0,0 +1,14 @@
+package custom_error
+
+import "fmt"
+
+type BalanceLimitExceeded struct {
+	Remaining int
+}
+
+func (e BalanceLimitExceeded) Error() string {
+	return fmt.Sprintf(
+		"Wallet balance limit exceeded, the wallet can receive %d more",
+		e.Remaining,
+	)
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type CannotReviewOwnKyc struct {
+}
+
+func (e CannotReviewOwnKyc) Error() string {
+	return "Cannot review your own KYC submission"
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type CannotClosePrimaryWallet struct {
+}
+
+func (e CannotClosePrimaryWallet) Error() string {
+	return "Cannot close your primary wallet"
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type ConvertedAmountTooSmall struct {
+}
+
+func (e ConvertedAmountTooSmall) Error() string {
+	return "Amount is too small to convert to the currency of the destination wallet"
+}
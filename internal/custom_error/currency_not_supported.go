@@ -0,0 +1,11 @@
+package custom_error
+
+import "fmt"
+
+type CurrencyNotSupported struct {
+	Currency string
+}
+
+func (e CurrencyNotSupported) Error() string {
+	return fmt.Sprintf("Currency %s is not supported", e.Currency)
+}
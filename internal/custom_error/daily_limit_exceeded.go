@@ -0,0 +1,14 @@
+package custom_error
+
+import "fmt"
+
+type DailyLimitExceeded struct {
+	Remaining int
+}
+
+func (e DailyLimitExceeded) Error() string {
+	return fmt.Sprintf(
+		"Daily outgoing limit exceeded, %d can still be sent today",
+		e.Remaining,
+	)
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type DestinationBalanceLimitExceeded struct {
+}
+
+func (e DestinationBalanceLimitExceeded) Error() string {
+	return "Destination wallet cannot receive this amount"
+}
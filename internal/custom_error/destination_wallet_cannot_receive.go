@@ -0,0 +1,8 @@
+package custom_error
+
+type DestinationWalletCannotReceive struct {
+}
+
+func (e DestinationWalletCannotReceive) Error() string {
+	return "Destination wallet cannot receive money"
+}
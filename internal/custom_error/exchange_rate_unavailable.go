@@ -0,0 +1,12 @@
+package custom_error
+
+import "fmt"
+
+type ExchangeRateUnavailable struct {
+	From string
+	To   string
+}
+
+func (e ExchangeRateUnavailable) Error() string {
+	return fmt.Sprintf("No exchange rate from %s to %s", e.From, e.To)
+}
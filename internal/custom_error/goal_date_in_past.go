@@ -0,0 +1,8 @@
+package custom_error
+
+type GoalDateInPast struct {
+}
+
+func (e GoalDateInPast) Error() string {
+	return "Goal date must be in the future"
+}
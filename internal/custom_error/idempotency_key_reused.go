@@ -0,0 +1,8 @@
+package custom_error
+
+type IdempotencyKeyReused struct {
+}
+
+func (e IdempotencyKeyReused) Error() string {
+	return "Idempotency-Key is already used for a different request"
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type IdempotentRequestInProgress struct {
+}
+
+func (e IdempotentRequestInProgress) Error() string {
+	return "Request with the same Idempotency-Key is still in progress"
+}
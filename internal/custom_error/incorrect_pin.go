@@ -0,0 +1,14 @@
+package custom_error
+
+import "fmt"
+
+type WrongPin struct {
+	RemainingAttempts int
+}
+
+func (e WrongPin) Error() string {
+	return fmt.Sprintf(
+		"Transaction PIN is incorrect, %d attempts left",
+		e.RemainingAttempts,
+	)
+}
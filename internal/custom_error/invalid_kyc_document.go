@@ -0,0 +1,8 @@
+package custom_error
+
+type InvalidKycDocument struct {
+}
+
+func (e InvalidKycDocument) Error() string {
+	return "Documents must be JPEG, PNG or PDF files of at most 5 MB"
+}
@@ -0,0 +1,11 @@
+package custom_error
+
+import "fmt"
+
+type InvalidPinFormat struct {
+	Length int
+}
+
+func (e InvalidPinFormat) Error() string {
+	return fmt.Sprintf("Transaction PIN must be %d digits", e.Length)
+}
@@ -0,0 +1,11 @@
+package custom_error
+
+import "fmt"
+
+type InvalidSortField struct {
+	Field string
+}
+
+func (e InvalidSortField) Error() string {
+	return fmt.Sprintf("Cannot sort by '%s'", e.Field)
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type InvalidWebhookSignature struct {
+}
+
+func (e InvalidWebhookSignature) Error() string {
+	return "Webhook signature is invalid"
+}
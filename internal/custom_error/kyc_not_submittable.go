@@ -0,0 +1,11 @@
+package custom_error
+
+import "fmt"
+
+type KycNotSubmittable struct {
+	Status string
+}
+
+func (e KycNotSubmittable) Error() string {
+	return fmt.Sprintf("KYC cannot be submitted while it is %s", e.Status)
+}
@@ -0,0 +1,11 @@
+package custom_error
+
+import "fmt"
+
+type KycSubmissionNotPending struct {
+	Status string
+}
+
+func (e KycSubmissionNotPending) Error() string {
+	return fmt.Sprintf("KYC submission is already %s", e.Status)
+}
@@ -0,0 +1,14 @@
+package custom_error
+
+import "fmt"
+
+type MonthlyLimitExceeded struct {
+	Remaining int
+}
+
+func (e MonthlyLimitExceeded) Error() string {
+	return fmt.Sprintf(
+		"Monthly outgoing limit exceeded, %d can still be sent this month",
+		e.Remaining,
+	)
+}
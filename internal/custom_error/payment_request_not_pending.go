@@ -0,0 +1,11 @@
+package custom_error
+
+import "fmt"
+
+type PaymentRequestNotPending struct {
+	Status string
+}
+
+func (e PaymentRequestNotPending) Error() string {
+	return fmt.Sprintf("Payment request is already %s", e.Status)
+}
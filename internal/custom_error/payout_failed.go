@@ -0,0 +1,11 @@
+package custom_error
+
+import "fmt"
+
+type PayoutFailed struct {
+	Reason string
+}
+
+func (e PayoutFailed) Error() string {
+	return fmt.Sprintf("Payout failed: %s", e.Reason)
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type PayoutPending struct {
+}
+
+func (e PayoutPending) Error() string {
+	return "The balance is being paid out, close the wallet again once the payout completed and the wallet is empty"
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type PinAlreadySet struct {
+}
+
+func (e PinAlreadySet) Error() string {
+	return "Transaction PIN is already set"
+}
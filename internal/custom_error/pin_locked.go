@@ -0,0 +1,17 @@
+package custom_error
+
+import (
+	"fmt"
+	"time"
+)
+
+type PinLocked struct {
+	Until time.Time
+}
+
+func (e PinLocked) Error() string {
+	return fmt.Sprintf(
+		"Transaction PIN is locked until %s",
+		e.Until.Format(time.RFC3339),
+	)
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type PinNotSet struct {
+}
+
+func (e PinNotSet) Error() string {
+	return "Transaction PIN is not set"
+}
@@ -0,0 +1,14 @@
+package custom_error
+
+import "fmt"
+
+type RefundExceedsAmount struct {
+	Refundable int
+}
+
+func (e RefundExceedsAmount) Error() string {
+	return fmt.Sprintf(
+		"Refund amount exceeds the refundable amount of %d",
+		e.Refundable,
+	)
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type CannotRequestFromOwnWallet struct {
+}
+
+func (e CannotRequestFromOwnWallet) Error() string {
+	return "Cannot Request Payment from Own Wallet"
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type ScheduleStartInPast struct {
+}
+
+func (e ScheduleStartInPast) Error() string {
+	return "Schedule start time must be in the future"
+}
@@ -0,0 +1,11 @@
+package custom_error
+
+import "fmt"
+
+type ScheduledTransferNotActive struct {
+	Status string
+}
+
+func (e ScheduledTransferNotActive) Error() string {
+	return fmt.Sprintf("Scheduled transfer is already %s", e.Status)
+}
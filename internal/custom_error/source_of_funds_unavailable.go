@@ -0,0 +1,8 @@
+package custom_error
+
+type SourceOfFundsUnavailable struct {
+}
+
+func (e SourceOfFundsUnavailable) Error() string {
+	return "Source of funds is not available"
+}
@@ -0,0 +1,11 @@
+package custom_error
+
+import "fmt"
+
+type TransactionNotPending struct {
+	Status string
+}
+
+func (e TransactionNotPending) Error() string {
+	return fmt.Sprintf("Transaction is already %s", e.Status)
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type TransactionNotRefundable struct {
+}
+
+func (e TransactionNotRefundable) Error() string {
+	return "Only transfers can be refunded"
+}
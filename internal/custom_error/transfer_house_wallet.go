@@ -0,0 +1,8 @@
+package custom_error
+
+type CannotTransferToHouseWallet struct {
+}
+
+func (e CannotTransferToHouseWallet) Error() string {
+	return "Cannot Transfer to a House Wallet"
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type CannotTransferToSameWallet struct {
+}
+
+func (e CannotTransferToSameWallet) Error() string {
+	return "Cannot Transfer to the Same Wallet"
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type UnbalancedJournalEntry struct {
+}
+
+func (e UnbalancedJournalEntry) Error() string {
+	return "Journal entry debits and credits are not balanced"
+}
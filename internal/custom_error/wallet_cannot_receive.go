@@ -0,0 +1,11 @@
+package custom_error
+
+import "fmt"
+
+type WalletCannotReceive struct {
+	Status string
+}
+
+func (e WalletCannotReceive) Error() string {
+	return fmt.Sprintf("Wallet is %s and cannot receive money", e.Status)
+}
@@ -0,0 +1,11 @@
+package custom_error
+
+import "fmt"
+
+type WalletCannotSend struct {
+	Status string
+}
+
+func (e WalletCannotSend) Error() string {
+	return fmt.Sprintf("Wallet is %s and cannot send money", e.Status)
+}
@@ -0,0 +1,8 @@
+package custom_error
+
+type WalletClosed struct {
+}
+
+func (e WalletClosed) Error() string {
+	return "Wallet is closed"
+}
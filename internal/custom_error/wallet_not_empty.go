@@ -0,0 +1,8 @@
+package custom_error
+
+type WalletNotEmpty struct {
+}
+
+func (e WalletNotEmpty) Error() string {
+	return "Move the balance out of the wallet before closing it"
+}
@@ -10,3 +10,11 @@ type LoginRequestBody struct {
 	Email    string `json:"email"    binding:"required,email"`
 	Password string `json:"password" binding:"required"`
 }
+
+type RefreshRequestBody struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequestBody struct {
+	RefreshToken string `json:"refresh_token"`
+}
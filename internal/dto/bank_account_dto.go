@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+)
+
+type CreateBankAccountRequestBody struct {
+	BankCode      string `json:"bank_code"      binding:"required,alphanum,max=10"`
+	AccountNumber string `json:"account_number" binding:"required,numeric,min=6,max=20"`
+	AccountName   string `json:"account_name"   binding:"required,max=100"`
+}
+
+type FormattedBankAccount struct {
+	ID            int       `json:"id"`
+	BankCode      string    `json:"bank_code"`
+	AccountNumber string    `json:"account_number"`
+	AccountName   string    `json:"account_name"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func FormatBankAccount(bankAccount *entity.BankAccount) *FormattedBankAccount {
+	return &FormattedBankAccount{
+		ID:            bankAccount.ID,
+		BankCode:      bankAccount.BankCode,
+		AccountNumber: bankAccount.AccountNumber,
+		AccountName:   bankAccount.AccountName,
+		CreatedAt:     bankAccount.CreatedAt,
+	}
+}
+
+func FormatMultipleBankAccount(
+	bankAccounts []*entity.BankAccount,
+) []*FormattedBankAccount {
+	formattedBankAccounts := []*FormattedBankAccount{}
+	for _, bankAccount := range bankAccounts {
+		formattedBankAccounts = append(
+			formattedBankAccounts,
+			FormatBankAccount(bankAccount),
+		)
+	}
+
+	return formattedBankAccounts
+}
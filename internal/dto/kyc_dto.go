@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"mime/multipart"
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+)
+
+const (
+	KYC_DATE_OF_BIRTH_LAYOUT = "2006-01-02"
+)
+
+type SubmitKycRequestBody struct {
+	FullName         string                `form:"full_name"         binding:"required,max=100"`
+	IdentityNumber   string                `form:"identity_number"   binding:"required,alphanum,max=32"`
+	DateOfBirth      string                `form:"date_of_birth"     binding:"required,datetime=2006-01-02"`
+	Address          string                `form:"address"           binding:"required,max=255"`
+	IdentityDocument *multipart.FileHeader `form:"identity_document" binding:"required"`
+	Selfie           *multipart.FileHeader `form:"selfie"            binding:"required"`
+}
+
+type RejectKycRequestBody struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+type FormattedKycSubmission struct {
+	ID              int              `json:"id"`
+	UserID          int              `json:"user_id"`
+	FullName        string           `json:"full_name"`
+	IdentityNumber  string           `json:"identity_number"`
+	DateOfBirth     string           `json:"date_of_birth"`
+	Address         string           `json:"address"`
+	Status          entity.KycStatus `json:"status"`
+	RejectionReason string           `json:"rejection_reason,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	ReviewedAt      *time.Time       `json:"reviewed_at,omitempty"`
+}
+
+func FormatKycSubmission(
+	submission *entity.KycSubmission,
+) *FormattedKycSubmission {
+	return &FormattedKycSubmission{
+		ID:              submission.ID,
+		UserID:          submission.UserID,
+		FullName:        submission.FullName,
+		IdentityNumber:  submission.IdentityNumber,
+		DateOfBirth:     submission.DateOfBirth.Format(KYC_DATE_OF_BIRTH_LAYOUT),
+		Address:         submission.Address,
+		Status:          submission.Status,
+		RejectionReason: submission.RejectionReason,
+		CreatedAt:       submission.CreatedAt,
+		ReviewedAt:      submission.ReviewedAt,
+	}
+}
+
+func FormatMultipleKycSubmission(
+	submissions []*entity.KycSubmission,
+) []*FormattedKycSubmission {
+	formattedSubmissions := []*FormattedKycSubmission{}
+	for _, submission := range submissions {
+		formattedSubmissions = append(
+			formattedSubmissions,
+			FormatKycSubmission(submission),
+		)
+	}
+
+	return formattedSubmissions
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+)
+
+type CreatePaymentRequestRequestBody struct {
+	Description string `json:"description"`
+	From        int    `json:"from"        binding:"required"`
+	Amount      int    `json:"amount"      binding:"required"`
+}
+
+type ApprovePaymentRequestRequestBody struct {
+	Pin string `json:"pin" binding:"required"`
+}
+
+type FormattedPaymentRequest struct {
+	ID            int                         `json:"id"`
+	Requester     int                         `json:"requester"`
+	Payer         int                         `json:"payer"`
+	Amount        int                         `json:"amount"`
+	Description   string                      `json:"description,omitempty"`
+	Status        entity.PaymentRequestStatus `json:"status"`
+	CreatedAt     time.Time                   `json:"created_at"`
+	ExpiresAt     time.Time                   `json:"expires_at"`
+	TransactionID *int                        `json:"transaction_id,omitempty"`
+}
+
+func FormatPaymentRequest(
+	paymentRequest *entity.PaymentRequest,
+) *FormattedPaymentRequest {
+	return &FormattedPaymentRequest{
+		ID:            paymentRequest.ID,
+		Requester:     paymentRequest.RequesterNumber,
+		Payer:         paymentRequest.PayerNumber,
+		Amount:        paymentRequest.Amount,
+		Description:   paymentRequest.Description,
+		Status:        paymentRequest.CurrentStatus(time.Now()),
+		CreatedAt:     paymentRequest.CreatedAt,
+		ExpiresAt:     paymentRequest.ExpiresAt,
+		TransactionID: paymentRequest.TransactionID,
+	}
+}
+
+func FormatMultiplePaymentRequest(
+	paymentRequests []*entity.PaymentRequest,
+) []*FormattedPaymentRequest {
+	formattedPaymentRequests := []*FormattedPaymentRequest{}
+	for _, paymentRequest := range paymentRequests {
+		formattedPaymentRequests = append(
+			formattedPaymentRequests,
+			FormatPaymentRequest(paymentRequest),
+		)
+	}
+
+	return formattedPaymentRequests
+}
+
+type ApprovePaymentRequestResponseBody struct {
+	PaymentRequest *FormattedPaymentRequest `json:"payment_request"`
+	Transaction    *FormattedTransaction    `json:"transaction"`
+}
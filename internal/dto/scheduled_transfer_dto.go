@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+)
+
+type ScheduledTransferRequestBody struct {
+	Description string            `json:"description"`
+	To          int               `json:"to"          binding:"required"`
+	Amount      int               `json:"amount"      binding:"required"`
+	StartAt     time.Time         `json:"start_at"    binding:"required"`
+	Recurrence  entity.Recurrence `json:"recurrence"  binding:"required"`
+	Pin         string            `json:"pin"         binding:"required"`
+}
+
+type FormattedScheduledTransfer struct {
+	ID          int                            `json:"id"`
+	From        int                            `json:"from"`
+	To          int                            `json:"to"`
+	Amount      int                            `json:"amount"`
+	Description string                         `json:"description,omitempty"`
+	Recurrence  entity.Recurrence              `json:"recurrence"`
+	StartAt     time.Time                      `json:"start_at"`
+	NextRunAt   *time.Time                     `json:"next_run_at"`
+	Status      entity.ScheduledTransferStatus `json:"status"`
+	Runs        []*FormattedScheduledRun       `json:"runs,omitempty"`
+}
+
+type FormattedScheduledRun struct {
+	ScheduledAt   time.Time                 `json:"scheduled_at"`
+	Status        entity.ScheduledRunStatus `json:"status"`
+	TransactionID *int                      `json:"transaction_id,omitempty"`
+	FailureReason string                    `json:"failure_reason,omitempty"`
+}
+
+func FormatScheduledTransfer(
+	scheduledTransfer *entity.ScheduledTransfer,
+) *FormattedScheduledTransfer {
+	formattedScheduledTransfer := &FormattedScheduledTransfer{
+		ID:          scheduledTransfer.ID,
+		From:        scheduledTransfer.From,
+		To:          scheduledTransfer.To,
+		Amount:      scheduledTransfer.Amount,
+		Description: scheduledTransfer.Description,
+		Recurrence:  scheduledTransfer.Recurrence,
+		StartAt:     scheduledTransfer.StartAt,
+		NextRunAt:   scheduledTransfer.NextRunAt,
+		Status:      scheduledTransfer.Status,
+	}
+
+	for _, run := range scheduledTransfer.Runs {
+		formattedScheduledTransfer.Runs = append(
+			formattedScheduledTransfer.Runs,
+			&FormattedScheduledRun{
+				ScheduledAt:   run.ScheduledAt,
+				Status:        run.Status,
+				TransactionID: run.TransactionID,
+				FailureReason: run.FailureReason,
+			},
+		)
+	}
+
+	return formattedScheduledTransfer
+}
+
+func FormatMultipleScheduledTransfer(
+	scheduledTransfers []*entity.ScheduledTransfer,
+) []*FormattedScheduledTransfer {
+	formattedScheduledTransfers := []*FormattedScheduledTransfer{}
+	for _, scheduledTransfer := range scheduledTransfers {
+		formattedScheduledTransfers = append(
+			formattedScheduledTransfers,
+			FormatScheduledTransfer(scheduledTransfer),
+		)
+	}
+
+	return formattedScheduledTransfers
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "assignment-golang-backend/internal/entity"
+
+type FormattedSourceOfFunds struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	MinTopupAmount int    `json:"min_topup_amount"`
+	MaxTopupAmount int    `json:"max_topup_amount"`
+}
+
+func FormatSourceOfFunds(source *entity.SourceOfFunds) *FormattedSourceOfFunds {
+	return &FormattedSourceOfFunds{
+		ID:             source.ID,
+		Name:           source.Name,
+		MinTopupAmount: source.MinTopupAmount,
+		MaxTopupAmount: source.MaxTopupAmount,
+	}
+}
+
+func FormatMultipleSourceOfFunds(
+	sources []*entity.SourceOfFunds,
+) []*FormattedSourceOfFunds {
+	formattedSources := []*FormattedSourceOfFunds{}
+	for _, source := range sources {
+		formattedSources = append(
+			formattedSources,
+			FormatSourceOfFunds(source),
+		)
+	}
+
+	return formattedSources
+}
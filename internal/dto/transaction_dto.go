@@ -6,6 +6,10 @@ import (
 	"assignment-golang-backend/internal/entity"
 )
 
+const (
+	TRANSACTION_HISTORY_DATE_LAYOUT = "2006-01-02"
+)
+
 type TopupRequestBody struct {
 	Amount   int                    `json:"amount"    binding:"required"`
 	SourceID entity.SourceOfFundsID `json:"source_id" binding:"required"`
@@ -13,8 +17,74 @@ type TopupRequestBody struct {
 
 type TransferRequestBody struct {
 	Description string `json:"description"`
+	From        int    `json:"from"`
 	To          int    `json:"To"          binding:"required"`
-	Amount      int    `json:"amount"      binding:"required"`
+	Amount      int    `json:"amount"      binding:"required,min=1"`
+	Pin         string `json:"pin"         binding:"required"`
+}
+
+type RefundRequestBody struct {
+	Description string `json:"description"`
+	From        int    `json:"from"`
+	Amount      int    `json:"amount"      binding:"required,min=1"`
+	Pin         string `json:"pin"         binding:"required"`
+}
+
+type WithdrawRequestBody struct {
+	BankAccountID int    `json:"bank_account_id" binding:"required"`
+	Amount        int    `json:"amount"          binding:"required"`
+	Pin           string `json:"pin"             binding:"required"`
+}
+
+// TransactionHistoryQuery filters the transaction history. From and To are
+// dates and both days are included.
+type TransactionHistoryQuery struct {
+	Search       string                      `form:"s"`
+	From         string                      `form:"from"         binding:"omitempty,datetime=2006-01-02"`
+	To           string                      `form:"to"           binding:"omitempty,datetime=2006-01-02"`
+	Type         entity.TransactionType      `form:"type"         binding:"omitempty,oneof=TRANSFER TOP_UP REFUND WITHDRAWAL"`
+	Direction    entity.TransactionDirection `form:"direction"    binding:"omitempty,oneof=incoming outgoing"`
+	MinAmount    *int                        `form:"min_amount"   binding:"omitempty,min=0"`
+	MaxAmount    *int                        `form:"max_amount"   binding:"omitempty,min=0"`
+	Counterparty int                         `form:"counterparty" binding:"omitempty,min=1"`
+}
+
+// StatementQuery picks the wallet, period and format of a statement. From
+// and To are dates and both days are included.
+type StatementQuery struct {
+	WalletNumber int    `form:"wallet_number" binding:"omitempty,min=1"`
+	Format       string `form:"format"        binding:"omitempty,oneof=csv pdf"`
+	From         string `form:"from"          binding:"required,datetime=2006-01-02"`
+	To           string `form:"to"            binding:"required,datetime=2006-01-02"`
+}
+
+type QuoteFeeQuery struct {
+	Type     entity.TransactionType  `form:"type"      binding:"required,oneof=TRANSFER TOP_UP"`
+	Amount   int                     `form:"amount"    binding:"required,min=1"`
+	SourceID *entity.SourceOfFundsID `form:"source_id" binding:"required_if=Type TOP_UP"`
+	Currency entity.Currency         `form:"currency"`
+}
+
+type FormattedFeeQuote struct {
+	Type        entity.TransactionType  `json:"type"`
+	SourceID    *entity.SourceOfFundsID `json:"source_id,omitempty"`
+	Currency    entity.Currency         `json:"currency"`
+	Amount      int                     `json:"amount"`
+	Fee         int                     `json:"fee"`
+	GrossAmount int                     `json:"gross_amount"`
+	NetAmount   int                     `json:"net_amount"`
+}
+
+func FormatFeeQuote(transaction *entity.Transaction) *FormattedFeeQuote {
+	return &FormattedFeeQuote{
+		Type:        transaction.Type,
+		SourceID:    transaction.SourceID,
+		Currency:    transaction.Currency,
+		Amount:      transaction.Amount,
+		Fee:         transaction.Fee,
+		GrossAmount: transaction.Amount + transaction.Fee,
+		NetAmount:   transaction.Amount,
+	}
 }
 
 type GetTransactionsByWalletNumberResponseBody struct {
@@ -22,15 +92,33 @@ type GetTransactionsByWalletNumberResponseBody struct {
 	Rows       []*FormattedTransaction `json:"rows"`
 }
 
+type GetTransactionsAfterCursorResponseBody struct {
+	Pagination entity.CursorPagination `json:"pagination"`
+	Rows       []*FormattedTransaction `json:"rows"`
+}
+
 type FormattedTransaction struct {
-	ID          int
-	Amount      int                    `json:"amount"`
-	Description string                 `json:"description,omitempty"`
-	Type        entity.TransactionType `json:"type"`
-	Datetime    time.Time              `json:"datetime"`
-	Source      string                 `json:"source,omitempty"`
-	From        int                    `json:"from,omitempty"`
-	To          int                    `json:"to,omitempty"`
+	ID                int                      `json:"id"`
+	Amount            int                      `json:"amount"`
+	Currency          entity.Currency          `json:"currency,omitempty"`
+	Description       string                   `json:"description,omitempty"`
+	Type              entity.TransactionType   `json:"type"`
+	Status            entity.TransactionStatus `json:"status,omitempty"`
+	Datetime          time.Time                `json:"datetime"`
+	Source            string                   `json:"source,omitempty"`
+	Fee               int                      `json:"fee,omitempty"`
+	GrossAmount       int                      `json:"gross_amount,omitempty"`
+	NetAmount         int                      `json:"net_amount,omitempty"`
+	From              int                      `json:"from,omitempty"`
+	To                int                      `json:"to,omitempty"`
+	ConvertedAmount   *int                     `json:"converted_amount,omitempty"`
+	ConvertedCurrency entity.Currency          `json:"converted_currency,omitempty"`
+	ExchangeRate      string                   `json:"exchange_rate,omitempty"`
+	ReferenceID       *int                     `json:"reference_id,omitempty"`
+	RefundedAmount    int                      `json:"refunded_amount,omitempty"`
+	BankAccountID     *int                     `json:"bank_account_id,omitempty"`
+	ProviderReference string                   `json:"provider_reference,omitempty"`
+	FailureReason     string                   `json:"failure_reason,omitempty"`
 }
 
 func FormatGetTransaction(
@@ -39,22 +127,48 @@ func FormatGetTransaction(
 ) *FormattedTransaction {
 	ResponseBody := &FormattedTransaction{
 		ID:          transaction.ID,
+		Currency:    transaction.Currency,
 		Description: transaction.Description,
 		Type:        transaction.Type,
+		Status:      transaction.Status,
 		Datetime:    transaction.Datetime,
 		To:          transaction.To,
 	}
 	if transaction.Type == entity.TopUp {
 		ResponseBody.Amount = transaction.Amount
-		ResponseBody.Source = entity.SourceOfFundsID(*transaction.SourceID).
-			String()
-	} else if transaction.Type == entity.Transfer {
+		if transaction.SourceOfFunds != nil {
+			ResponseBody.Source = transaction.SourceOfFunds.Name
+		}
+		setFeeAmounts(ResponseBody, transaction)
+		ResponseBody.ProviderReference = transaction.ProviderReference
+		ResponseBody.FailureReason = transaction.FailureReason
+	} else if transaction.Type == entity.Transfer ||
+		transaction.Type == entity.Refund {
 		ResponseBody.From = transaction.From
+		ResponseBody.ReferenceID = transaction.ReferenceID
+		ResponseBody.RefundedAmount = transaction.RefundedAmount
+		ResponseBody.ConvertedAmount = transaction.ConvertedAmount
+		ResponseBody.ConvertedCurrency = transaction.ConvertedCurrency
+		ResponseBody.ExchangeRate = transaction.ExchangeRate
+		setFeeAmounts(ResponseBody, transaction)
 		if sourceWalletNumber == transaction.From {
-			ResponseBody.Amount = -transaction.Amount
+			// The sender pays the fee on top of the amount.
+			ResponseBody.Amount = -ResponseBody.GrossAmount
 		} else {
-			ResponseBody.Amount = transaction.Amount
+			// The receiver gets the amount in the currency of its wallet.
+			ResponseBody.Amount = transaction.ReceivedAmount()
+			if transaction.ConvertedAmount != nil {
+				ResponseBody.Currency = transaction.ConvertedCurrency
+			}
 		}
+	} else if transaction.Type == entity.Withdrawal {
+		// Withdrawals are stored to their own wallet, the money goes to
+		// the bank account.
+		ResponseBody.Amount = -transaction.Amount
+		ResponseBody.From = transaction.From
+		ResponseBody.To = 0
+		ResponseBody.BankAccountID = transaction.BankAccountID
+		ResponseBody.FailureReason = transaction.FailureReason
 	} else {
 		ResponseBody.Amount = transaction.Amount
 	}
@@ -62,6 +176,17 @@ func FormatGetTransaction(
 	return ResponseBody
 }
 
+// setFeeAmounts shows what the payer pays, the fee on top of the amount, as
+// the gross amount and what the receiver gets as the net amount.
+func setFeeAmounts(
+	responseBody *FormattedTransaction,
+	transaction *entity.Transaction,
+) {
+	responseBody.Fee = transaction.Fee
+	responseBody.GrossAmount = transaction.Amount + transaction.Fee
+	responseBody.NetAmount = transaction.Amount
+}
+
 func FormatMultipleGetTransaction(
 	transactions []*entity.Transaction,
 	sourceWalletNumber int,
@@ -92,3 +217,56 @@ func FormatGetTransactionsByWalletNumberResponseBody(
 		Rows:       transactionsFormatted,
 	}
 }
+
+func FormatGetTransactionsAfterCursorResponseBody(
+	transactions []*entity.Transaction,
+	pagination *entity.CursorPagination,
+	sourceWalletNumber int,
+) *GetTransactionsAfterCursorResponseBody {
+	return &GetTransactionsAfterCursorResponseBody{
+		Pagination: *pagination,
+		Rows: FormatMultipleGetTransaction(
+			transactions,
+			sourceWalletNumber,
+		),
+	}
+}
+
+// FormattedTransactionDetail is a transaction as the user sees it from their
+// wallet that sent or received it, with the balance of that wallet right
+// after it.
+type FormattedTransactionDetail struct {
+	FormattedTransaction
+	SenderName    string                  `json:"sender_name,omitempty"`
+	RecipientName string                  `json:"recipient_name,omitempty"`
+	SourceOfFunds *FormattedSourceOfFunds `json:"source_of_funds,omitempty"`
+	BalanceAfter  *int                    `json:"balance_after,omitempty"`
+}
+
+// FormatTransactionDetail shows the transaction from the wallet of the user
+// that sent it, or from the one that received it when the user did not
+// send it. Top ups are always shown from the wallet they credited.
+func FormatTransactionDetail(
+	detail *entity.TransactionDetail,
+	userID int,
+) *FormattedTransactionDetail {
+	walletNumber := detail.To
+	balanceAfter := detail.ToBalanceAfter
+	if detail.Type != entity.TopUp && detail.FromWallet.IsOwnedBy(userID) {
+		walletNumber = detail.From
+		balanceAfter = detail.FromBalanceAfter
+	}
+
+	formatted := &FormattedTransactionDetail{
+		FormattedTransaction: *FormatGetTransaction(detail.Transaction, walletNumber),
+		SenderName:           detail.SenderName,
+		RecipientName:        detail.RecipientName,
+		BalanceAfter:         balanceAfter,
+	}
+
+	if detail.SourceOfFunds != nil {
+		formatted.SourceOfFunds = FormatSourceOfFunds(detail.SourceOfFunds)
+	}
+
+	return formatted
+}
@@ -2,6 +2,20 @@ package dto
 
 import "assignment-golang-backend/internal/entity"
 
+type SetPinRequestBody struct {
+	Pin string `json:"pin" binding:"required"`
+}
+
+type ChangePinRequestBody struct {
+	OldPin string `json:"old_pin" binding:"required"`
+	NewPin string `json:"new_pin" binding:"required"`
+}
+
+type ResetPinRequestBody struct {
+	Password string `json:"password" binding:"required"`
+	NewPin   string `json:"new_pin"  binding:"required"`
+}
+
 func FormatUser(user *entity.User) *entity.User {
 	return &entity.User{
 		Base: entity.Base{
@@ -9,6 +23,8 @@ func FormatUser(user *entity.User) *entity.User {
 		},
 		Name:         user.Name,
 		Email:        user.Email,
+		Tier:         user.Tier,
+		KycStatus:    user.KycStatus,
 		WalletNumber: user.WalletNumber,
 		Wallet:       *FormatWallet(&user.Wallet),
 	}
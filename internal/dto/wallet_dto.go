@@ -1,13 +1,46 @@
 package dto
 
-import "assignment-golang-backend/internal/entity"
+import (
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+)
+
+type CreateWalletRequestBody struct {
+	Name       string          `json:"name"        binding:"required,max=50"`
+	Currency   entity.Currency `json:"currency"    binding:"omitempty,len=3"`
+	GoalAmount *int            `json:"goal_amount" binding:"omitempty,min=1"`
+	GoalDate   *time.Time      `json:"goal_date"`
+}
+
+type UpdateWalletStatusRequestBody struct {
+	Status        entity.WalletStatus `json:"status"          binding:"required,oneof=ACTIVE FROZEN SUSPENDED CLOSED"`
+	Reason        string              `json:"reason"          binding:"required,max=255"`
+	BankAccountID *int                `json:"bank_account_id" binding:"omitempty,min=1"`
+}
 
 func FormatWallet(wallet *entity.Wallet) *entity.Wallet {
 	return &entity.Wallet{
 		Base: entity.Base{
 			ID: wallet.ID,
 		},
-		Number:  wallet.Number,
-		Balance: wallet.Balance,
+		Number:          wallet.Number,
+		Balance:         wallet.Balance,
+		Currency:        wallet.Currency,
+		Name:            wallet.Name,
+		GoalAmount:      wallet.GoalAmount,
+		GoalDate:        wallet.GoalDate,
+		Status:          wallet.Status,
+		StatusReason:    wallet.StatusReason,
+		StatusChangedAt: wallet.StatusChangedAt,
 	}
 }
+
+func FormatMultipleWallet(wallets []*entity.Wallet) []*entity.Wallet {
+	formattedWallets := []*entity.Wallet{}
+	for _, wallet := range wallets {
+		formattedWallets = append(formattedWallets, FormatWallet(wallet))
+	}
+
+	return formattedWallets
+}
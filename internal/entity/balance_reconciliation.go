@@ -0,0 +1,9 @@
+package entity
+
+type BalanceReconciliation struct {
+	WalletNumber       int  `json:"wallet_number"`
+	WalletBalance      int  `json:"wallet_balance"`
+	TransactionBalance int  `json:"transaction_balance"`
+	Difference         int  `json:"difference"`
+	Repaired           bool `json:"repaired"`
+}
@@ -0,0 +1,9 @@
+package entity
+
+type BankAccount struct {
+	Base
+	UserID        int    `json:"user_id"        gorm:"index"`
+	BankCode      string `json:"bank_code"`
+	AccountNumber string `json:"account_number"`
+	AccountName   string `json:"account_name"`
+}
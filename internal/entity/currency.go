@@ -0,0 +1,55 @@
+package entity
+
+// Currency is the ISO 4217 code of the currency a wallet holds. Amounts are
+// whole numbers of the currency's minor unit, e.g. cents of USD, except for
+// IDR, which has always been kept in whole rupiah.
+type Currency string
+
+const (
+	IDR Currency = "IDR"
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	SGD Currency = "SGD"
+)
+
+// BaseCurrency is the currency of the wallet every user starts with, and
+// the only currency top ups and withdrawals move.
+const BaseCurrency = IDR
+
+type currencyInfo struct {
+	exponent          int
+	houseWalletNumber int
+}
+
+// currencies are the supported currencies with the digits of their minor
+// unit and the number of the house wallet their fees are collected into.
+// House wallets are numbered down from HouseWalletNumber, below the wallets
+// of users.
+var currencies = map[Currency]currencyInfo{
+	IDR: {exponent: 0, houseWalletNumber: HouseWalletNumber},
+	USD: {exponent: 2, houseWalletNumber: HouseWalletNumber - 1},
+	EUR: {exponent: 2, houseWalletNumber: HouseWalletNumber - 2},
+	SGD: {exponent: 2, houseWalletNumber: HouseWalletNumber - 3},
+}
+
+func (c Currency) IsSupported() bool {
+	_, ok := currencies[c]
+	return ok
+}
+
+// Exponent is the digits of the minor unit amounts of the currency are
+// kept in.
+func (c Currency) Exponent() int {
+	return currencies[c].exponent
+}
+
+// HouseWalletNumber is the wallet fees paid in the currency are collected
+// into.
+func (c Currency) HouseWalletNumber() int {
+	return currencies[c].houseWalletNumber
+}
+
+// SupportedCurrencies lists every currency a wallet can hold.
+func SupportedCurrencies() []Currency {
+	return []Currency{IDR, USD, EUR, SGD}
+}
@@ -0,0 +1,23 @@
+package entity
+
+// FeeRule prices the fee of transactions of Type paid from a wallet in
+// Currency whose amount is between MinAmount and MaxAmount, where a
+// MaxAmount of 0 has no upper bound. A rule with a SourceID only prices top
+// ups from that source of funds and wins over a rule without one. The fee
+// is FlatFee plus PercentageBasisPoints hundredths of a percent of the
+// amount, so rules for adjacent amount bands make a tiered schedule.
+type FeeRule struct {
+	Base
+	Type                  TransactionType  `json:"type"                    gorm:"not null;index"`
+	Currency              Currency         `json:"currency"                gorm:"not null;default:'IDR'"`
+	SourceID              *SourceOfFundsID `json:"source_id,omitempty"`
+	MinAmount             int              `json:"min_amount"              gorm:"not null;default:0"`
+	MaxAmount             int              `json:"max_amount"              gorm:"not null;default:0"`
+	FlatFee               int              `json:"flat_fee"                gorm:"not null;default:0"`
+	PercentageBasisPoints int              `json:"percentage_basis_points" gorm:"not null;default:0"`
+}
+
+// Fee is what the rule charges on amount, with the percentage rounded down.
+func (r *FeeRule) Fee(amount int) int {
+	return r.FlatFee + amount*r.PercentageBasisPoints/10000
+}
@@ -0,0 +1,10 @@
+package entity
+
+type IdempotencyKey struct {
+	Base
+	UserID       int    `json:"user_id" gorm:"uniqueIndex:idx_idempotency_keys_user_id_key"`
+	Key          string `json:"key"     gorm:"uniqueIndex:idx_idempotency_keys_user_id_key"`
+	RequestHash  string `json:"-"`
+	ResponseCode int    `json:"-"`
+	ResponseBody []byte `json:"-"`
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+type JournalEntry struct {
+	Base
+	TransactionID *int      `json:"transaction_id,omitempty" gorm:"index"`
+	Description   string    `json:"description"`
+	Datetime      time.Time `json:"datetime"`
+	Postings      []Posting `json:"postings"`
+}
+
+type Posting struct {
+	Base
+	JournalEntryID int               `json:"-"              gorm:"index"`
+	AccountType    LedgerAccountType `json:"account_type"   gorm:"index:idx_postings_account"`
+	AccountNumber  int               `json:"account_number" gorm:"index:idx_postings_account"`
+	Direction      PostingDirection  `json:"direction"`
+	Amount         int               `json:"amount"`
+}
+
+type LedgerAccountType string
+
+const (
+	WalletAccount         LedgerAccountType = "WALLET"
+	FundingSourceAccount  LedgerAccountType = "FUNDING_SOURCE"
+	OpeningBalanceAccount LedgerAccountType = "OPENING_BALANCE"
+	AdjustmentAccount     LedgerAccountType = "ADJUSTMENT"
+	PayoutAccount         LedgerAccountType = "PAYOUT"
+	ExchangeAccount       LedgerAccountType = "EXCHANGE"
+)
+
+type PostingDirection string
+
+const (
+	Debit  PostingDirection = "DEBIT"
+	Credit PostingDirection = "CREDIT"
+)
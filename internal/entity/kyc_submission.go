@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// KycSubmission is the identity data and documents a user sent for review.
+// Its Status is PENDING until an admin approves it as VERIFIED or rejects
+// it as REJECTED. The documents are kept in the file storage under the
+// paths stored here.
+type KycSubmission struct {
+	Base
+	UserID               int        `json:"user_id"                    gorm:"index"`
+	FullName             string     `json:"full_name"`
+	IdentityNumber       string     `json:"identity_number"`
+	DateOfBirth          time.Time  `json:"date_of_birth"              gorm:"type:date"`
+	Address              string     `json:"address"`
+	IdentityDocumentPath string     `json:"-"`
+	SelfiePath           string     `json:"-"`
+	Status               KycStatus  `json:"status"                     gorm:"index"`
+	RejectionReason      string     `json:"rejection_reason,omitempty"`
+	ReviewedBy           *int       `json:"reviewed_by,omitempty"`
+	ReviewedAt           *time.Time `json:"reviewed_at,omitempty"`
+}
+
+type KycDocument string
+
+const (
+	KycIdentityDocument KycDocument = "identity-document"
+	KycSelfie           KycDocument = "selfie"
+)
+
+// DocumentPath returns where the document is stored, or false for a
+// document the submission does not have.
+func (k *KycSubmission) DocumentPath(document KycDocument) (string, bool) {
+	switch document {
+	case KycIdentityDocument:
+		return k.IdentityDocumentPath, true
+	case KycSelfie:
+		return k.SelfiePath, true
+	default:
+		return "", false
+	}
+}
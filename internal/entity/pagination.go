@@ -1,11 +1,57 @@
 package entity
 
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
 type Pagination struct {
-	Limit      int    `json:"limit"`
-	Page       int    `json:"page"`
-	Search     string `json:"search,omitempty"`
-	Sort       string `json:"sort"`
-	SortBy     string `json:"sort_by"`
-	TotalRows  int    `json:"total_rows"`
-	TotalPages int    `json:"total_pages"`
+	Limit      int  `json:"limit"`
+	Page       int  `json:"page"`
+	Sort       Sort `json:"sort"`
+	TotalRows  int  `json:"total_rows"`
+	TotalPages int  `json:"total_pages"`
+}
+
+// CursorPagination pages through transactions by their position rather
+// than an offset, so transactions that arrive while paging are neither
+// skipped nor repeated. After is the last transaction of the previous page,
+// nil on the first one, and NextCursor is empty on the last page.
+type CursorPagination struct {
+	Limit      int                `json:"limit"`
+	Sort       string             `json:"sort"`
+	After      *TransactionCursor `json:"-"`
+	NextCursor string             `json:"next_cursor"`
+}
+
+// TransactionCursor is the position of a transaction in a history ordered
+// by datetime, with the ID breaking ties.
+type TransactionCursor struct {
+	Datetime time.Time
+	ID       int
+}
+
+// Encode returns the cursor as an opaque string for clients.
+func (c *TransactionCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%d.%d", c.Datetime.UnixNano(), c.ID)),
+	)
+}
+
+// DecodeTransactionCursor reads a cursor returned by Encode.
+func DecodeTransactionCursor(cursor string) (*TransactionCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var nanoseconds int64
+	var id int
+	_, err = fmt.Sscanf(string(decoded), "%d.%d", &nanoseconds, &id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionCursor{Datetime: time.Unix(0, nanoseconds), ID: id}, nil
 }
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type PaymentRequest struct {
+	Base
+	RequesterNumber int                  `json:"requester_number" gorm:"index"`
+	RequesterWallet Wallet               `json:"-"                gorm:"references:Number;foreignKey:RequesterNumber;constraint:OnUpdate:CASCADE"`
+	PayerNumber     int                  `json:"payer_number"     gorm:"index"`
+	PayerWallet     Wallet               `json:"-"                gorm:"references:Number;foreignKey:PayerNumber;constraint:OnUpdate:CASCADE"`
+	Amount          int                  `json:"amount"`
+	Description     string               `json:"description,omitempty"`
+	Status          PaymentRequestStatus `json:"status"`
+	ExpiresAt       time.Time            `json:"expires_at"`
+	TransactionID   *int                 `json:"transaction_id,omitempty"`
+}
+
+type PaymentRequestStatus string
+
+const (
+	PaymentRequestPending  PaymentRequestStatus = "PENDING"
+	PaymentRequestApproved PaymentRequestStatus = "APPROVED"
+	PaymentRequestDeclined PaymentRequestStatus = "DECLINED"
+	PaymentRequestExpired  PaymentRequestStatus = "EXPIRED"
+)
+
+// CurrentStatus reports a pending request past its expiry as expired. The
+// stored status is never updated to EXPIRED, expiry is derived on read.
+func (p *PaymentRequest) CurrentStatus(now time.Time) PaymentRequestStatus {
+	if p.Status == PaymentRequestPending && !now.Before(p.ExpiresAt) {
+		return PaymentRequestExpired
+	}
+
+	return p.Status
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+type RefreshToken struct {
+	Base
+	UserID    int        `json:"user_id"    gorm:"index"`
+	TokenHash string     `json:"-"          gorm:"uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+type RevokedToken struct {
+	Base
+	JTI       string    `json:"jti"        gorm:"uniqueIndex"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
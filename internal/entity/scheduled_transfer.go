@@ -0,0 +1,112 @@
+package entity
+
+import "time"
+
+type ScheduledTransfer struct {
+	Base
+	UserID      int                     `json:"user_id"               gorm:"index"`
+	From        int                     `json:"from"                  gorm:"column:from_number"`
+	To          int                     `json:"to"                    gorm:"column:to_number"`
+	Amount      int                     `json:"amount"`
+	Description string                  `json:"description,omitempty"`
+	Recurrence  Recurrence              `json:"recurrence"`
+	StartAt     time.Time               `json:"start_at"`
+	NextRunAt   *time.Time              `json:"next_run_at"           gorm:"index"`
+	Occurrence  int                     `json:"-"`
+	Status      ScheduledTransferStatus `json:"status"`
+	Runs        []ScheduledTransferRun  `json:"runs,omitempty"`
+}
+
+type ScheduledTransferRun struct {
+	Base
+	ScheduledTransferID int                `json:"scheduled_transfer_id"    gorm:"index"`
+	ScheduledAt         time.Time          `json:"scheduled_at"`
+	Status              ScheduledRunStatus `json:"status"`
+	TransactionID       *int               `json:"transaction_id,omitempty"`
+	FailureReason       string             `json:"failure_reason,omitempty"`
+}
+
+type Recurrence string
+
+const (
+	RecurrenceOnce    Recurrence = "ONCE"
+	RecurrenceDaily   Recurrence = "DAILY"
+	RecurrenceWeekly  Recurrence = "WEEKLY"
+	RecurrenceMonthly Recurrence = "MONTHLY"
+)
+
+func (r Recurrence) IsValid() bool {
+	switch r {
+	case RecurrenceOnce, RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+		return true
+	default:
+		return false
+	}
+}
+
+type ScheduledTransferStatus string
+
+const (
+	ScheduledTransferActive    ScheduledTransferStatus = "ACTIVE"
+	ScheduledTransferCompleted ScheduledTransferStatus = "COMPLETED"
+	ScheduledTransferCancelled ScheduledTransferStatus = "CANCELLED"
+)
+
+type ScheduledRunStatus string
+
+const (
+	ScheduledRunSucceeded ScheduledRunStatus = "SUCCEEDED"
+	ScheduledRunFailed    ScheduledRunStatus = "FAILED"
+)
+
+// OccurrenceAt returns the n-th run time counted from StartAt, which is
+// occurrence 0. Monthly runs keep the day of StartAt and fall back to the
+// last day of shorter months, so a transfer starting on the 31st runs on
+// the 30th in April instead of drifting into May.
+func (s *ScheduledTransfer) OccurrenceAt(n int) time.Time {
+	switch s.Recurrence {
+	case RecurrenceDaily:
+		return s.StartAt.AddDate(0, 0, n)
+	case RecurrenceWeekly:
+		return s.StartAt.AddDate(0, 0, 7*n)
+	case RecurrenceMonthly:
+		year, month, day := s.StartAt.Date()
+		firstOfMonth := time.Date(
+			year,
+			month+time.Month(n),
+			1,
+			s.StartAt.Hour(),
+			s.StartAt.Minute(),
+			s.StartAt.Second(),
+			s.StartAt.Nanosecond(),
+			s.StartAt.Location(),
+		)
+		lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+		if day > lastDay {
+			day = lastDay
+		}
+		return firstOfMonth.AddDate(0, 0, day-1)
+	default:
+		return s.StartAt
+	}
+}
+
+// NextOccurrenceAfter returns the index and time of the first occurrence
+// after the current one that is later than now. ok is false when the
+// schedule has no more runs.
+func (s *ScheduledTransfer) NextOccurrenceAfter(
+	now time.Time,
+) (n int, runAt time.Time, ok bool) {
+	if s.Recurrence == RecurrenceOnce {
+		return 0, time.Time{}, false
+	}
+
+	n = s.Occurrence + 1
+	runAt = s.OccurrenceAt(n)
+	for !runAt.After(now) {
+		n++
+		runAt = s.OccurrenceAt(n)
+	}
+
+	return n, runAt, true
+}
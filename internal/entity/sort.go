@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// TransactionSortFields are the fields the transaction history can be
+// sorted by.
+var TransactionSortFields = []string{"datetime", "amount", "id", "type"}
+
+// SortField orders a list by one field.
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// Sort orders a list by its fields in turn, each later field breaking the
+// ties of the ones before it.
+type Sort []SortField
+
+// String returns the sort in the form clients send it, such as
+// "-amount,datetime", with descending fields prefixed by a minus sign.
+func (s Sort) String() string {
+	fields := make([]string, len(s))
+	for i, field := range s {
+		fields[i] = field.Field
+		if field.Descending {
+			fields[i] = "-" + field.Field
+		}
+	}
+
+	return strings.Join(fields, ",")
+}
+
+func (s Sort) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Has reports whether the sort already orders by field.
+func (s Sort) Has(field string) bool {
+	for _, sortField := range s {
+		if sortField.Field == field {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,17 @@
+package entity
+
+// SourceOfFunds is where the money of a top up comes from. Provider is the
+// name of the top up provider that collects it. The fees of its top ups are
+// priced by the fee rules.
+type SourceOfFunds struct {
+	Base
+	Name           string `json:"name"             gorm:"not null;uniqueIndex"`
+	Provider       string `json:"provider"         gorm:"not null"`
+	Enabled        bool   `json:"enabled"          gorm:"not null"`
+	MinTopupAmount int    `json:"min_topup_amount" gorm:"not null"`
+	MaxTopupAmount int    `json:"max_topup_amount" gorm:"not null"`
+}
+
+func (SourceOfFunds) TableName() string {
+	return "sources_of_funds"
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// StatementAdjustment is the type of the lines of a statement that no
+// transaction caused, such as the corrections of a wallet balance.
+const StatementAdjustment TransactionType = "ADJUSTMENT"
+
+// Statement is the money that moved in and out of a wallet over a period,
+// from the balance the wallet had when the period started. To is the end
+// of the period and is not part of it.
+type Statement struct {
+	Wallet         *Wallet
+	From           time.Time
+	To             time.Time
+	OpeningBalance int
+}
+
+// StatementLine is what one journal entry moved in or out of the wallet of
+// a statement, negative when money left it. The journal entry of a failed
+// withdrawal is a line of its own, dated when the money came back. Lines of
+// no transaction have no TransactionID and the type StatementAdjustment.
+type StatementLine struct {
+	JournalEntryID int
+	TransactionID  *int
+	Type           TransactionType
+	Description    string
+	Datetime       time.Time
+	Amount         int
+}
@@ -1,6 +1,7 @@
 package entity
 
 type Token struct {
-	IDToken string `json:"id_token"`
-	User    User   `json:"user"`
+	IDToken      string `json:"id_token"`
+	RefreshToken string `json:"refresh_token"`
+	User         User   `json:"user"`
 }
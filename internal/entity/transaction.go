@@ -1,47 +1,88 @@
 package entity
 
-import (
-	"fmt"
-	"time"
-)
+import "time"
 
+// Transaction moves money into, out of or between wallets. FromBalanceAfter
+// and ToBalanceAfter are the balances of the wallets right after it moved
+// the money, and are empty for what was recorded before they were kept and
+// for top ups that have not been paid.
 type Transaction struct {
 	Base
-	Amount      int              `json:"amount"`
-	Description string           `json:"description,omitempty"`
-	Type        TransactionType  `json:"type"`
-	Datetime    time.Time        `json:"datetime"`
-	SourceID    *SourceOfFundsID `json:"source_id,omitempty"`
-	From        int              `json:"from_number"           gorm:"column:from_number"`
-	FromWallet  Wallet           `json:"from_wallet"           gorm:"references:Number;foreignKey:From;constraint:OnUpdate:CASCADE"`
-	To          int              `json:"to_number"             gorm:"column:to_number"`
-	ToWallet    Wallet           `json:"to_wallet"             gorm:"references:Number;foreignKey:To;constraint:OnUpdate:CASCADE"`
+	Amount            int               `json:"amount"`
+	Description       string            `json:"description,omitempty"`
+	Type              TransactionType   `json:"type"`
+	Status            TransactionStatus `json:"status"                       gorm:"not null;default:'COMPLETED'"`
+	Datetime          time.Time         `json:"datetime"                     gorm:"index:idx_transactions_from_number_datetime,priority:2;index:idx_transactions_to_number_datetime,priority:2"`
+	SourceID          *SourceOfFundsID  `json:"source_id,omitempty"`
+	SourceOfFunds     *SourceOfFunds    `json:"source_of_funds,omitempty"    gorm:"foreignKey:SourceID"`
+	Fee               int               `json:"fee"                          gorm:"not null;default:0"`
+	From              int               `json:"from_number"                  gorm:"column:from_number;index:idx_transactions_from_number_datetime,priority:1"`
+	FromWallet        Wallet            `json:"from_wallet"                  gorm:"references:Number;foreignKey:From;constraint:OnUpdate:CASCADE"`
+	To                int               `json:"to_number"                    gorm:"column:to_number;index:idx_transactions_to_number_datetime,priority:1"`
+	ToWallet          Wallet            `json:"to_wallet"                    gorm:"references:Number;foreignKey:To;constraint:OnUpdate:CASCADE"`
+	ReferenceID       *int              `json:"reference_id,omitempty"       gorm:"index"`
+	RefundedAmount    int               `json:"refunded_amount"              gorm:"not null;default:0"`
+	BankAccountID     *int              `json:"bank_account_id,omitempty"`
+	ProviderReference string            `json:"provider_reference,omitempty" gorm:"index"`
+	FailureReason     string            `json:"failure_reason,omitempty"`
+	Currency          Currency          `json:"currency"                     gorm:"not null;default:'IDR'"`
+	ConvertedAmount   *int              `json:"converted_amount,omitempty"`
+	ConvertedCurrency Currency          `json:"converted_currency,omitempty"`
+	ExchangeRate      string            `json:"exchange_rate,omitempty"`
+	FromBalanceAfter  *int              `json:"from_balance_after,omitempty"`
+	ToBalanceAfter    *int              `json:"to_balance_after,omitempty"`
+}
+
+// TransactionDetail is a transaction with the names of the users who sent
+// and received it. A name is empty when no user owns the wallet, as for
+// the house wallets, and for top ups and withdrawals, which only move
+// money in and out of the user's own wallet.
+type TransactionDetail struct {
+	*Transaction
+	SenderName    string
+	RecipientName string
 }
 
+// SourceOfFundsID is the ID of a top up's row in sources_of_funds.
 type SourceOfFundsID int
 
+type TransactionType string
+
 const (
-	BankTransfer SourceOfFundsID = iota + 1
-	CreditCard
-	Cash
+	Transfer   TransactionType = "TRANSFER"
+	TopUp      TransactionType = "TOP_UP"
+	Refund     TransactionType = "REFUND"
+	Withdrawal TransactionType = "WITHDRAWAL"
 )
 
-func (e SourceOfFundsID) String() string {
-	switch e {
-	case BankTransfer:
-		return "Bank Transfer"
-	case CreditCard:
-		return "Credit Card"
-	case Cash:
-		return "Cash"
-	default:
-		return fmt.Sprintf("%d", int(e))
+// TransactionStatus tells whether the money of a transaction has settled.
+// Transfers and refunds settle at once and are always COMPLETED.
+type TransactionStatus string
+
+const (
+	TransactionPending    TransactionStatus = "PENDING"
+	TransactionProcessing TransactionStatus = "PROCESSING"
+	TransactionCompleted  TransactionStatus = "COMPLETED"
+	TransactionFailed     TransactionStatus = "FAILED"
+)
+
+// ReceivedAmount is what the destination wallet was credited, in its own
+// currency. Amount is in the currency of the source wallet, and only
+// differs from it when the transaction converted between currencies.
+func (t *Transaction) ReceivedAmount() int {
+	if t.ConvertedAmount != nil {
+		return *t.ConvertedAmount
 	}
+
+	return t.Amount
 }
 
-type TransactionType string
+// RefundableAmount is what is left to refund of a transfer, in the currency
+// of the wallet that received it.
+func (t *Transaction) RefundableAmount() int {
+	if t.Type != Transfer {
+		return 0
+	}
 
-const (
-	Transfer TransactionType = "TRANSFER"
-	TopUp    TransactionType = "TOP_UP"
-)
+	return t.ReceivedAmount() - t.RefundedAmount
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// TransactionFilter narrows the transaction history of a wallet. Fields left
+// at their zero value do not filter.
+type TransactionFilter struct {
+	Search       string
+	From         *time.Time
+	To           *time.Time
+	Type         TransactionType
+	Direction    TransactionDirection
+	MinAmount    *int
+	MaxAmount    *int
+	Counterparty int
+}
+
+// TransactionDirection tells whether a transaction brought money into the
+// wallet or took it out. Top ups are incoming, withdrawals outgoing.
+type TransactionDirection string
+
+const (
+	Incoming TransactionDirection = "incoming"
+	Outgoing TransactionDirection = "outgoing"
+)
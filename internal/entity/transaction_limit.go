@@ -0,0 +1,19 @@
+package entity
+
+// TransactionLimit bounds what a wallet in Currency of a user of Tier can
+// move, in the minor unit of the currency. The daily and monthly limits cap
+// the total of outgoing transfers and withdrawals in the current calendar
+// day and month, and MaxBalance caps what the wallet can hold after
+// receiving money.
+type TransactionLimit struct {
+	Base
+	Tier                 UserTier `json:"tier"                   gorm:"not null;uniqueIndex:idx_transaction_limits_tier_currency,priority:1"`
+	Currency             Currency `json:"currency"               gorm:"not null;default:'IDR';uniqueIndex:idx_transaction_limits_tier_currency,priority:2"`
+	MinTransferAmount    int      `json:"min_transfer_amount"    gorm:"not null"`
+	MaxTransferAmount    int      `json:"max_transfer_amount"    gorm:"not null"`
+	MinWithdrawalAmount  int      `json:"min_withdrawal_amount"  gorm:"not null"`
+	MaxWithdrawalAmount  int      `json:"max_withdrawal_amount"  gorm:"not null"`
+	DailyOutgoingLimit   int      `json:"daily_outgoing_limit"   gorm:"not null"`
+	MonthlyOutgoingLimit int      `json:"monthly_outgoing_limit" gorm:"not null"`
+	MaxBalance           int      `json:"max_balance"            gorm:"not null"`
+}
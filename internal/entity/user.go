@@ -1,16 +1,52 @@
 package entity
 
+import "time"
+
 type User struct {
 	Base
-	Name         string `json:"name"`
-	Email        string `json:"email"              gorm:"unique"`
-	Password     string `json:"password,omitempty"`
-	WalletNumber int    `json:"wallet_number"`
-	Wallet       Wallet `json:"wallet"             gorm:"references:Number;foreignKey:WalletNumber;constraint:OnUpdate:CASCADE"`
+	Name              string     `json:"name"`
+	Email             string     `json:"email"              gorm:"unique"`
+	Password          string     `json:"password,omitempty"`
+	Pin               string     `json:"-"`
+	PinFailedAttempts int        `json:"-"                  gorm:"not null;default:0"`
+	PinLockedUntil    *time.Time `json:"-"`
+	Tier              UserTier   `json:"tier"               gorm:"not null;default:'BASIC'"`
+	KycStatus         KycStatus  `json:"kyc_status"         gorm:"not null;default:'UNVERIFIED'"`
+	Role              UserRole   `json:"-"                  gorm:"not null;default:'USER'"`
+	WalletNumber      int        `json:"wallet_number"`
+	Wallet            Wallet     `json:"wallet"             gorm:"references:Number;foreignKey:WalletNumber;constraint:OnUpdate:CASCADE"`
 }
+
+// UserTier picks the transaction limits that apply to a user.
+type UserTier string
+
+const (
+	BasicTier    UserTier = "BASIC"
+	VerifiedTier UserTier = "VERIFIED"
+)
+
+// KycStatus tracks the identity check of a user. Only a VERIFIED user is
+// on the VERIFIED tier.
+type KycStatus string
+
+const (
+	KycUnverified KycStatus = "UNVERIFIED"
+	KycPending    KycStatus = "PENDING"
+	KycVerified   KycStatus = "VERIFIED"
+	KycRejected   KycStatus = "REJECTED"
+)
+
+type UserRole string
+
+const (
+	UserRoleUser UserRole = "USER"
+	AdminRole    UserRole = "ADMIN"
+)
+
 type TokenizedUser struct {
-	ID           int    `json:"id"`
-	Name         string `json:"name"`
-	Email        string `json:"email"`
-	WalletNumber int    `json:"wallet_number"`
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	Email        string   `json:"email"`
+	WalletNumber int      `json:"wallet_number"`
+	Role         UserRole `json:"role,omitempty"`
 }
@@ -1,7 +1,69 @@
 package entity
 
+import "time"
+
+// HouseWalletNumber is the wallet fees in the BaseCurrency are collected
+// into. It is below the number of the first user's wallet, so no user owns
+// it.
+const HouseWalletNumber = 100000
+
+// Wallet holds the balance of one currency. A user's first wallet is their
+// WalletNumber; the others are pockets with a Name and optionally a savings
+// goal. An admin sets the Status of a wallet, with the reason and who and
+// when kept next to it.
 type Wallet struct {
 	Base
-	Number  int `json:"wallet_number" gorm:"unique"`
-	Balance int `json:"balance"`
+	Number          int          `json:"wallet_number"               gorm:"unique"`
+	Balance         int          `json:"balance"`
+	Currency        Currency     `json:"currency"                    gorm:"not null;default:'IDR'"`
+	UserID          *int         `json:"-"                           gorm:"index"`
+	Name            string       `json:"name,omitempty"`
+	GoalAmount      *int         `json:"goal_amount,omitempty"`
+	GoalDate        *time.Time   `json:"goal_date,omitempty"`
+	Status          WalletStatus `json:"status"                      gorm:"not null;default:'ACTIVE'"`
+	StatusReason    string       `json:"status_reason,omitempty"`
+	StatusChangedBy *int         `json:"-"`
+	StatusChangedAt *time.Time   `json:"status_changed_at,omitempty"`
+}
+
+// WalletStatus limits the money a wallet can move. A FROZEN wallet can only
+// receive, a SUSPENDED one can neither send nor receive, and a CLOSED one
+// is empty and stays closed.
+type WalletStatus string
+
+const (
+	WalletActive    WalletStatus = "ACTIVE"
+	WalletFrozen    WalletStatus = "FROZEN"
+	WalletSuspended WalletStatus = "SUSPENDED"
+	WalletClosed    WalletStatus = "CLOSED"
+)
+
+// CanSend reports whether money can leave a wallet of the status.
+func (s WalletStatus) CanSend() bool {
+	switch s {
+	case WalletFrozen, WalletSuspended, WalletClosed:
+		return false
+	default:
+		return true
+	}
+}
+
+// CanReceive reports whether money can arrive in a wallet of the status.
+func (s WalletStatus) CanReceive() bool {
+	switch s {
+	case WalletSuspended, WalletClosed:
+		return false
+	default:
+		return true
+	}
+}
+
+// IsSameOwner reports whether both wallets belong to the same user.
+func (w *Wallet) IsSameOwner(other *Wallet) bool {
+	return w.UserID != nil && other.UserID != nil && *w.UserID == *other.UserID
+}
+
+// IsOwnedBy reports whether the wallet belongs to the user.
+func (w *Wallet) IsOwnedBy(userID int) bool {
+	return w.UserID != nil && *w.UserID == userID
 }
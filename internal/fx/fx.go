@@ -0,0 +1,161 @@
+// Package fx converts amounts between the currencies of wallets with the
+// exchange rates of a rate provider.
+package fx
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"strings"
+
+	"assignment-golang-backend/internal/config"
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+)
+
+const (
+	DEFAULT_RATES_FILE = "asset/fx_rates.json"
+	RATE_DECIMALS      = 10
+)
+
+// IRateProvider returns how many units of the second currency one unit of
+// the first currency is worth, as a decimal string.
+type IRateProvider interface {
+	Rate(entity.Currency, entity.Currency) (string, error)
+}
+
+// RatesFile is the format of the file StaticFileProvider reads. Rates holds
+// what one unit of each currency is worth in Base, e.g. {"USD": "15500"}
+// with IDR as Base.
+type RatesFile struct {
+	Base  entity.Currency            `json:"base"`
+	Rates map[entity.Currency]string `json:"rates"`
+}
+
+// StaticFileProvider serves the rates of a file read once on start, for
+// offline use and local development.
+type StaticFileProvider struct {
+	base  entity.Currency
+	rates map[entity.Currency]*big.Rat
+	err   error
+}
+
+// NewStaticFileProvider reads the rates file at path. A file that cannot be
+// read leaves a provider that fails every conversion with the read error,
+// so same currency transfers keep working.
+func NewStaticFileProvider(path string) *StaticFileProvider {
+	provider := &StaticFileProvider{}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		provider.err = err
+		return provider
+	}
+
+	var file RatesFile
+	err = json.Unmarshal(content, &file)
+	if err != nil {
+		provider.err = err
+		return provider
+	}
+
+	provider.base = file.Base
+	provider.rates = map[entity.Currency]*big.Rat{
+		file.Base: big.NewRat(1, 1),
+	}
+	for currency, rate := range file.Rates {
+		value, ok := new(big.Rat).SetString(rate)
+		if !ok || value.Sign() <= 0 {
+			provider.err = errors.New("invalid rate of " + string(currency))
+			return provider
+		}
+		provider.rates[currency] = value
+	}
+
+	return provider
+}
+
+// RatesFileFromEnv reads FX_RATES_FILE, the file StaticFileProvider reads.
+func RatesFileFromEnv() string {
+	path := config.GetEnv("FX_RATES_FILE")
+	if path == "" {
+		return DEFAULT_RATES_FILE
+	}
+
+	return path
+}
+
+func (p *StaticFileProvider) Rate(
+	from entity.Currency,
+	to entity.Currency,
+) (string, error) {
+	if from == to {
+		return "1", nil
+	}
+
+	if p.err != nil {
+		return "", p.err
+	}
+
+	fromValue, fromOk := p.rates[from]
+	toValue, toOk := p.rates[to]
+	if !fromOk || !toOk {
+		return "", &custom_error.ExchangeRateUnavailable{
+			From: string(from),
+			To:   string(to),
+		}
+	}
+
+	return FormatRate(new(big.Rat).Quo(fromValue, toValue)), nil
+}
+
+// FormatRate rounds a rate to RATE_DECIMALS decimals without trailing
+// zeros.
+func FormatRate(rate *big.Rat) string {
+	formatted := rate.FloatString(RATE_DECIMALS)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, ".")
+}
+
+// Convert returns what amount of from is worth in to at rate, in the minor
+// unit of to and rounded down.
+func Convert(
+	amount int,
+	rate string,
+	from entity.Currency,
+	to entity.Currency,
+) (int, error) {
+	value, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return 0, errors.New("invalid rate " + rate)
+	}
+
+	value.Mul(value, big.NewRat(int64(amount), 1))
+
+	scale := new(big.Int).Exp(
+		big.NewInt(10),
+		big.NewInt(int64(abs(to.Exponent()-from.Exponent()))),
+		nil,
+	)
+	if to.Exponent() > from.Exponent() {
+		value.Mul(value, new(big.Rat).SetInt(scale))
+	} else {
+		value.Quo(value, new(big.Rat).SetInt(scale))
+	}
+
+	converted := new(big.Int).Quo(value.Num(), value.Denom())
+	if !converted.IsInt64() {
+		return 0, errors.New("converted amount overflows")
+	}
+
+	return int(converted.Int64()), nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
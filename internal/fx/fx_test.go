@@ -0,0 +1,157 @@
+package fx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRatesFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "fx_rates.json")
+	err := os.WriteFile(path, []byte(content), 0o600)
+	require.NoError(t, err)
+
+	return path
+}
+
+func TestStaticFileProvider_Rate(t *testing.T) {
+	provider := NewStaticFileProvider(writeRatesFile(
+		t,
+		`{"base": "IDR", "rates": {"USD": "15500", "EUR": "16800"}}`,
+	))
+
+	tests := []struct {
+		name        string
+		from        entity.Currency
+		to          entity.Currency
+		want        string
+		expectedErr error
+	}{
+		{
+			name: "Same currency",
+			from: entity.USD,
+			to:   entity.USD,
+			want: "1",
+		},
+		{
+			name: "To the base currency",
+			from: entity.USD,
+			to:   entity.IDR,
+			want: "15500",
+		},
+		{
+			name: "From the base currency",
+			from: entity.IDR,
+			to:   entity.USD,
+			want: "0.0000645161",
+		},
+		{
+			name: "Between two other currencies",
+			from: entity.USD,
+			to:   entity.EUR,
+			want: "0.9226190476",
+		},
+		{
+			name: "Currency without a rate",
+			from: entity.USD,
+			to:   entity.SGD,
+			expectedErr: &custom_error.ExchangeRateUnavailable{
+				From: "USD",
+				To:   "SGD",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.Rate(tt.from, tt.to)
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func TestStaticFileProvider_unreadable(t *testing.T) {
+	provider := NewStaticFileProvider(filepath.Join(t.TempDir(), "missing.json"))
+
+	rate, err := provider.Rate(entity.IDR, entity.IDR)
+	assert.NoError(t, err, "Same currency rates need no file")
+	assert.Equal(t, "1", rate)
+
+	_, err = provider.Rate(entity.IDR, entity.USD)
+	assert.Error(t, err)
+
+	provider = NewStaticFileProvider(writeRatesFile(
+		t,
+		`{"base": "IDR", "rates": {"USD": "-1"}}`,
+	))
+	_, err = provider.Rate(entity.IDR, entity.USD)
+	assert.Error(t, err, "Rates must be positive")
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int
+		rate    string
+		from    entity.Currency
+		to      entity.Currency
+		want    int
+		wantErr bool
+	}{
+		{
+			name:   "Rupiah to cents rounds down",
+			amount: 100000,
+			rate:   "0.0000645161",
+			from:   entity.IDR,
+			to:     entity.USD,
+			want:   645,
+		},
+		{
+			name:   "Cents to rupiah",
+			amount: 1000,
+			rate:   "15500",
+			from:   entity.USD,
+			to:     entity.IDR,
+			want:   155000,
+		},
+		{
+			name:   "Cents to cents",
+			amount: 1000,
+			rate:   "0.9226190476",
+			from:   entity.USD,
+			to:     entity.EUR,
+			want:   922,
+		},
+		{
+			name:    "Invalid rate",
+			amount:  1000,
+			rate:    "rate",
+			from:    entity.USD,
+			to:      entity.EUR,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Convert(tt.amount, tt.rate, tt.from, tt.to)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"assignment-golang-backend/internal/dto"
 	"assignment-golang-backend/internal/entity"
 	"assignment-golang-backend/internal/helper"
+	middlewares "assignment-golang-backend/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,6 +17,12 @@ func (h *Handler) initAuthRoutes(api *gin.RouterGroup) {
 	{
 		auth.POST("/login", h.Login)
 		auth.POST("/register", h.Register)
+		auth.POST("/refresh", h.Refresh)
+		auth.POST(
+			"/logout",
+			middlewares.AuthorizeJWT(h.services.Auth),
+			h.Logout,
+		)
 	}
 }
 
@@ -101,3 +108,107 @@ func (h *Handler) Register(ctx *gin.Context) {
 		token,
 	)
 }
+
+func (h *Handler) Refresh(ctx *gin.Context) {
+	var input dto.RefreshRequestBody
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	token, err := h.services.Auth.Refresh(input.RefreshToken)
+
+	if _, ok := err.(*custom_error.InvalidToken); ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusUnauthorized,
+			err.Error(),
+			nil,
+		)
+
+		return
+	}
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		token,
+	)
+}
+
+func (h *Handler) Logout(ctx *gin.Context) {
+	var input dto.LogoutRequestBody
+	if ctx.Request.ContentLength != 0 {
+		err := ctx.ShouldBindJSON(&input)
+		if err != nil {
+			helper.WriteErrorResponse(
+				ctx,
+				http.StatusBadRequest,
+				custom_error.InvalidRequestBody{}.Error(),
+				nil,
+			)
+			return
+		}
+	}
+
+	claims, ok := ctx.Get("claims")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	err := h.services.Auth.Logout(
+		claims.(*helper.IdTokenClaims),
+		input.RefreshToken,
+	)
+
+	if _, ok := err.(*custom_error.InvalidToken); ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+
+		return
+	}
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		nil,
+	)
+}
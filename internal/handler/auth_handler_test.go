@@ -217,3 +217,209 @@ func TestHandler_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_Refresh(t *testing.T) {
+	mockDataInInterface, err := StructToMap(&entity.Token{})
+	require.NoError(t, err)
+
+	validBody := &dto.RefreshRequestBody{
+		RefreshToken: "refresh-token",
+	}
+	tests := []struct {
+		name        string
+		body        io.Reader
+		authService *mocks.IAuthService
+		mock        func(*mocks.IAuthService)
+		want        helper.JsonResponse
+	}{
+		{
+			name:        "Error | Invalid Request Body",
+			authService: mocks.NewIAuthService(t),
+			body:        MakeRequestBody(&dto.RefreshRequestBody{}),
+			mock: func(us *mocks.IAuthService) {
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidRequestBody{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:        "Error | Invalid refresh token",
+			authService: mocks.NewIAuthService(t),
+			body:        MakeRequestBody(validBody),
+			mock: func(us *mocks.IAuthService) {
+				us.On("Refresh", validBody.RefreshToken).
+					Return(nil, &custom_error.InvalidToken{})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusUnauthorized,
+				Message: custom_error.InvalidToken{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:        "Error | Error from AuthService",
+			authService: mocks.NewIAuthService(t),
+			body:        MakeRequestBody(validBody),
+			mock: func(us *mocks.IAuthService) {
+				us.On("Refresh", validBody.RefreshToken).
+					Return(nil, fmt.Errorf("error"))
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+				Data:    nil,
+			},
+		},
+		{
+			name:        "Success",
+			authService: mocks.NewIAuthService(t),
+			body:        MakeRequestBody(validBody),
+			mock: func(us *mocks.IAuthService) {
+				us.On("Refresh", validBody.RefreshToken).
+					Return(&entity.Token{}, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockDataInInterface,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				services: &usecase.Services{
+					Auth: tt.authService,
+				},
+			}
+
+			tt.mock(tt.authService)
+
+			r := SetUpRouter()
+			endpoint := "/api/auth/refresh"
+			r.POST(endpoint, h.Refresh)
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				endpoint,
+				tt.body,
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_Logout(t *testing.T) {
+	validBody := &dto.LogoutRequestBody{
+		RefreshToken: "refresh-token",
+	}
+	tests := []struct {
+		name                     string
+		body                     io.Reader
+		mockClaimsFromMiddleware bool
+		authService              *mocks.IAuthService
+		mock                     func(*mocks.IAuthService)
+		want                     helper.JsonResponse
+	}{
+		{
+			name:                     "Error | Failed to get claims from middleware",
+			authService:              mocks.NewIAuthService(t),
+			body:                     nil,
+			mockClaimsFromMiddleware: false,
+			mock: func(us *mocks.IAuthService) {
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: custom_error.FailedToGetInfoFromToken{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                     "Error | Refresh token does not belong to user",
+			authService:              mocks.NewIAuthService(t),
+			body:                     MakeRequestBody(validBody),
+			mockClaimsFromMiddleware: true,
+			mock: func(us *mocks.IAuthService) {
+				us.On("Logout", MockIdTokenClaims, validBody.RefreshToken).
+					Return(&custom_error.InvalidToken{})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidToken{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                     "Error | Error from AuthService",
+			authService:              mocks.NewIAuthService(t),
+			body:                     nil,
+			mockClaimsFromMiddleware: true,
+			mock: func(us *mocks.IAuthService) {
+				us.On("Logout", MockIdTokenClaims, "").
+					Return(fmt.Errorf("error"))
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+				Data:    nil,
+			},
+		},
+		{
+			name:                     "Success",
+			authService:              mocks.NewIAuthService(t),
+			body:                     MakeRequestBody(validBody),
+			mockClaimsFromMiddleware: true,
+			mock: func(us *mocks.IAuthService) {
+				us.On("Logout", MockIdTokenClaims, validBody.RefreshToken).
+					Return(nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				services: &usecase.Services{
+					Auth: tt.authService,
+				},
+			}
+
+			tt.mock(tt.authService)
+
+			r := SetUpRouter()
+			endpoint := "/api/auth/logout"
+			if tt.mockClaimsFromMiddleware {
+				r.POST(endpoint, MiddlewareMockClaims, h.Logout)
+			} else {
+				r.POST(endpoint, h.Logout)
+			}
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				endpoint,
+				tt.body,
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
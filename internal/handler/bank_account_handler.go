@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) initBankAccountRoutes(api *gin.RouterGroup) {
+	bankAccount := api.Group("/users/bank-accounts")
+	{
+		bankAccount.POST("", h.CreateBankAccount)
+		bankAccount.GET("", h.GetBankAccounts)
+		bankAccount.DELETE("/:id", h.DeleteBankAccount)
+	}
+}
+
+func (h *Handler) CreateBankAccount(ctx *gin.Context) {
+	var input dto.CreateBankAccountRequestBody
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.BankAccount.CreateBankAccount(&entity.BankAccount{
+		UserID:        user.(*entity.TokenizedUser).ID,
+		BankCode:      input.BankCode,
+		AccountNumber: input.AccountNumber,
+		AccountName:   input.AccountName,
+	})
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		dto.FormatBankAccount(res),
+	)
+}
+
+func (h *Handler) GetBankAccounts(ctx *gin.Context) {
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.BankAccount.FindByUserID(
+		user.(*entity.TokenizedUser).ID,
+	)
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatMultipleBankAccount(res),
+	)
+}
+
+func (h *Handler) DeleteBankAccount(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	err = h.services.BankAccount.DeleteBankAccount(
+		id,
+		user.(*entity.TokenizedUser).ID,
+	)
+
+	if _, ok := err.(*custom_error.NoDataFound); ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+
+		return
+	}
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		nil,
+	)
+}
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
+	"assignment-golang-backend/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bankAccountHandlerTest struct {
+	name                   string
+	path                   string
+	body                   io.Reader
+	mockUserFromMiddleware bool
+	mock                   func(*mocks.IBankAccountService)
+	want                   helper.JsonResponse
+}
+
+func runBankAccountHandlerTests(
+	t *testing.T,
+	method string,
+	route string,
+	handlerFunc func(*Handler) gin.HandlerFunc,
+	tests []bankAccountHandlerTest,
+) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bankAccountService := mocks.NewIBankAccountService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					BankAccount: bankAccountService,
+				},
+			}
+
+			tt.mock(bankAccountService)
+
+			r := SetUpRouter()
+			if tt.mockUserFromMiddleware {
+				r.Handle(method, route, MiddlewareMockUser, handlerFunc(h))
+			} else {
+				r.Handle(method, route, handlerFunc(h))
+			}
+			req, _ := http.NewRequest(method, tt.path, tt.body)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_initBankAccountRoutes(t *testing.T) {
+	router := SetUpRouter()
+	handler := New(&usecase.Services{})
+	group := router.Group("/")
+
+	handler.initBankAccountRoutes(group)
+}
+
+func TestHandler_CreateBankAccount(t *testing.T) {
+	validBody := &dto.CreateBankAccountRequestBody{
+		BankCode:      "BCA",
+		AccountNumber: "1234567890",
+		AccountName:   "Name",
+	}
+	mockBankAccount := &entity.BankAccount{
+		Base:          entity.Base{ID: 1},
+		UserID:        MockTokenizedUser.ID,
+		BankCode:      validBody.BankCode,
+		AccountNumber: validBody.AccountNumber,
+		AccountName:   validBody.AccountName,
+	}
+	mockDataInInterface, err := StructToMap(dto.FormatBankAccount(mockBankAccount))
+	require.NoError(t, err)
+
+	runBankAccountHandlerTests(
+		t,
+		http.MethodPost,
+		"/api/users/bank-accounts",
+		func(h *Handler) gin.HandlerFunc { return h.CreateBankAccount },
+		[]bankAccountHandlerTest{
+			{
+				name: "Error | Account number is not numeric",
+				path: "/api/users/bank-accounts",
+				body: MakeRequestBody(&dto.CreateBankAccountRequestBody{
+					BankCode:      "BCA",
+					AccountNumber: "12345abc",
+					AccountName:   "Name",
+				}),
+				mockUserFromMiddleware: true,
+				mock:                   func(bs *mocks.IBankAccountService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidRequestBody{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Error from service",
+				path:                   "/api/users/bank-accounts",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(bs *mocks.IBankAccountService) {
+					bs.On("CreateBankAccount", &entity.BankAccount{
+						UserID:        MockTokenizedUser.ID,
+						BankCode:      validBody.BankCode,
+						AccountNumber: validBody.AccountNumber,
+						AccountName:   validBody.AccountName,
+					}).Return(nil, fmt.Errorf("error"))
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: http.StatusText(http.StatusInternalServerError),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/users/bank-accounts",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(bs *mocks.IBankAccountService) {
+					bs.On("CreateBankAccount", &entity.BankAccount{
+						UserID:        MockTokenizedUser.ID,
+						BankCode:      validBody.BankCode,
+						AccountNumber: validBody.AccountNumber,
+						AccountName:   validBody.AccountName,
+					}).Return(mockBankAccount, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusCreated,
+					Message: http.StatusText(http.StatusCreated),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_GetBankAccounts(t *testing.T) {
+	runBankAccountHandlerTests(
+		t,
+		http.MethodGet,
+		"/api/users/bank-accounts",
+		func(h *Handler) gin.HandlerFunc { return h.GetBankAccounts },
+		[]bankAccountHandlerTest{
+			{
+				name:                   "Error | Failed to get user key from middleware",
+				path:                   "/api/users/bank-accounts",
+				mockUserFromMiddleware: false,
+				mock:                   func(bs *mocks.IBankAccountService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: custom_error.FailedToGetInfoFromToken{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/users/bank-accounts",
+				mockUserFromMiddleware: true,
+				mock: func(bs *mocks.IBankAccountService) {
+					bs.On("FindByUserID", MockTokenizedUser.ID).
+						Return([]*entity.BankAccount{}, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    []interface{}{},
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_DeleteBankAccount(t *testing.T) {
+	runBankAccountHandlerTests(
+		t,
+		http.MethodDelete,
+		"/api/users/bank-accounts/:id",
+		func(h *Handler) gin.HandlerFunc { return h.DeleteBankAccount },
+		[]bankAccountHandlerTest{
+			{
+				name:                   "Error | Bank account not found",
+				path:                   "/api/users/bank-accounts/1",
+				mockUserFromMiddleware: true,
+				mock: func(bs *mocks.IBankAccountService) {
+					bs.On("DeleteBankAccount", 1, MockTokenizedUser.ID).
+						Return(&custom_error.NoDataFound{DataType: "bank account"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusNotFound,
+					Message: custom_error.NoDataFound{DataType: "bank account"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/users/bank-accounts/1",
+				mockUserFromMiddleware: true,
+				mock: func(bs *mocks.IBankAccountService) {
+					bs.On("DeleteBankAccount", 1, MockTokenizedUser.ID).Return(nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    nil,
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) initFeeRoutes(transaction *gin.RouterGroup) {
+	transaction.GET("/quote", h.QuoteFee)
+}
+
+func (h *Handler) QuoteFee(ctx *gin.Context) {
+	var input dto.QuoteFeeQuery
+	err := ctx.ShouldBindQuery(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	if input.Currency == "" {
+		input.Currency = entity.BaseCurrency
+	}
+
+	// Top ups only fill wallets of the base currency.
+	if !input.Currency.IsSupported() ||
+		(input.Type == entity.TopUp && input.Currency != entity.BaseCurrency) {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.CurrencyNotSupported{
+				Currency: string(input.Currency),
+			}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.Fee.Quote(&entity.Transaction{
+		Type:     input.Type,
+		Amount:   input.Amount,
+		SourceID: input.SourceID,
+		Currency: input.Currency,
+	})
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatFeeQuote(res),
+	)
+}
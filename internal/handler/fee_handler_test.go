@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_initFeeRoutes(t *testing.T) {
+	router := SetUpRouter()
+	handler := New(&usecase.Services{})
+	group := router.Group("/")
+
+	handler.initFeeRoutes(group)
+}
+
+func TestHandler_QuoteFee(t *testing.T) {
+	sourceID := entity.SourceOfFundsID(1)
+	mockTransferQuote := &entity.Transaction{
+		Type:     entity.Transfer,
+		Amount:   100000,
+		Currency: entity.IDR,
+		Fee:      1000,
+	}
+	mockTopupQuote := &entity.Transaction{
+		Type:     entity.TopUp,
+		Amount:   100000,
+		SourceID: &sourceID,
+		Currency: entity.IDR,
+		Fee:      2500,
+	}
+	mockUsdTransferQuote := &entity.Transaction{
+		Type:     entity.Transfer,
+		Amount:   10000,
+		Currency: entity.USD,
+		Fee:      50,
+	}
+	transferQuoteInInterface, err := StructToMap(dto.FormatFeeQuote(mockTransferQuote))
+	require.NoError(t, err)
+	usdTransferQuoteInInterface, err := StructToMap(dto.FormatFeeQuote(mockUsdTransferQuote))
+	require.NoError(t, err)
+	topupQuoteInInterface, err := StructToMap(dto.FormatFeeQuote(mockTopupQuote))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		query string
+		mock  func(*mocks.IFeeService)
+		want  helper.JsonResponse
+	}{
+		{
+			name:  "Error | Type not quotable",
+			query: "type=WITHDRAWAL&amount=100000",
+			mock:  func(fs *mocks.IFeeService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidRequestBody{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:  "Error | Amount not positive",
+			query: "type=TRANSFER&amount=0",
+			mock:  func(fs *mocks.IFeeService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidRequestBody{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:  "Error | Top up without a source of funds",
+			query: "type=TOP_UP&amount=100000",
+			mock:  func(fs *mocks.IFeeService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidRequestBody{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:  "Error | Currency not supported",
+			query: "type=TRANSFER&amount=100000&currency=JPY",
+			mock:  func(fs *mocks.IFeeService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.CurrencyNotSupported{Currency: "JPY"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:  "Error | Top up in a currency other than the base currency",
+			query: "type=TOP_UP&amount=100000&source_id=1&currency=USD",
+			mock:  func(fs *mocks.IFeeService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.CurrencyNotSupported{Currency: "USD"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:  "Error | Error from services",
+			query: "type=TRANSFER&amount=100000",
+			mock: func(fs *mocks.IFeeService) {
+				fs.On("Quote", &entity.Transaction{
+					Type:     entity.Transfer,
+					Amount:   100000,
+					Currency: entity.IDR,
+				}).Return(nil, fmt.Errorf("error"))
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+				Data:    nil,
+			},
+		},
+		{
+			name:  "Success | Transfer",
+			query: "type=TRANSFER&amount=100000",
+			mock: func(fs *mocks.IFeeService) {
+				fs.On("Quote", &entity.Transaction{
+					Type:     entity.Transfer,
+					Amount:   100000,
+					Currency: entity.IDR,
+				}).Return(mockTransferQuote, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    transferQuoteInInterface,
+			},
+		},
+		{
+			name:  "Success | Transfer in another currency",
+			query: "type=TRANSFER&amount=10000&currency=USD",
+			mock: func(fs *mocks.IFeeService) {
+				fs.On("Quote", &entity.Transaction{
+					Type:     entity.Transfer,
+					Amount:   10000,
+					Currency: entity.USD,
+				}).Return(mockUsdTransferQuote, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    usdTransferQuoteInInterface,
+			},
+		},
+		{
+			name:  "Success | Top up",
+			query: "type=TOP_UP&amount=100000&source_id=1",
+			mock: func(fs *mocks.IFeeService) {
+				fs.On("Quote", &entity.Transaction{
+					Type:     entity.TopUp,
+					Amount:   100000,
+					SourceID: &sourceID,
+					Currency: entity.IDR,
+				}).Return(mockTopupQuote, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    topupQuoteInInterface,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feeService := mocks.NewIFeeService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					Fee: feeService,
+				},
+			}
+
+			tt.mock(feeService)
+
+			r := SetUpRouter()
+			r.GET("/api/transactions/quote", h.QuoteFee)
+
+			req, _ := http.NewRequest(
+				http.MethodGet,
+				"/api/transactions/quote?"+tt.query,
+				nil,
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
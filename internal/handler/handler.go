@@ -24,14 +24,29 @@ func (h *Handler) InitAPI(router *gin.Engine) {
 	api := router.Group("/api")
 	{
 		h.initAuthRoutes(api)
+		h.initWebhookRoutes(api)
 
 		protected := api.Group("/")
-		protected.Use(middlewares.AuthorizeJWT)
+		protected.Use(middlewares.AuthorizeJWT(h.services.Auth))
 
 		h.initUserRoutes(protected)
+		h.initWalletRoutes(protected)
+		h.initPinRoutes(protected)
+		h.initBankAccountRoutes(protected)
+		h.initSourceOfFundsRoutes(protected)
 		h.initTransactionRoutes(protected)
+		h.initPaymentRequestRoutes(protected)
+		h.initKycRoutes(protected)
+
+		admin := protected.Group("/admin")
+		admin.Use(middlewares.AuthorizeAdmin())
+
+		h.initAdminKycRoutes(admin)
+		h.initAdminWalletRoutes(admin)
 	}
 
+	h.initWellKnownRoutes(router)
+
 	router.Static("/docs", "dist")
 
 	router.NoRoute(func(ctx *gin.Context) {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
 	"assignment-golang-backend/internal/usecase"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +19,10 @@ var MockTokenizedUser *entity.TokenizedUser = &entity.TokenizedUser{
 	WalletNumber: 1,
 }
 
+var MockIdTokenClaims *helper.IdTokenClaims = &helper.IdTokenClaims{
+	User: MockTokenizedUser,
+}
+
 func SetUpRouter() *gin.Engine {
 	r := gin.Default()
 
@@ -45,6 +50,12 @@ func MiddlewareMockUser(ctx *gin.Context) {
 	ctx.Next()
 }
 
+func MiddlewareMockClaims(ctx *gin.Context) {
+	ctx.Set("user", MockIdTokenClaims.User)
+	ctx.Set("claims", MockIdTokenClaims)
+	ctx.Next()
+}
+
 func TestNew(t *testing.T) {
 	service := &usecase.Services{}
 	New(service)
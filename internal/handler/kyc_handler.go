@@ -0,0 +1,313 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) initKycRoutes(api *gin.RouterGroup) {
+	kyc := api.Group("/users/kyc")
+	{
+		kyc.POST("", h.SubmitKyc)
+		kyc.GET("", h.GetKyc)
+	}
+}
+
+func (h *Handler) initAdminKycRoutes(admin *gin.RouterGroup) {
+	kyc := admin.Group("/kyc-submissions")
+	{
+		kyc.GET("", h.GetPendingKycSubmissions)
+		kyc.GET("/:id/documents/:document", h.GetKycDocument)
+		kyc.POST("/:id/approve", h.ApproveKycSubmission)
+		kyc.POST("/:id/reject", h.RejectKycSubmission)
+	}
+}
+
+func (h *Handler) SubmitKyc(ctx *gin.Context) {
+	var input dto.SubmitKycRequestBody
+	err := ctx.ShouldBind(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	dateOfBirth, err := time.Parse(dto.KYC_DATE_OF_BIRTH_LAYOUT, input.DateOfBirth)
+	if err != nil || !dateOfBirth.Before(time.Now()) {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	identityDocument, err := input.IdentityDocument.Open()
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+	defer identityDocument.Close()
+
+	selfie, err := input.Selfie.Open()
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+	defer selfie.Close()
+
+	res, err := h.services.Kyc.Submit(
+		&entity.KycSubmission{
+			UserID:         user.(*entity.TokenizedUser).ID,
+			FullName:       input.FullName,
+			IdentityNumber: input.IdentityNumber,
+			DateOfBirth:    dateOfBirth,
+			Address:        input.Address,
+		},
+		identityDocument,
+		selfie,
+	)
+
+	if err != nil {
+		writeKycErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		dto.FormatKycSubmission(res),
+	)
+}
+
+func (h *Handler) GetKyc(ctx *gin.Context) {
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.Kyc.FindLatest(user.(*entity.TokenizedUser).ID)
+	if err != nil {
+		writeKycErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatKycSubmission(res),
+	)
+}
+
+func (h *Handler) GetPendingKycSubmissions(ctx *gin.Context) {
+	res, err := h.services.Kyc.FindPending()
+	if err != nil {
+		writeKycErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatMultipleKycSubmission(res),
+	)
+}
+
+func (h *Handler) GetKycDocument(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	document, contentType, err := h.services.Kyc.OpenDocument(
+		id,
+		entity.KycDocument(ctx.Param("document")),
+	)
+	if err != nil {
+		writeKycErrorResponse(ctx, err)
+		return
+	}
+	defer document.Close()
+
+	ctx.DataFromReader(
+		http.StatusOK,
+		-1,
+		contentType,
+		document,
+		map[string]string{"Cache-Control": "no-store"},
+	)
+}
+
+func (h *Handler) ApproveKycSubmission(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.Kyc.Approve(id, user.(*entity.TokenizedUser).ID)
+	if err != nil {
+		writeKycErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatKycSubmission(res),
+	)
+}
+
+func (h *Handler) RejectKycSubmission(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	var input dto.RejectKycRequestBody
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.Kyc.Reject(
+		id,
+		user.(*entity.TokenizedUser).ID,
+		input.Reason,
+	)
+	if err != nil {
+		writeKycErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatKycSubmission(res),
+	)
+}
+
+func writeKycErrorResponse(ctx *gin.Context, err error) {
+	switch err.(type) {
+	case *custom_error.NoDataFound:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.KycNotSubmittable,
+		*custom_error.KycSubmissionNotPending,
+		*custom_error.InvalidKycDocument:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.CannotReviewOwnKyc:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusForbidden,
+			err.Error(),
+			nil,
+		)
+	default:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+	}
+}
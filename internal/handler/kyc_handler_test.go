@@ -0,0 +1,541 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
+	"assignment-golang-backend/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type kycHandlerTest struct {
+	name                   string
+	path                   string
+	body                   io.Reader
+	contentType            string
+	mockUserFromMiddleware bool
+	mock                   func(*mocks.IKycService)
+	want                   helper.JsonResponse
+}
+
+func runKycHandlerTests(
+	t *testing.T,
+	method string,
+	route string,
+	handlerFunc func(*Handler) gin.HandlerFunc,
+	tests []kycHandlerTest,
+) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kycService := mocks.NewIKycService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					Kyc: kycService,
+				},
+			}
+
+			tt.mock(kycService)
+
+			r := SetUpRouter()
+			if tt.mockUserFromMiddleware {
+				r.Handle(method, route, MiddlewareMockUser, handlerFunc(h))
+			} else {
+				r.Handle(method, route, handlerFunc(h))
+			}
+			req, _ := http.NewRequest(method, tt.path, tt.body)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+// makeKycRequestBody builds a multipart form with the fields and a file
+// for each of files.
+func makeKycRequestBody(
+	t *testing.T,
+	fields map[string]string,
+	files map[string]string,
+) (io.Reader, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	for name, content := range files {
+		part, err := writer.CreateFormFile(name, name+".png")
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	return body, writer.FormDataContentType()
+}
+
+var mockKycSubmission = &entity.KycSubmission{
+	Base:           entity.Base{ID: 1},
+	UserID:         MockTokenizedUser.ID,
+	FullName:       "Full Name",
+	IdentityNumber: "3171234567890001",
+	DateOfBirth:    time.Date(1990, time.January, 31, 0, 0, 0, 0, time.UTC),
+	Address:        "Address",
+	Status:         entity.KycPending,
+}
+
+func TestHandler_initKycRoutes(t *testing.T) {
+	router := SetUpRouter()
+	handler := New(&usecase.Services{})
+	group := router.Group("/")
+
+	handler.initKycRoutes(group)
+	handler.initAdminKycRoutes(group)
+}
+
+func TestHandler_SubmitKyc(t *testing.T) {
+	validFields := map[string]string{
+		"full_name":       mockKycSubmission.FullName,
+		"identity_number": mockKycSubmission.IdentityNumber,
+		"date_of_birth":   "1990-01-31",
+		"address":         mockKycSubmission.Address,
+	}
+	validFiles := map[string]string{
+		"identity_document": "identity document",
+		"selfie":            "selfie",
+	}
+	withField := func(name string, value string) map[string]string {
+		fields := map[string]string{}
+		for key, value := range validFields {
+			fields[key] = value
+		}
+		fields[name] = value
+		return fields
+	}
+	isSubmission := mock.MatchedBy(func(submission *entity.KycSubmission) bool {
+		return submission.UserID == MockTokenizedUser.ID &&
+			submission.FullName == mockKycSubmission.FullName &&
+			submission.DateOfBirth.Equal(mockKycSubmission.DateOfBirth)
+	})
+	isContent := func(content string) interface{} {
+		return mock.MatchedBy(func(r io.Reader) bool {
+			read, err := io.ReadAll(r)
+			return err == nil && string(read) == content
+		})
+	}
+	mockDataInInterface, err := StructToMap(dto.FormatKycSubmission(mockKycSubmission))
+	require.NoError(t, err)
+
+	newTest := func(
+		name string,
+		fields map[string]string,
+		files map[string]string,
+		mock func(*mocks.IKycService),
+		want helper.JsonResponse,
+	) kycHandlerTest {
+		body, contentType := makeKycRequestBody(t, fields, files)
+		return kycHandlerTest{
+			name:                   name,
+			path:                   "/api/users/kyc",
+			body:                   body,
+			contentType:            contentType,
+			mockUserFromMiddleware: true,
+			mock:                   mock,
+			want:                   want,
+		}
+	}
+
+	runKycHandlerTests(
+		t,
+		http.MethodPost,
+		"/api/users/kyc",
+		func(h *Handler) gin.HandlerFunc { return h.SubmitKyc },
+		[]kycHandlerTest{
+			newTest(
+				"Error | Missing selfie",
+				validFields,
+				map[string]string{"identity_document": "identity document"},
+				func(ks *mocks.IKycService) {},
+				helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidRequestBody{}.Error(),
+					Data:    nil,
+				},
+			),
+			newTest(
+				"Error | Invalid date of birth",
+				withField("date_of_birth", "31-01-1990"),
+				validFiles,
+				func(ks *mocks.IKycService) {},
+				helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidRequestBody{}.Error(),
+					Data:    nil,
+				},
+			),
+			newTest(
+				"Error | Date of birth in the future",
+				withField("date_of_birth", time.Now().AddDate(1, 0, 0).Format("2006-01-02")),
+				validFiles,
+				func(ks *mocks.IKycService) {},
+				helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidRequestBody{}.Error(),
+					Data:    nil,
+				},
+			),
+			newTest(
+				"Error | KYC already pending",
+				validFields,
+				validFiles,
+				func(ks *mocks.IKycService) {
+					ks.On("Submit", isSubmission, mock.Anything, mock.Anything).
+						Return(nil, &custom_error.KycNotSubmittable{Status: "PENDING"})
+				},
+				helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.KycNotSubmittable{Status: "PENDING"}.Error(),
+					Data:    nil,
+				},
+			),
+			newTest(
+				"Error | Other errors from service",
+				validFields,
+				validFiles,
+				func(ks *mocks.IKycService) {
+					ks.On("Submit", isSubmission, mock.Anything, mock.Anything).
+						Return(nil, fmt.Errorf("error"))
+				},
+				helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: http.StatusText(http.StatusInternalServerError),
+					Data:    nil,
+				},
+			),
+			newTest(
+				"Success",
+				validFields,
+				validFiles,
+				func(ks *mocks.IKycService) {
+					ks.On(
+						"Submit",
+						isSubmission,
+						isContent("identity document"),
+						isContent("selfie"),
+					).Return(mockKycSubmission, nil)
+				},
+				helper.JsonResponse{
+					Code:    http.StatusCreated,
+					Message: http.StatusText(http.StatusCreated),
+					Data:    mockDataInInterface,
+				},
+			),
+		},
+	)
+}
+
+func TestHandler_GetKyc(t *testing.T) {
+	mockDataInInterface, err := StructToMap(dto.FormatKycSubmission(mockKycSubmission))
+	require.NoError(t, err)
+
+	runKycHandlerTests(
+		t,
+		http.MethodGet,
+		"/api/users/kyc",
+		func(h *Handler) gin.HandlerFunc { return h.GetKyc },
+		[]kycHandlerTest{
+			{
+				name:                   "Error | Failed to get user key from middleware",
+				path:                   "/api/users/kyc",
+				mockUserFromMiddleware: false,
+				mock:                   func(ks *mocks.IKycService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: custom_error.FailedToGetInfoFromToken{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Nothing submitted yet",
+				path:                   "/api/users/kyc",
+				mockUserFromMiddleware: true,
+				mock: func(ks *mocks.IKycService) {
+					ks.On("FindLatest", MockTokenizedUser.ID).
+						Return(nil, &custom_error.NoDataFound{DataType: "KYC submission"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusNotFound,
+					Message: custom_error.NoDataFound{DataType: "KYC submission"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/users/kyc",
+				mockUserFromMiddleware: true,
+				mock: func(ks *mocks.IKycService) {
+					ks.On("FindLatest", MockTokenizedUser.ID).
+						Return(mockKycSubmission, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_GetPendingKycSubmissions(t *testing.T) {
+	mockSubmissions := []*entity.KycSubmission{mockKycSubmission}
+	var mockDataInInterface []interface{}
+	data, err := json.Marshal(dto.FormatMultipleKycSubmission(mockSubmissions))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &mockDataInInterface))
+
+	runKycHandlerTests(
+		t,
+		http.MethodGet,
+		"/api/admin/kyc-submissions",
+		func(h *Handler) gin.HandlerFunc { return h.GetPendingKycSubmissions },
+		[]kycHandlerTest{
+			{
+				name: "Error | Error from service",
+				path: "/api/admin/kyc-submissions",
+				mock: func(ks *mocks.IKycService) {
+					ks.On("FindPending").Return(nil, fmt.Errorf("error"))
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: http.StatusText(http.StatusInternalServerError),
+					Data:    nil,
+				},
+			},
+			{
+				name: "Success",
+				path: "/api/admin/kyc-submissions",
+				mock: func(ks *mocks.IKycService) {
+					ks.On("FindPending").Return(mockSubmissions, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_GetKycDocument(t *testing.T) {
+	runKycHandlerTests(
+		t,
+		http.MethodGet,
+		"/api/admin/kyc-submissions/:id/documents/:document",
+		func(h *Handler) gin.HandlerFunc { return h.GetKycDocument },
+		[]kycHandlerTest{
+			{
+				name: "Error | Invalid ID",
+				path: "/api/admin/kyc-submissions/abc/documents/selfie",
+				mock: func(ks *mocks.IKycService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: http.StatusText(http.StatusBadRequest),
+					Data:    nil,
+				},
+			},
+			{
+				name: "Error | Unknown document",
+				path: "/api/admin/kyc-submissions/1/documents/passport",
+				mock: func(ks *mocks.IKycService) {
+					ks.On("OpenDocument", 1, entity.KycDocument("passport")).
+						Return(nil, "", &custom_error.NoDataFound{DataType: "KYC document"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusNotFound,
+					Message: custom_error.NoDataFound{DataType: "KYC document"}.Error(),
+					Data:    nil,
+				},
+			},
+		},
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		kycService := mocks.NewIKycService(t)
+		h := &Handler{services: &usecase.Services{Kyc: kycService}}
+		kycService.On("OpenDocument", 1, entity.KycSelfie).
+			Return(io.NopCloser(strings.NewReader("selfie")), "image/png", nil)
+
+		r := SetUpRouter()
+		r.GET("/api/admin/kyc-submissions/:id/documents/:document", h.GetKycDocument)
+		req, _ := http.NewRequest(
+			http.MethodGet,
+			"/api/admin/kyc-submissions/1/documents/selfie",
+			nil,
+		)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		assert.Equal(t, "selfie", w.Body.String())
+	})
+}
+
+func TestHandler_ApproveKycSubmission(t *testing.T) {
+	approved := *mockKycSubmission
+	approved.Status = entity.KycVerified
+	mockDataInInterface, err := StructToMap(dto.FormatKycSubmission(&approved))
+	require.NoError(t, err)
+
+	runKycHandlerTests(
+		t,
+		http.MethodPost,
+		"/api/admin/kyc-submissions/:id/approve",
+		func(h *Handler) gin.HandlerFunc { return h.ApproveKycSubmission },
+		[]kycHandlerTest{
+			{
+				name:                   "Error | Invalid ID",
+				path:                   "/api/admin/kyc-submissions/abc/approve",
+				mockUserFromMiddleware: true,
+				mock:                   func(ks *mocks.IKycService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: http.StatusText(http.StatusBadRequest),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Submission already reviewed",
+				path:                   "/api/admin/kyc-submissions/1/approve",
+				mockUserFromMiddleware: true,
+				mock: func(ks *mocks.IKycService) {
+					ks.On("Approve", 1, MockTokenizedUser.ID).
+						Return(nil, &custom_error.KycSubmissionNotPending{Status: "REJECTED"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.KycSubmissionNotPending{Status: "REJECTED"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Own submission",
+				path:                   "/api/admin/kyc-submissions/1/approve",
+				mockUserFromMiddleware: true,
+				mock: func(ks *mocks.IKycService) {
+					ks.On("Approve", 1, MockTokenizedUser.ID).
+						Return(nil, &custom_error.CannotReviewOwnKyc{})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusForbidden,
+					Message: custom_error.CannotReviewOwnKyc{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/admin/kyc-submissions/1/approve",
+				mockUserFromMiddleware: true,
+				mock: func(ks *mocks.IKycService) {
+					ks.On("Approve", 1, MockTokenizedUser.ID).Return(&approved, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_RejectKycSubmission(t *testing.T) {
+	rejected := *mockKycSubmission
+	rejected.Status = entity.KycRejected
+	rejected.RejectionReason = "Blurry document"
+	mockDataInInterface, err := StructToMap(dto.FormatKycSubmission(&rejected))
+	require.NoError(t, err)
+
+	runKycHandlerTests(
+		t,
+		http.MethodPost,
+		"/api/admin/kyc-submissions/:id/reject",
+		func(h *Handler) gin.HandlerFunc { return h.RejectKycSubmission },
+		[]kycHandlerTest{
+			{
+				name:                   "Error | Missing reason",
+				path:                   "/api/admin/kyc-submissions/1/reject",
+				body:                   MakeRequestBody(&dto.RejectKycRequestBody{}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ks *mocks.IKycService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidRequestBody{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name: "Error | Submission not found",
+				path: "/api/admin/kyc-submissions/1/reject",
+				body: MakeRequestBody(&dto.RejectKycRequestBody{
+					Reason: rejected.RejectionReason,
+				}),
+				mockUserFromMiddleware: true,
+				mock: func(ks *mocks.IKycService) {
+					ks.On("Reject", 1, MockTokenizedUser.ID, rejected.RejectionReason).
+						Return(nil, &custom_error.NoDataFound{DataType: "KYC submission"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusNotFound,
+					Message: custom_error.NoDataFound{DataType: "KYC submission"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name: "Success",
+				path: "/api/admin/kyc-submissions/1/reject",
+				body: MakeRequestBody(&dto.RejectKycRequestBody{
+					Reason: rejected.RejectionReason,
+				}),
+				mockUserFromMiddleware: true,
+				mock: func(ks *mocks.IKycService) {
+					ks.On("Reject", 1, MockTokenizedUser.ID, rejected.RejectionReason).
+						Return(&rejected, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
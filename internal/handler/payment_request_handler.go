@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) initPaymentRequestRoutes(api *gin.RouterGroup) {
+	paymentRequest := api.Group("/payment-requests")
+	{
+		paymentRequest.POST("", h.CreatePaymentRequest)
+		paymentRequest.GET("/incoming", h.GetIncomingPaymentRequests)
+		paymentRequest.GET("/outgoing", h.GetOutgoingPaymentRequests)
+		paymentRequest.POST("/:id/approve", h.ApprovePaymentRequest)
+		paymentRequest.POST("/:id/decline", h.DeclinePaymentRequest)
+	}
+}
+
+func (h *Handler) CreatePaymentRequest(ctx *gin.Context) {
+	var input dto.CreatePaymentRequestRequestBody
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+	tokenizedUser := user.(*entity.TokenizedUser)
+
+	if tokenizedUser.WalletNumber == input.From {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.CannotRequestFromOwnWallet{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.PaymentRequest.CreatePaymentRequest(
+		&entity.PaymentRequest{
+			RequesterNumber: tokenizedUser.WalletNumber,
+			PayerNumber:     input.From,
+			Amount:          input.Amount,
+			Description:     input.Description,
+		},
+	)
+
+	if _, ok := err.(*custom_error.NoDataFound); ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+
+		return
+	}
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		dto.FormatPaymentRequest(res),
+	)
+}
+
+func (h *Handler) GetIncomingPaymentRequests(ctx *gin.Context) {
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.PaymentRequest.FindIncoming(
+		user.(*entity.TokenizedUser).WalletNumber,
+	)
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatMultiplePaymentRequest(res),
+	)
+}
+
+func (h *Handler) GetOutgoingPaymentRequests(ctx *gin.Context) {
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.PaymentRequest.FindOutgoing(
+		user.(*entity.TokenizedUser).WalletNumber,
+	)
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatMultiplePaymentRequest(res),
+	)
+}
+
+func (h *Handler) ApprovePaymentRequest(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	var input dto.ApprovePaymentRequestRequestBody
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+	tokenizedUser := user.(*entity.TokenizedUser)
+
+	err = h.services.Pin.VerifyPin(tokenizedUser.ID, input.Pin)
+	if err != nil {
+		writePinErrorResponse(ctx, err)
+		return
+	}
+
+	paymentRequest, transfer, err := h.services.PaymentRequest.Approve(
+		id,
+		tokenizedUser.WalletNumber,
+	)
+	if err != nil {
+		writePaymentRequestErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		&dto.ApprovePaymentRequestResponseBody{
+			PaymentRequest: dto.FormatPaymentRequest(paymentRequest),
+			Transaction: dto.FormatGetTransaction(
+				transfer,
+				tokenizedUser.WalletNumber,
+			),
+		},
+	)
+}
+
+func (h *Handler) DeclinePaymentRequest(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.PaymentRequest.Decline(
+		id,
+		user.(*entity.TokenizedUser).WalletNumber,
+	)
+	if err != nil {
+		writePaymentRequestErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatPaymentRequest(res),
+	)
+}
+
+func writePaymentRequestErrorResponse(ctx *gin.Context, err error) {
+	switch err.(type) {
+	case *custom_error.NoDataFound:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.PaymentRequestNotPending,
+		*custom_error.InsufficientBalance,
+		*custom_error.AmountNotInRange,
+		*custom_error.DailyLimitExceeded,
+		*custom_error.MonthlyLimitExceeded,
+		*custom_error.DestinationBalanceLimitExceeded:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.WalletCannotSend,
+		*custom_error.DestinationWalletCannotReceive:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusForbidden,
+			err.Error(),
+			nil,
+		)
+	default:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+	}
+}
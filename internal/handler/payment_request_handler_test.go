@@ -0,0 +1,402 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
+	"assignment-golang-backend/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type paymentRequestHandlerTest struct {
+	name                   string
+	path                   string
+	body                   io.Reader
+	mockUserFromMiddleware bool
+	mock                   func(*mocks.IPaymentRequestService, *mocks.IPinService)
+	want                   helper.JsonResponse
+}
+
+func runPaymentRequestHandlerTests(
+	t *testing.T,
+	method string,
+	route string,
+	handlerFunc func(*Handler) gin.HandlerFunc,
+	tests []paymentRequestHandlerTest,
+) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paymentRequestService := mocks.NewIPaymentRequestService(t)
+			pinService := mocks.NewIPinService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					PaymentRequest: paymentRequestService,
+					Pin:            pinService,
+				},
+			}
+
+			tt.mock(paymentRequestService, pinService)
+
+			r := SetUpRouter()
+			if tt.mockUserFromMiddleware {
+				r.Handle(method, route, MiddlewareMockUser, handlerFunc(h))
+			} else {
+				r.Handle(method, route, handlerFunc(h))
+			}
+			req, _ := http.NewRequest(method, tt.path, tt.body)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_initPaymentRequestRoutes(t *testing.T) {
+	router := SetUpRouter()
+	handler := New(&usecase.Services{})
+	group := router.Group("/")
+
+	handler.initPaymentRequestRoutes(group)
+}
+
+func TestHandler_CreatePaymentRequest(t *testing.T) {
+	validBody := &dto.CreatePaymentRequestRequestBody{
+		From:        2,
+		Amount:      1000,
+		Description: "dinner",
+	}
+	mockPaymentRequest := &entity.PaymentRequest{
+		Base:            entity.Base{ID: 1},
+		RequesterNumber: MockTokenizedUser.WalletNumber,
+		PayerNumber:     validBody.From,
+		Amount:          validBody.Amount,
+		Description:     validBody.Description,
+		Status:          entity.PaymentRequestPending,
+		ExpiresAt:       time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+	mockDataInInterface, err := StructToMap(
+		dto.FormatPaymentRequest(mockPaymentRequest),
+	)
+	require.NoError(t, err)
+	isPaymentRequest := mock.MatchedBy(func(paymentRequest *entity.PaymentRequest) bool {
+		return paymentRequest.RequesterNumber == MockTokenizedUser.WalletNumber &&
+			paymentRequest.PayerNumber == validBody.From &&
+			paymentRequest.Amount == validBody.Amount
+	})
+
+	runPaymentRequestHandlerTests(
+		t,
+		http.MethodPost,
+		"/api/payment-requests",
+		func(h *Handler) gin.HandlerFunc { return h.CreatePaymentRequest },
+		[]paymentRequestHandlerTest{
+			{
+				name:                   "Error | Invalid Request Body",
+				path:                   "/api/payment-requests",
+				body:                   MakeRequestBody(&dto.CreatePaymentRequestRequestBody{}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidRequestBody{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name: "Error | Request from own wallet",
+				path: "/api/payment-requests",
+				body: MakeRequestBody(&dto.CreatePaymentRequestRequestBody{
+					From:   MockTokenizedUser.WalletNumber,
+					Amount: 1000,
+				}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.CannotRequestFromOwnWallet{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Payer wallet not found",
+				path:                   "/api/payment-requests",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {
+					ps.On("CreatePaymentRequest", isPaymentRequest).
+						Return(nil, &custom_error.NoDataFound{DataType: "payer wallet"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusNotFound,
+					Message: custom_error.NoDataFound{DataType: "payer wallet"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/payment-requests",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {
+					ps.On("CreatePaymentRequest", isPaymentRequest).
+						Return(mockPaymentRequest, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusCreated,
+					Message: http.StatusText(http.StatusCreated),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_ApprovePaymentRequest(t *testing.T) {
+	validBody := &dto.ApprovePaymentRequestRequestBody{Pin: "123456"}
+	mockPaymentRequest := &entity.PaymentRequest{
+		Base:   entity.Base{ID: 1},
+		Status: entity.PaymentRequestApproved,
+	}
+	mockTransfer := &entity.Transaction{
+		Base:   entity.Base{ID: 10},
+		Amount: 1000,
+		Type:   entity.Transfer,
+		From:   MockTokenizedUser.WalletNumber,
+		To:     2,
+	}
+	mockDataInInterface, err := StructToMap(&dto.ApprovePaymentRequestResponseBody{
+		PaymentRequest: dto.FormatPaymentRequest(mockPaymentRequest),
+		Transaction: dto.FormatGetTransaction(
+			mockTransfer,
+			MockTokenizedUser.WalletNumber,
+		),
+	})
+	require.NoError(t, err)
+
+	runPaymentRequestHandlerTests(
+		t,
+		http.MethodPost,
+		"/api/payment-requests/:id/approve",
+		func(h *Handler) gin.HandlerFunc { return h.ApprovePaymentRequest },
+		[]paymentRequestHandlerTest{
+			{
+				name:                   "Error | Invalid ID",
+				path:                   "/api/payment-requests/abc/approve",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock:                   func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: http.StatusText(http.StatusBadRequest),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Wrong PIN",
+				path:                   "/api/payment-requests/1/approve",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {
+					pin.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).
+						Return(&custom_error.WrongPin{RemainingAttempts: 2})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusForbidden,
+					Message: custom_error.WrongPin{RemainingAttempts: 2}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Payment request is not pending",
+				path:                   "/api/payment-requests/1/approve",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {
+					pin.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+					ps.On("Approve", 1, MockTokenizedUser.WalletNumber).
+						Return(nil, nil, &custom_error.PaymentRequestNotPending{Status: "EXPIRED"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.PaymentRequestNotPending{Status: "EXPIRED"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Other error from service",
+				path:                   "/api/payment-requests/1/approve",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {
+					pin.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+					ps.On("Approve", 1, MockTokenizedUser.WalletNumber).
+						Return(nil, nil, fmt.Errorf("error"))
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: http.StatusText(http.StatusInternalServerError),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/payment-requests/1/approve",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {
+					pin.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+					ps.On("Approve", 1, MockTokenizedUser.WalletNumber).
+						Return(mockPaymentRequest, mockTransfer, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_DeclinePaymentRequest(t *testing.T) {
+	mockPaymentRequest := &entity.PaymentRequest{
+		Base:   entity.Base{ID: 1},
+		Status: entity.PaymentRequestDeclined,
+	}
+	mockDataInInterface, err := StructToMap(
+		dto.FormatPaymentRequest(mockPaymentRequest),
+	)
+	require.NoError(t, err)
+
+	runPaymentRequestHandlerTests(
+		t,
+		http.MethodPost,
+		"/api/payment-requests/:id/decline",
+		func(h *Handler) gin.HandlerFunc { return h.DeclinePaymentRequest },
+		[]paymentRequestHandlerTest{
+			{
+				name:                   "Error | Failed to get user key from middleware",
+				path:                   "/api/payment-requests/1/decline",
+				mockUserFromMiddleware: false,
+				mock:                   func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: custom_error.FailedToGetInfoFromToken{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Payment request not found",
+				path:                   "/api/payment-requests/1/decline",
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {
+					ps.On("Decline", 1, MockTokenizedUser.WalletNumber).
+						Return(nil, &custom_error.NoDataFound{DataType: "payment request"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusNotFound,
+					Message: custom_error.NoDataFound{DataType: "payment request"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/payment-requests/1/decline",
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {
+					ps.On("Decline", 1, MockTokenizedUser.WalletNumber).
+						Return(mockPaymentRequest, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_GetIncomingPaymentRequests(t *testing.T) {
+	runPaymentRequestHandlerTests(
+		t,
+		http.MethodGet,
+		"/api/payment-requests/incoming",
+		func(h *Handler) gin.HandlerFunc { return h.GetIncomingPaymentRequests },
+		[]paymentRequestHandlerTest{
+			{
+				name:                   "Error | Error from service",
+				path:                   "/api/payment-requests/incoming",
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {
+					ps.On("FindIncoming", MockTokenizedUser.WalletNumber).
+						Return(nil, fmt.Errorf("error"))
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: http.StatusText(http.StatusInternalServerError),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/payment-requests/incoming",
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {
+					ps.On("FindIncoming", MockTokenizedUser.WalletNumber).
+						Return([]*entity.PaymentRequest{}, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    []interface{}{},
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_GetOutgoingPaymentRequests(t *testing.T) {
+	runPaymentRequestHandlerTests(
+		t,
+		http.MethodGet,
+		"/api/payment-requests/outgoing",
+		func(h *Handler) gin.HandlerFunc { return h.GetOutgoingPaymentRequests },
+		[]paymentRequestHandlerTest{
+			{
+				name:                   "Success",
+				path:                   "/api/payment-requests/outgoing",
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPaymentRequestService, pin *mocks.IPinService) {
+					ps.On("FindOutgoing", MockTokenizedUser.WalletNumber).
+						Return([]*entity.PaymentRequest{}, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    []interface{}{},
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"net/http"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) initPinRoutes(api *gin.RouterGroup) {
+	pin := api.Group("/users/pin")
+	{
+		pin.POST("", h.SetPin)
+		pin.PUT("", h.ChangePin)
+		pin.POST("/reset", h.ResetPin)
+	}
+}
+
+func (h *Handler) SetPin(ctx *gin.Context) {
+	var input dto.SetPinRequestBody
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	if !helper.IsValidPin(input.Pin) {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidPinFormat{Length: helper.PIN_LENGTH}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	err = h.services.Pin.SetPin(user.(*entity.TokenizedUser).ID, input.Pin)
+	if err != nil {
+		writePinErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		nil,
+	)
+}
+
+func (h *Handler) ChangePin(ctx *gin.Context) {
+	var input dto.ChangePinRequestBody
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	if !helper.IsValidPin(input.NewPin) {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidPinFormat{Length: helper.PIN_LENGTH}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	err = h.services.Pin.ChangePin(
+		user.(*entity.TokenizedUser).ID,
+		input.OldPin,
+		input.NewPin,
+	)
+	if err != nil {
+		writePinErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		nil,
+	)
+}
+
+func (h *Handler) ResetPin(ctx *gin.Context) {
+	var input dto.ResetPinRequestBody
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	if !helper.IsValidPin(input.NewPin) {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidPinFormat{Length: helper.PIN_LENGTH}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	err = h.services.Pin.ResetPin(
+		user.(*entity.TokenizedUser).ID,
+		input.Password,
+		input.NewPin,
+	)
+	if err != nil {
+		writePinErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		nil,
+	)
+}
+
+func writePinErrorResponse(ctx *gin.Context, err error) {
+	switch err.(type) {
+	case *custom_error.PinNotSet,
+		*custom_error.WrongPin,
+		*custom_error.PinLocked,
+		*custom_error.WrongPassword:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusForbidden,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.PinAlreadySet:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.NoDataFound:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+	default:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+	}
+}
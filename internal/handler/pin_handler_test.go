@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
+	"assignment-golang-backend/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pinHandlerTest struct {
+	name                   string
+	body                   io.Reader
+	mockUserFromMiddleware bool
+	mock                   func(*mocks.IPinService)
+	want                   helper.JsonResponse
+}
+
+func runPinHandlerTests(
+	t *testing.T,
+	method string,
+	endpoint string,
+	handlerFunc func(*Handler) gin.HandlerFunc,
+	tests []pinHandlerTest,
+) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pinService := mocks.NewIPinService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					Pin: pinService,
+				},
+			}
+
+			tt.mock(pinService)
+
+			r := SetUpRouter()
+			if tt.mockUserFromMiddleware {
+				r.Handle(method, endpoint, MiddlewareMockUser, handlerFunc(h))
+			} else {
+				r.Handle(method, endpoint, handlerFunc(h))
+			}
+			req, _ := http.NewRequest(method, endpoint, tt.body)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_initPinRoutes(t *testing.T) {
+	router := SetUpRouter()
+	handler := New(&usecase.Services{})
+	group := router.Group("/")
+
+	handler.initPinRoutes(group)
+}
+
+func TestHandler_SetPin(t *testing.T) {
+	validBody := &dto.SetPinRequestBody{Pin: "123456"}
+
+	runPinHandlerTests(
+		t,
+		http.MethodPost,
+		"/api/users/pin",
+		func(h *Handler) gin.HandlerFunc { return h.SetPin },
+		[]pinHandlerTest{
+			{
+				name:                   "Error | Invalid Request Body",
+				body:                   MakeRequestBody(&dto.SetPinRequestBody{}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ps *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidRequestBody{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | PIN is not 6 digits",
+				body:                   MakeRequestBody(&dto.SetPinRequestBody{Pin: "12a456"}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ps *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidPinFormat{Length: helper.PIN_LENGTH}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Failed to get user key from middleware",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: false,
+				mock:                   func(ps *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: custom_error.FailedToGetInfoFromToken{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | PIN already set",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPinService) {
+					ps.On("SetPin", MockTokenizedUser.ID, validBody.Pin).
+						Return(&custom_error.PinAlreadySet{})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.PinAlreadySet{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Other error from PinService",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPinService) {
+					ps.On("SetPin", MockTokenizedUser.ID, validBody.Pin).
+						Return(fmt.Errorf("error"))
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: http.StatusText(http.StatusInternalServerError),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPinService) {
+					ps.On("SetPin", MockTokenizedUser.ID, validBody.Pin).
+						Return(nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    nil,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_ChangePin(t *testing.T) {
+	validBody := &dto.ChangePinRequestBody{OldPin: "123456", NewPin: "654321"}
+
+	runPinHandlerTests(
+		t,
+		http.MethodPut,
+		"/api/users/pin",
+		func(h *Handler) gin.HandlerFunc { return h.ChangePin },
+		[]pinHandlerTest{
+			{
+				name: "Error | New PIN is not 6 digits",
+				body: MakeRequestBody(&dto.ChangePinRequestBody{
+					OldPin: "123456",
+					NewPin: "1234",
+				}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ps *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidPinFormat{Length: helper.PIN_LENGTH}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Wrong old PIN",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPinService) {
+					ps.On("ChangePin", MockTokenizedUser.ID, validBody.OldPin, validBody.NewPin).
+						Return(&custom_error.WrongPin{RemainingAttempts: 1})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusForbidden,
+					Message: custom_error.WrongPin{RemainingAttempts: 1}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPinService) {
+					ps.On("ChangePin", MockTokenizedUser.ID, validBody.OldPin, validBody.NewPin).
+						Return(nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    nil,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_ResetPin(t *testing.T) {
+	validBody := &dto.ResetPinRequestBody{Password: "password", NewPin: "654321"}
+
+	runPinHandlerTests(
+		t,
+		http.MethodPost,
+		"/api/users/pin/reset",
+		func(h *Handler) gin.HandlerFunc { return h.ResetPin },
+		[]pinHandlerTest{
+			{
+				name:                   "Error | Invalid Request Body",
+				body:                   MakeRequestBody(&dto.ResetPinRequestBody{NewPin: "654321"}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ps *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidRequestBody{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Wrong password",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPinService) {
+					ps.On("ResetPin", MockTokenizedUser.ID, validBody.Password, validBody.NewPin).
+						Return(&custom_error.WrongPassword{})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusForbidden,
+					Message: custom_error.WrongPassword{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | User not found",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPinService) {
+					ps.On("ResetPin", MockTokenizedUser.ID, validBody.Password, validBody.NewPin).
+						Return(&custom_error.NoDataFound{DataType: "user"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusNotFound,
+					Message: custom_error.NoDataFound{DataType: "user"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ps *mocks.IPinService) {
+					ps.On("ResetPin", MockTokenizedUser.ID, validBody.Password, validBody.NewPin).
+						Return(nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    nil,
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) initScheduledTransferRoutes(transaction *gin.RouterGroup) {
+	scheduled := transaction.Group("/scheduled")
+	{
+		scheduled.POST("", h.CreateScheduledTransfer)
+		scheduled.GET("", h.GetScheduledTransfers)
+		scheduled.GET("/:id", h.GetScheduledTransfer)
+		scheduled.PUT("/:id", h.UpdateScheduledTransfer)
+		scheduled.DELETE("/:id", h.CancelScheduledTransfer)
+	}
+}
+
+func (h *Handler) CreateScheduledTransfer(ctx *gin.Context) {
+	scheduledTransfer, ok := h.bindScheduledTransfer(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := h.services.Schedule.CreateScheduledTransfer(scheduledTransfer)
+	if err != nil {
+		writeScheduledTransferErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		dto.FormatScheduledTransfer(res),
+	)
+}
+
+func (h *Handler) GetScheduledTransfers(ctx *gin.Context) {
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.Schedule.FindByUserID(user.(*entity.TokenizedUser).ID)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatMultipleScheduledTransfer(res),
+	)
+}
+
+func (h *Handler) GetScheduledTransfer(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.Schedule.FindByID(
+		id,
+		user.(*entity.TokenizedUser).ID,
+	)
+	if err != nil {
+		writeScheduledTransferErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatScheduledTransfer(res),
+	)
+}
+
+func (h *Handler) UpdateScheduledTransfer(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	scheduledTransfer, ok := h.bindScheduledTransfer(ctx)
+	if !ok {
+		return
+	}
+	scheduledTransfer.ID = id
+
+	res, err := h.services.Schedule.UpdateScheduledTransfer(scheduledTransfer)
+	if err != nil {
+		writeScheduledTransferErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatScheduledTransfer(res),
+	)
+}
+
+func (h *Handler) CancelScheduledTransfer(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	err = h.services.Schedule.CancelScheduledTransfer(
+		id,
+		user.(*entity.TokenizedUser).ID,
+	)
+	if err != nil {
+		writeScheduledTransferErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		nil,
+	)
+}
+
+// bindScheduledTransfer validates a create or update request the same way
+// Transfer does, PIN included, and writes the error response when it fails.
+func (h *Handler) bindScheduledTransfer(
+	ctx *gin.Context,
+) (*entity.ScheduledTransfer, bool) {
+	var input dto.ScheduledTransferRequestBody
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil || !input.Recurrence.IsValid() {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return nil, false
+	}
+
+	if !input.StartAt.After(time.Now()) {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.ScheduleStartInPast{}.Error(),
+			nil,
+		)
+		return nil, false
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return nil, false
+	}
+	tokenizedUser := user.(*entity.TokenizedUser)
+
+	if tokenizedUser.WalletNumber == input.To {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.CannotTransferToSameWallet{}.Error(),
+			nil,
+		)
+		return nil, false
+	}
+
+	err = h.services.Pin.VerifyPin(tokenizedUser.ID, input.Pin)
+	if err != nil {
+		writePinErrorResponse(ctx, err)
+		return nil, false
+	}
+
+	return &entity.ScheduledTransfer{
+		UserID:      tokenizedUser.ID,
+		From:        tokenizedUser.WalletNumber,
+		To:          input.To,
+		Amount:      input.Amount,
+		Description: input.Description,
+		Recurrence:  input.Recurrence,
+		StartAt:     input.StartAt,
+	}, true
+}
+
+func writeScheduledTransferErrorResponse(ctx *gin.Context, err error) {
+	switch err.(type) {
+	case *custom_error.NoDataFound:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.ScheduledTransferNotActive,
+		*custom_error.AmountNotInRange,
+		*custom_error.CannotTransferToHouseWallet:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+	default:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+	}
+}
@@ -0,0 +1,406 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
+	"assignment-golang-backend/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type scheduledTransferHandlerTest struct {
+	name                   string
+	path                   string
+	body                   io.Reader
+	mockUserFromMiddleware bool
+	mock                   func(*mocks.IScheduledTransferService, *mocks.IPinService)
+	want                   helper.JsonResponse
+}
+
+func runScheduledTransferHandlerTests(
+	t *testing.T,
+	method string,
+	route string,
+	handlerFunc func(*Handler) gin.HandlerFunc,
+	tests []scheduledTransferHandlerTest,
+) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduledTransferService := mocks.NewIScheduledTransferService(t)
+			pinService := mocks.NewIPinService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					Schedule: scheduledTransferService,
+					Pin:      pinService,
+				},
+			}
+
+			tt.mock(scheduledTransferService, pinService)
+
+			r := SetUpRouter()
+			if tt.mockUserFromMiddleware {
+				r.Handle(method, route, MiddlewareMockUser, handlerFunc(h))
+			} else {
+				r.Handle(method, route, handlerFunc(h))
+			}
+			req, _ := http.NewRequest(method, tt.path, tt.body)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_initScheduledTransferRoutes(t *testing.T) {
+	router := SetUpRouter()
+	handler := New(&usecase.Services{})
+	group := router.Group("/")
+
+	handler.initScheduledTransferRoutes(group)
+}
+
+func TestHandler_CreateScheduledTransfer(t *testing.T) {
+	startAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	validBody := &dto.ScheduledTransferRequestBody{
+		To:          2,
+		Amount:      1000,
+		Description: "rent",
+		StartAt:     startAt,
+		Recurrence:  entity.RecurrenceMonthly,
+		Pin:         "123456",
+	}
+	mockScheduledTransfer := &entity.ScheduledTransfer{
+		Base:        entity.Base{ID: 1},
+		UserID:      MockTokenizedUser.ID,
+		From:        MockTokenizedUser.WalletNumber,
+		To:          validBody.To,
+		Amount:      validBody.Amount,
+		Description: validBody.Description,
+		Recurrence:  validBody.Recurrence,
+		StartAt:     startAt,
+		NextRunAt:   &startAt,
+		Status:      entity.ScheduledTransferActive,
+	}
+	mockDataInInterface, err := StructToMap(
+		dto.FormatScheduledTransfer(mockScheduledTransfer),
+	)
+	require.NoError(t, err)
+	isScheduledTransfer := mock.MatchedBy(func(scheduledTransfer *entity.ScheduledTransfer) bool {
+		return scheduledTransfer.UserID == MockTokenizedUser.ID &&
+			scheduledTransfer.From == MockTokenizedUser.WalletNumber &&
+			scheduledTransfer.To == validBody.To &&
+			scheduledTransfer.StartAt.Equal(startAt)
+	})
+
+	runScheduledTransferHandlerTests(
+		t,
+		http.MethodPost,
+		"/api/transactions/scheduled",
+		func(h *Handler) gin.HandlerFunc { return h.CreateScheduledTransfer },
+		[]scheduledTransferHandlerTest{
+			{
+				name: "Error | Invalid recurrence",
+				path: "/api/transactions/scheduled",
+				body: MakeRequestBody(&dto.ScheduledTransferRequestBody{
+					To:         2,
+					Amount:     1000,
+					StartAt:    startAt,
+					Recurrence: "YEARLY",
+					Pin:        "123456",
+				}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidRequestBody{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name: "Error | Start time in the past",
+				path: "/api/transactions/scheduled",
+				body: MakeRequestBody(&dto.ScheduledTransferRequestBody{
+					To:         2,
+					Amount:     1000,
+					StartAt:    time.Now().Add(-time.Hour),
+					Recurrence: entity.RecurrenceOnce,
+					Pin:        "123456",
+				}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.ScheduleStartInPast{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name: "Error | Transfer to own wallet",
+				path: "/api/transactions/scheduled",
+				body: MakeRequestBody(&dto.ScheduledTransferRequestBody{
+					To:         MockTokenizedUser.WalletNumber,
+					Amount:     1000,
+					StartAt:    startAt,
+					Recurrence: entity.RecurrenceOnce,
+					Pin:        "123456",
+				}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.CannotTransferToSameWallet{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Wrong PIN",
+				path:                   "/api/transactions/scheduled",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {
+					pin.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).
+						Return(&custom_error.WrongPin{RemainingAttempts: 2})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusForbidden,
+					Message: custom_error.WrongPin{RemainingAttempts: 2}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Destination wallet not found",
+				path:                   "/api/transactions/scheduled",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {
+					pin.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+					ss.On("CreateScheduledTransfer", isScheduledTransfer).
+						Return(nil, &custom_error.NoDataFound{DataType: "destination wallet"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusNotFound,
+					Message: custom_error.NoDataFound{DataType: "destination wallet"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/transactions/scheduled",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {
+					pin.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+					ss.On("CreateScheduledTransfer", isScheduledTransfer).
+						Return(mockScheduledTransfer, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusCreated,
+					Message: http.StatusText(http.StatusCreated),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_GetScheduledTransfer(t *testing.T) {
+	transactionID := 10
+	mockScheduledTransfer := &entity.ScheduledTransfer{
+		Base:       entity.Base{ID: 1},
+		Recurrence: entity.RecurrenceOnce,
+		Status:     entity.ScheduledTransferCompleted,
+		Runs: []entity.ScheduledTransferRun{
+			{
+				Status:        entity.ScheduledRunSucceeded,
+				TransactionID: &transactionID,
+			},
+		},
+	}
+	mockDataInInterface, err := StructToMap(
+		dto.FormatScheduledTransfer(mockScheduledTransfer),
+	)
+	require.NoError(t, err)
+
+	runScheduledTransferHandlerTests(
+		t,
+		http.MethodGet,
+		"/api/transactions/scheduled/:id",
+		func(h *Handler) gin.HandlerFunc { return h.GetScheduledTransfer },
+		[]scheduledTransferHandlerTest{
+			{
+				name:                   "Error | Invalid ID",
+				path:                   "/api/transactions/scheduled/abc",
+				mockUserFromMiddleware: true,
+				mock:                   func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: http.StatusText(http.StatusBadRequest),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Scheduled transfer not found",
+				path:                   "/api/transactions/scheduled/1",
+				mockUserFromMiddleware: true,
+				mock: func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {
+					ss.On("FindByID", 1, MockTokenizedUser.ID).
+						Return(nil, &custom_error.NoDataFound{DataType: "scheduled transfer"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusNotFound,
+					Message: custom_error.NoDataFound{DataType: "scheduled transfer"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/transactions/scheduled/1",
+				mockUserFromMiddleware: true,
+				mock: func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {
+					ss.On("FindByID", 1, MockTokenizedUser.ID).
+						Return(mockScheduledTransfer, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_GetScheduledTransfers(t *testing.T) {
+	runScheduledTransferHandlerTests(
+		t,
+		http.MethodGet,
+		"/api/transactions/scheduled",
+		func(h *Handler) gin.HandlerFunc { return h.GetScheduledTransfers },
+		[]scheduledTransferHandlerTest{
+			{
+				name:                   "Error | Error from service",
+				path:                   "/api/transactions/scheduled",
+				mockUserFromMiddleware: true,
+				mock: func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {
+					ss.On("FindByUserID", MockTokenizedUser.ID).
+						Return(nil, fmt.Errorf("error"))
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: http.StatusText(http.StatusInternalServerError),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/transactions/scheduled",
+				mockUserFromMiddleware: true,
+				mock: func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {
+					ss.On("FindByUserID", MockTokenizedUser.ID).
+						Return([]*entity.ScheduledTransfer{}, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    []interface{}{},
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_UpdateScheduledTransfer(t *testing.T) {
+	startAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	validBody := &dto.ScheduledTransferRequestBody{
+		To:         2,
+		Amount:     1000,
+		StartAt:    startAt,
+		Recurrence: entity.RecurrenceWeekly,
+		Pin:        "123456",
+	}
+	isScheduledTransfer := mock.MatchedBy(func(scheduledTransfer *entity.ScheduledTransfer) bool {
+		return scheduledTransfer.ID == 1 &&
+			scheduledTransfer.UserID == MockTokenizedUser.ID &&
+			scheduledTransfer.Recurrence == entity.RecurrenceWeekly
+	})
+
+	runScheduledTransferHandlerTests(
+		t,
+		http.MethodPut,
+		"/api/transactions/scheduled/:id",
+		func(h *Handler) gin.HandlerFunc { return h.UpdateScheduledTransfer },
+		[]scheduledTransferHandlerTest{
+			{
+				name:                   "Error | Scheduled transfer is not active",
+				path:                   "/api/transactions/scheduled/1",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {
+					pin.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+					ss.On("UpdateScheduledTransfer", isScheduledTransfer).
+						Return(nil, &custom_error.ScheduledTransferNotActive{Status: "CANCELLED"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.ScheduledTransferNotActive{Status: "CANCELLED"}.Error(),
+					Data:    nil,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_CancelScheduledTransfer(t *testing.T) {
+	runScheduledTransferHandlerTests(
+		t,
+		http.MethodDelete,
+		"/api/transactions/scheduled/:id",
+		func(h *Handler) gin.HandlerFunc { return h.CancelScheduledTransfer },
+		[]scheduledTransferHandlerTest{
+			{
+				name:                   "Error | Failed to get user key from middleware",
+				path:                   "/api/transactions/scheduled/1",
+				mockUserFromMiddleware: false,
+				mock:                   func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: custom_error.FailedToGetInfoFromToken{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				path:                   "/api/transactions/scheduled/1",
+				mockUserFromMiddleware: true,
+				mock: func(ss *mocks.IScheduledTransferService, pin *mocks.IPinService) {
+					ss.On("CancelScheduledTransfer", 1, MockTokenizedUser.ID).Return(nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    nil,
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) initSourceOfFundsRoutes(api *gin.RouterGroup) {
+	api.GET("/sources-of-funds", h.GetSourcesOfFunds)
+}
+
+func (h *Handler) GetSourcesOfFunds(ctx *gin.Context) {
+	res, err := h.services.SourceOfFunds.FindEnabled()
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatMultipleSourceOfFunds(res),
+	)
+}
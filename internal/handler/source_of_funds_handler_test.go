@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_initSourceOfFundsRoutes(t *testing.T) {
+	router := SetUpRouter()
+	handler := New(&usecase.Services{})
+	group := router.Group("/")
+
+	handler.initSourceOfFundsRoutes(group)
+}
+
+func TestHandler_GetSourcesOfFunds(t *testing.T) {
+	mockSources := []*entity.SourceOfFunds{
+		{
+			Base:           entity.Base{ID: 1},
+			Name:           "Bank Transfer",
+			Provider:       "fake",
+			Enabled:        true,
+			MinTopupAmount: 50000,
+			MaxTopupAmount: 10000000,
+		},
+	}
+	var mockSourcesInInterface []interface{}
+	for _, source := range dto.FormatMultipleSourceOfFunds(mockSources) {
+		sourceInInterface, err := StructToMap(source)
+		require.NoError(t, err)
+		mockSourcesInInterface = append(mockSourcesInInterface, sourceInInterface)
+	}
+
+	tests := []struct {
+		name string
+		mock func(*mocks.ISourceOfFundsService)
+		want helper.JsonResponse
+	}{
+		{
+			name: "Error | Error from services",
+			mock: func(ss *mocks.ISourceOfFundsService) {
+				ss.On("FindEnabled").Return(nil, fmt.Errorf("error"))
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+				Data:    nil,
+			},
+		},
+		{
+			name: "Success",
+			mock: func(ss *mocks.ISourceOfFundsService) {
+				ss.On("FindEnabled").Return(mockSources, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockSourcesInInterface,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourceOfFundsService := mocks.NewISourceOfFundsService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					SourceOfFunds: sourceOfFundsService,
+				},
+			}
+
+			tt.mock(sourceOfFundsService)
+
+			r := SetUpRouter()
+			r.GET("/api/sources-of-funds", h.GetSourcesOfFunds)
+
+			req, _ := http.NewRequest(
+				http.MethodGet,
+				"/api/sources-of-funds",
+				nil,
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
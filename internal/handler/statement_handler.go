@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/statement"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) initStatementRoutes(transaction *gin.RouterGroup) {
+	transaction.GET("/export", h.ExportStatement)
+}
+
+// ExportStatement streams the statement of one of the user's wallets for a
+// period, as CSV by default or as PDF. The running balance starts from the
+// opening balance and adds the amount of every line, so the closing
+// balance is the one the ledger holds at the end of the period. Once the
+// statement has started an error can only cut it short.
+func (h *Handler) ExportStatement(ctx *gin.Context) {
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+	tokenizedUser := user.(*entity.TokenizedUser)
+
+	var input dto.StatementQuery
+	err := ctx.ShouldBindQuery(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	from, _ := time.Parse(dto.TRANSACTION_HISTORY_DATE_LAYOUT, input.From)
+	to, _ := time.Parse(dto.TRANSACTION_HISTORY_DATE_LAYOUT, input.To)
+	// To includes the whole day.
+	to = to.AddDate(0, 0, 1)
+	if !from.Before(to) {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	if input.Format == "" {
+		input.Format = statement.CSV
+	}
+
+	walletNumber, ok := h.ownedWalletNumber(ctx, tokenizedUser, input.WalletNumber)
+	if !ok {
+		return
+	}
+
+	walletStatement, err := h.services.Statement.Open(walletNumber, from, to)
+
+	if _, ok := err.(*custom_error.NoDataFound); ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+		return
+	}
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	writer, _ := statement.NewWriter(input.Format, ctx.Writer)
+	ctx.Header("Content-Type", writer.ContentType())
+	ctx.Header("Content-Disposition", fmt.Sprintf(
+		"attachment; filename=%q",
+		statement.FileName(walletStatement, input.Format),
+	))
+	ctx.Status(http.StatusOK)
+
+	err = writer.WriteHeader(walletStatement)
+	balance := walletStatement.OpeningBalance
+	if err == nil {
+		err = h.services.Statement.EachLine(
+			walletStatement,
+			func(line *entity.StatementLine) error {
+				balance += line.Amount
+
+				row := &statement.Row{
+					Datetime:    line.Datetime,
+					Type:        line.Type,
+					Description: line.Description,
+					Amount:      line.Amount,
+					Balance:     balance,
+				}
+				if line.TransactionID != nil {
+					row.ID = *line.TransactionID
+				}
+
+				return writer.WriteRow(row)
+			},
+		)
+	}
+
+	if err == nil {
+		err = writer.Close(balance)
+	}
+
+	if err != nil {
+		_ = ctx.Error(err)
+		ctx.Abort()
+	}
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_initStatementRoutes(t *testing.T) {
+	router := SetUpRouter()
+	handler := New(&usecase.Services{})
+	group := router.Group("/")
+
+	handler.initStatementRoutes(group)
+}
+
+func TestHandler_ExportStatement(t *testing.T) {
+	from := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2030, time.February, 1, 0, 0, 0, 0, time.UTC)
+	mockStatement := &entity.Statement{
+		Wallet:         &entity.Wallet{Number: MockTokenizedUser.WalletNumber, Currency: entity.IDR},
+		From:           from,
+		To:             to,
+		OpeningBalance: 100000,
+	}
+	transactionID := func(id int) *int {
+		return &id
+	}
+	mockLines := []*entity.StatementLine{
+		{
+			JournalEntryID: 1,
+			TransactionID:  transactionID(1),
+			Type:           entity.TopUp,
+			Datetime:       from.Add(time.Hour),
+			Amount:         50000,
+		},
+		{
+			JournalEntryID: 2,
+			TransactionID:  transactionID(2),
+			Type:           entity.Transfer,
+			Description:    "Rent",
+			Datetime:       from.Add(2 * time.Hour),
+			Amount:         -21000,
+		},
+		{
+			JournalEntryID: 3,
+			TransactionID:  transactionID(3),
+			Type:           entity.Withdrawal,
+			Description:    "Failed withdrawal 3",
+			Datetime:       from.Add(3 * time.Hour),
+			Amount:         10000,
+		},
+		{
+			JournalEntryID: 4,
+			Type:           entity.StatementAdjustment,
+			Description:    "Balance adjustment of wallet 1",
+			Datetime:       from.Add(4 * time.Hour),
+			Amount:         -500,
+		},
+	}
+	eachLine := func(ss *mocks.IStatementService) {
+		ss.On("EachLine", mockStatement, mock.Anything).
+			Return(func(_ *entity.Statement, fn func(*entity.StatementLine) error) error {
+				for _, line := range mockLines {
+					err := fn(line)
+					if err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name                   string
+		query                  string
+		mockUserFromMiddleware bool
+		mock                   func(*mocks.IStatementService)
+		wantCode               int
+		wantContentType        string
+		wantBody               string
+		wantError              *helper.JsonResponse
+	}{
+		{
+			name:                   "Error | Failed to get user key from middleware",
+			query:                  "?from=2030-01-01&to=2030-01-31",
+			mockUserFromMiddleware: false,
+			mock:                   func(ss *mocks.IStatementService) {},
+			wantError: &helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: custom_error.FailedToGetInfoFromToken{}.Error(),
+			},
+		},
+		{
+			name:                   "Error | Missing period",
+			query:                  "?format=csv",
+			mockUserFromMiddleware: true,
+			mock:                   func(ss *mocks.IStatementService) {},
+			wantError: &helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+		},
+		{
+			name:                   "Error | Unknown format",
+			query:                  "?from=2030-01-01&to=2030-01-31&format=xlsx",
+			mockUserFromMiddleware: true,
+			mock:                   func(ss *mocks.IStatementService) {},
+			wantError: &helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+		},
+		{
+			name:                   "Error | Period ends before it starts",
+			query:                  "?from=2030-02-01&to=2030-01-01",
+			mockUserFromMiddleware: true,
+			mock:                   func(ss *mocks.IStatementService) {},
+			wantError: &helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+			},
+		},
+		{
+			name:                   "Error | Wallet not found",
+			query:                  "?from=2030-01-01&to=2030-01-31",
+			mockUserFromMiddleware: true,
+			mock: func(ss *mocks.IStatementService) {
+				ss.On("Open", MockTokenizedUser.WalletNumber, from, to).
+					Return(nil, &custom_error.NoDataFound{DataType: "wallet"})
+			},
+			wantError: &helper.JsonResponse{
+				Code:    http.StatusNotFound,
+				Message: custom_error.NoDataFound{DataType: "wallet"}.Error(),
+			},
+		},
+		{
+			name:                   "Error | Other error from service",
+			query:                  "?from=2030-01-01&to=2030-01-31",
+			mockUserFromMiddleware: true,
+			mock: func(ss *mocks.IStatementService) {
+				ss.On("Open", MockTokenizedUser.WalletNumber, from, to).
+					Return(nil, fmt.Errorf("error"))
+			},
+			wantError: &helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+			},
+		},
+		{
+			name:                   "Success | CSV with the running balance of every ledger line",
+			query:                  "?from=2030-01-01&to=2030-01-31",
+			mockUserFromMiddleware: true,
+			mock: func(ss *mocks.IStatementService) {
+				ss.On("Open", MockTokenizedUser.WalletNumber, from, to).
+					Return(mockStatement, nil)
+				eachLine(ss)
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: "text/csv",
+			wantBody: "datetime,id,type,description,amount,balance,currency\n" +
+				"2030-01-01T00:00:00Z,,OPENING_BALANCE,Opening balance,,100000,IDR\n" +
+				"2030-01-01T01:00:00Z,1,TOP_UP,,50000,150000,IDR\n" +
+				"2030-01-01T02:00:00Z,2,TRANSFER,Rent,-21000,129000,IDR\n" +
+				"2030-01-01T03:00:00Z,3,WITHDRAWAL,Failed withdrawal 3,10000,139000,IDR\n" +
+				"2030-01-01T04:00:00Z,,ADJUSTMENT,Balance adjustment of wallet 1,-500,138500,IDR\n" +
+				"2030-02-01T00:00:00Z,,CLOSING_BALANCE,Closing balance,,138500,IDR\n",
+		},
+		{
+			name:                   "Success | PDF",
+			query:                  "?from=2030-01-01&to=2030-01-31&format=pdf",
+			mockUserFromMiddleware: true,
+			mock: func(ss *mocks.IStatementService) {
+				ss.On("Open", MockTokenizedUser.WalletNumber, from, to).
+					Return(mockStatement, nil)
+				eachLine(ss)
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: "application/pdf",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := mocks.NewIStatementService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					Statement: ss,
+				},
+			}
+
+			tt.mock(ss)
+
+			r := SetUpRouter()
+
+			endpoint := "/api/transactions/export"
+			if tt.mockUserFromMiddleware {
+				r.GET(endpoint, MiddlewareMockUser, h.ExportStatement)
+			} else {
+				r.GET(endpoint, h.ExportStatement)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, endpoint+tt.query, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if tt.wantError != nil {
+				var response helper.JsonResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				assert.Equal(t, tt.wantError.Code, w.Code)
+				assert.Equal(t, *tt.wantError, response)
+				return
+			}
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			assert.True(t, strings.HasPrefix(
+				w.Header().Get("Content-Disposition"),
+				"attachment; filename=\"statement-1-2030-01-01-2030-01-31.",
+			))
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			} else {
+				assert.True(t, strings.HasPrefix(w.Body.String(), "%PDF-"))
+			}
+		})
+	}
+}
@@ -10,23 +10,39 @@ import (
 	"assignment-golang-backend/internal/dto"
 	"assignment-golang-backend/internal/entity"
 	"assignment-golang-backend/internal/helper"
+	middlewares "assignment-golang-backend/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-const (
-	MIN_TOPUP_AMOUNT    = 50000
-	MAX_TOPUP_AMOUNT    = 10000000
-	MIN_TRANSFER_AMOUNT = 1000
-	MAX_TRANSFER_AMOUNT = 50000000
-)
-
 func (h *Handler) initTransactionRoutes(api *gin.RouterGroup) {
 	transaction := api.Group("/transactions")
 	{
 		transaction.GET("/", h.GetTransactionsByWalletNumber)
-		transaction.POST("/topup", h.Topup)
-		transaction.POST("/transfer", h.Transfer)
+		transaction.GET("/:id", h.GetTransaction)
+		transaction.POST(
+			"/topup",
+			middlewares.Idempotency(h.services.Idempotency),
+			h.Topup,
+		)
+		transaction.POST(
+			"/transfer",
+			middlewares.Idempotency(h.services.Idempotency),
+			h.Transfer,
+		)
+		transaction.POST(
+			"/withdraw",
+			middlewares.Idempotency(h.services.Idempotency),
+			h.Withdraw,
+		)
+		transaction.POST(
+			"/:id/refund",
+			middlewares.Idempotency(h.services.Idempotency),
+			h.Refund,
+		)
+		h.initScheduledTransferRoutes(transaction)
+		h.initFeeRoutes(transaction)
+		h.initStatementRoutes(transaction)
 	}
 }
 
@@ -43,9 +59,37 @@ func (h *Handler) GetTransactionsByWalletNumber(ctx *gin.Context) {
 	}
 	tokenizedUser := user.(*entity.TokenizedUser)
 
-	search := ctx.DefaultQuery("s", "")
-	sortBy := ctx.DefaultQuery("sortBy", "datetime")
-	sortMethod := ctx.DefaultQuery("sort", "desc")
+	walletNumber, err := strconv.Atoi(ctx.DefaultQuery("wallet_number", "0"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	walletNumber, ok = h.ownedWalletNumber(ctx, tokenizedUser, walletNumber)
+	if !ok {
+		return
+	}
+
+	filter, ok := transactionFilter(ctx)
+	if !ok {
+		return
+	}
+
+	if cursor, ok := ctx.GetQuery("cursor"); ok {
+		h.getTransactionsAfterCursor(ctx, walletNumber, filter, cursor)
+		return
+	}
+
+	sort, ok := transactionSort(ctx, entity.TransactionSortFields)
+	if !ok {
+		return
+	}
+
 	limit, err1 := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 	page, err2 := strconv.Atoi(ctx.DefaultQuery("page", "1"))
 
@@ -70,15 +114,14 @@ func (h *Handler) GetTransactionsByWalletNumber(ctx *gin.Context) {
 	}
 
 	pagination := &entity.Pagination{
-		Limit:  limit,
-		Page:   page,
-		Search: search,
-		SortBy: sortBy,
-		Sort:   sortMethod,
+		Limit: limit,
+		Page:  page,
+		Sort:  sort,
 	}
 
 	transactions, pagination, err := h.services.Transaction.FindByWalletNumber(
-		tokenizedUser.WalletNumber,
+		walletNumber,
+		filter,
 		pagination,
 	)
 
@@ -106,7 +149,7 @@ func (h *Handler) GetTransactionsByWalletNumber(ctx *gin.Context) {
 	resFormatted := dto.FormatGetTransactionsByWalletNumberResponseBody(
 		transactions,
 		pagination,
-		tokenizedUser.WalletNumber,
+		walletNumber,
 	)
 
 	helper.WriteSuccessResponse(
@@ -130,23 +173,6 @@ func (h *Handler) Transfer(ctx *gin.Context) {
 		return
 	}
 
-	if !helper.IsBetweenRange(
-		input.Amount,
-		MIN_TRANSFER_AMOUNT,
-		MAX_TRANSFER_AMOUNT,
-	) {
-		helper.WriteErrorResponse(
-			ctx,
-			http.StatusBadRequest,
-			custom_error.AmountNotInRange{
-				Minimum: MIN_TRANSFER_AMOUNT,
-				Maximum: MAX_TRANSFER_AMOUNT,
-			}.Error(),
-			nil,
-		)
-		return
-	}
-
 	user, ok := ctx.Get("user")
 	if !ok {
 		helper.WriteErrorResponse(
@@ -159,22 +185,33 @@ func (h *Handler) Transfer(ctx *gin.Context) {
 	}
 	tokenizedUser := user.(*entity.TokenizedUser)
 
-	if tokenizedUser.WalletNumber == input.To {
+	from, ok := h.ownedWalletNumber(ctx, tokenizedUser, input.From)
+	if !ok {
+		return
+	}
+
+	if from == input.To {
 		helper.WriteErrorResponse(
 			ctx,
 			http.StatusBadRequest,
-			custom_error.CannotTransferToOwnWallet{}.Error(),
+			custom_error.CannotTransferToSameWallet{}.Error(),
 			nil,
 		)
 		return
 	}
 
+	err = h.services.Pin.VerifyPin(tokenizedUser.ID, input.Pin)
+	if err != nil {
+		writePinErrorResponse(ctx, err)
+		return
+	}
+
 	transfer := &entity.Transaction{
 		Amount:      input.Amount,
 		Description: input.Description,
 		Type:        entity.Transfer,
 		Datetime:    time.Now(),
-		From:        tokenizedUser.WalletNumber,
+		From:        from,
 		To:          input.To,
 	}
 
@@ -202,6 +239,39 @@ func (h *Handler) Transfer(ctx *gin.Context) {
 		return
 	}
 
+	if _, ok := err.(*custom_error.CannotTransferToHouseWallet); ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+
+		return
+	}
+
+	if isWalletStatusError(err) {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusForbidden,
+			err.Error(),
+			nil,
+		)
+
+		return
+	}
+
+	if isTransactionLimitError(err) || isConversionError(err) {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+
+		return
+	}
+
 	if err != nil {
 		helper.WriteErrorResponse(
 			ctx,
@@ -212,7 +282,7 @@ func (h *Handler) Transfer(ctx *gin.Context) {
 		return
 	}
 
-	resFormatted := dto.FormatGetTransaction(res, tokenizedUser.WalletNumber)
+	resFormatted := dto.FormatGetTransaction(res, from)
 
 	helper.WriteSuccessResponse(
 		ctx,
@@ -222,45 +292,240 @@ func (h *Handler) Transfer(ctx *gin.Context) {
 	)
 }
 
-func (h *Handler) Topup(ctx *gin.Context) {
-	var input dto.TopupRequestBody
-	err := ctx.ShouldBindJSON(&input)
+// GetTransaction writes a transaction that one of the user's wallets sent
+// or received.
+func (h *Handler) GetTransaction(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		helper.WriteErrorResponse(
 			ctx,
 			http.StatusBadRequest,
-			custom_error.InvalidRequestBody{}.Error(),
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+	tokenizedUser := user.(*entity.TokenizedUser)
+
+	detail, err := h.services.Transaction.FindDetailByID(id, tokenizedUser.ID)
+
+	if _, ok := err.(*custom_error.NoDataFound); ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
 			nil,
 		)
 		return
 	}
 
-	if !helper.IsBetweenRange(
-		int(input.SourceID),
-		int(entity.BankTransfer),
-		int(entity.Cash),
-	) {
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatTransactionDetail(detail, tokenizedUser.ID),
+	)
+}
+
+// getTransactionsAfterCursor writes the page of the transaction history
+// that follows cursor, or the first page when cursor is empty. Cursor pages
+// are always ordered by datetime.
+func (h *Handler) getTransactionsAfterCursor(
+	ctx *gin.Context,
+	walletNumber int,
+	filter *entity.TransactionFilter,
+	cursor string,
+) {
+	sort, ok := transactionSort(ctx, []string{"datetime"})
+	if !ok {
+		return
+	}
+
+	sortMethod := "asc"
+	if sort[0].Descending {
+		sortMethod = "desc"
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	if err != nil || limit < 1 {
 		helper.WriteErrorResponse(
 			ctx,
 			http.StatusBadRequest,
-			custom_error.InvalidRequestBody{}.Error(),
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	pagination := &entity.CursorPagination{
+		Limit: limit,
+		Sort:  sortMethod,
+	}
+
+	if cursor != "" {
+		pagination.After, err = entity.DecodeTransactionCursor(cursor)
+		if err != nil {
+			helper.WriteErrorResponse(
+				ctx,
+				http.StatusBadRequest,
+				http.StatusText(http.StatusBadRequest),
+				nil,
+			)
+			return
+		}
+	}
+
+	transactions, pagination, err := h.services.Transaction.FindByWalletNumberAfterCursor(
+		walletNumber,
+		filter,
+		pagination,
+	)
+
+	if _, ok := err.(*custom_error.NoDataFound); ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
 			nil,
 		)
+
 		return
 	}
 
-	if !helper.IsBetweenRange(
-		input.Amount,
-		MIN_TOPUP_AMOUNT,
-		MAX_TOPUP_AMOUNT,
-	) {
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatGetTransactionsAfterCursorResponseBody(
+			transactions,
+			pagination,
+			walletNumber,
+		),
+	)
+}
+
+// transactionSort reads the sort of the transaction history, such as
+// "-amount,datetime", allowing only the fields in allowed. The older
+// sortBy with a sort of asc or desc still sorts by a single field.
+func transactionSort(ctx *gin.Context, allowed []string) (entity.Sort, bool) {
+	spec := ctx.DefaultQuery("sort", "desc")
+	if spec == "asc" || spec == "desc" {
+		direction := spec
+		spec = ctx.DefaultQuery("sortBy", "datetime")
+		if direction == "desc" {
+			spec = "-" + spec
+		}
+	}
+
+	sort, err := helper.ParseSort(spec, allowed)
+	if err != nil {
 		helper.WriteErrorResponse(
 			ctx,
 			http.StatusBadRequest,
-			custom_error.AmountNotInRange{
-				Minimum: MIN_TOPUP_AMOUNT,
-				Maximum: MAX_TOPUP_AMOUNT,
-			}.Error(),
+			err.Error(),
+			nil,
+		)
+		return nil, false
+	}
+
+	return sort, true
+}
+
+// transactionFilter reads the filters of the transaction history from the
+// query. It writes the error response and returns false when they are
+// invalid.
+func transactionFilter(ctx *gin.Context) (*entity.TransactionFilter, bool) {
+	var input dto.TransactionHistoryQuery
+	err := ctx.ShouldBindQuery(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return nil, false
+	}
+
+	filter := &entity.TransactionFilter{
+		Search:       input.Search,
+		Type:         input.Type,
+		Direction:    input.Direction,
+		MinAmount:    input.MinAmount,
+		MaxAmount:    input.MaxAmount,
+		Counterparty: input.Counterparty,
+	}
+
+	if input.From != "" {
+		from, _ := time.Parse(dto.TRANSACTION_HISTORY_DATE_LAYOUT, input.From)
+		filter.From = &from
+	}
+
+	// To includes the whole day.
+	if input.To != "" {
+		to, _ := time.Parse(dto.TRANSACTION_HISTORY_DATE_LAYOUT, input.To)
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	isDateRangeInvalid := filter.From != nil && filter.To != nil &&
+		!filter.From.Before(*filter.To)
+	isAmountRangeInvalid := filter.MinAmount != nil && filter.MaxAmount != nil &&
+		*filter.MinAmount > *filter.MaxAmount
+
+	if isDateRangeInvalid || isAmountRangeInvalid {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return nil, false
+	}
+
+	return filter, true
+}
+
+func (h *Handler) Topup(ctx *gin.Context) {
+	var input dto.TopupRequestBody
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
 			nil,
 		)
 		return
@@ -279,11 +544,7 @@ func (h *Handler) Topup(ctx *gin.Context) {
 	tokenizedUser := user.(*entity.TokenizedUser)
 
 	topup := &entity.Transaction{
-		Amount: input.Amount,
-		Description: fmt.Sprintf(
-			"Top Up from %s",
-			entity.SourceOfFundsID(input.SourceID).String(),
-		),
+		Amount:   input.Amount,
 		Type:     entity.TopUp,
 		Datetime: time.Now(),
 		SourceID: &input.SourceID,
@@ -291,24 +552,308 @@ func (h *Handler) Topup(ctx *gin.Context) {
 		To:       tokenizedUser.WalletNumber,
 	}
 
-	res, err := h.services.Transaction.CreateTopup(topup)
+	res, err := h.services.Topup.CreateTopup(topup)
 
 	if err != nil {
+		writeTopupErrorResponse(ctx, err)
+		return
+	}
+
+	resFormatted := dto.FormatGetTransaction(res, tokenizedUser.WalletNumber)
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		resFormatted,
+	)
+}
+
+func writeTopupErrorResponse(ctx *gin.Context, err error) {
+	switch err.(type) {
+	case *custom_error.SourceOfFundsUnavailable,
+		*custom_error.AmountNotInRange,
+		*custom_error.BalanceLimitExceeded:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.WalletCannotReceive:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusForbidden,
+			err.Error(),
+			nil,
+		)
+	default:
 		helper.WriteErrorResponse(
 			ctx,
 			http.StatusInternalServerError,
 			http.StatusText(http.StatusInternalServerError),
 			nil,
 		)
+	}
+}
+
+func (h *Handler) Refund(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
 		return
 	}
 
-	resFormatted := dto.FormatGetTransaction(res, tokenizedUser.WalletNumber)
+	var input dto.RefundRequestBody
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+	tokenizedUser := user.(*entity.TokenizedUser)
+
+	from, ok := h.ownedWalletNumber(ctx, tokenizedUser, input.From)
+	if !ok {
+		return
+	}
+
+	err = h.services.Pin.VerifyPin(tokenizedUser.ID, input.Pin)
+	if err != nil {
+		writePinErrorResponse(ctx, err)
+		return
+	}
+
+	description := input.Description
+	if description == "" {
+		description = fmt.Sprintf("Refund of transaction %d", id)
+	}
+
+	refund := &entity.Transaction{
+		Amount:      input.Amount,
+		Description: description,
+		Type:        entity.Refund,
+		Datetime:    time.Now(),
+		From:        from,
+		ReferenceID: &id,
+	}
+
+	res, err := h.services.Transaction.CreateRefund(refund)
+	if err != nil {
+		writeRefundErrorResponse(ctx, err)
+		return
+	}
 
 	helper.WriteSuccessResponse(
 		ctx,
 		http.StatusOK,
 		http.StatusText(http.StatusOK),
-		resFormatted,
+		dto.FormatGetTransaction(res, from),
+	)
+}
+
+// isTransactionLimitError reports whether err is a transaction limit of
+// the user's tier that the request broke.
+func isTransactionLimitError(err error) bool {
+	switch err.(type) {
+	case *custom_error.AmountNotInRange,
+		*custom_error.DailyLimitExceeded,
+		*custom_error.MonthlyLimitExceeded,
+		*custom_error.BalanceLimitExceeded,
+		*custom_error.DestinationBalanceLimitExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// isWalletStatusError reports whether the status of a wallet keeps it from
+// sending or receiving the money of the request.
+func isWalletStatusError(err error) bool {
+	switch err.(type) {
+	case *custom_error.WalletCannotSend,
+		*custom_error.WalletCannotReceive,
+		*custom_error.DestinationWalletCannotReceive,
+		*custom_error.WalletClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+// isConversionError reports whether the amount of a transfer could not be
+// converted to the currency of the receiving wallet.
+func isConversionError(err error) bool {
+	switch err.(type) {
+	case *custom_error.ExchangeRateUnavailable,
+		*custom_error.ConvertedAmountTooSmall:
+		return true
+	default:
+		return false
+	}
+}
+
+func writeRefundErrorResponse(ctx *gin.Context, err error) {
+	switch err.(type) {
+	case *custom_error.NoDataFound:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.TransactionNotRefundable,
+		*custom_error.RefundExceedsAmount,
+		*custom_error.InsufficientBalance,
+		*custom_error.ExchangeRateUnavailable,
+		*custom_error.ConvertedAmountTooSmall:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.WalletCannotSend,
+		*custom_error.DestinationWalletCannotReceive:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusForbidden,
+			err.Error(),
+			nil,
+		)
+	default:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+	}
+}
+
+func (h *Handler) Withdraw(ctx *gin.Context) {
+	var input dto.WithdrawRequestBody
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+	tokenizedUser := user.(*entity.TokenizedUser)
+
+	err = h.services.Pin.VerifyPin(tokenizedUser.ID, input.Pin)
+	if err != nil {
+		writePinErrorResponse(ctx, err)
+		return
+	}
+
+	withdrawal := &entity.Transaction{
+		Amount:        input.Amount,
+		Description:   fmt.Sprintf("Withdrawal to bank account %d", input.BankAccountID),
+		Type:          entity.Withdrawal,
+		Datetime:      time.Now(),
+		From:          tokenizedUser.WalletNumber,
+		BankAccountID: &input.BankAccountID,
+	}
+
+	res, err := h.services.Withdrawal.CreateWithdrawal(
+		tokenizedUser.ID,
+		withdrawal,
+	)
+
+	if _, ok := err.(*custom_error.NoDataFound); ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+
+		return
+	}
+
+	if _, ok := err.(*custom_error.InsufficientBalance); ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+
+		return
+	}
+
+	if isWalletStatusError(err) {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusForbidden,
+			err.Error(),
+			nil,
+		)
+
+		return
+	}
+
+	if isTransactionLimitError(err) {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+
+		return
+	}
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatGetTransaction(res, tokenizedUser.WalletNumber),
 	)
 }
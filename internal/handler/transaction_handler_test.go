@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"assignment-golang-backend/internal/custom_error"
 	"assignment-golang-backend/internal/dto"
@@ -35,21 +36,21 @@ func TestHandler_Topup(t *testing.T) {
 
 	tests := []struct {
 		name                   string
-		transactionService     *mocks.ITransactionService
+		topupService           *mocks.ITopupService
 		body                   io.Reader
 		mockUserFromMiddleware bool
-		mock                   func(*mocks.ITransactionService)
+		mock                   func(*mocks.ITopupService)
 		want                   helper.JsonResponse
 	}{
 
 		{
-			name:               "Error | Invalid Request Body",
-			transactionService: mocks.NewITransactionService(t),
+			name:         "Error | Invalid Request Body",
+			topupService: mocks.NewITopupService(t),
 			body: MakeRequestBody(dto.TopupRequestBody{
 				Amount: 100000,
 			}),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITopupService) {
 			},
 			want: helper.JsonResponse{
 				Code:    http.StatusBadRequest,
@@ -58,49 +59,56 @@ func TestHandler_Topup(t *testing.T) {
 			},
 		},
 		{
-			name:               "Error | Invalid Source ID Fund",
-			transactionService: mocks.NewITransactionService(t),
+			name:         "Error | Source of funds unavailable",
+			topupService: mocks.NewITopupService(t),
 			body: MakeRequestBody(dto.TopupRequestBody{
-				Amount:   10,
+				Amount:   100000,
 				SourceID: 4,
 			}),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITopupService) {
+				ts.On("CreateTopup", mock.Anything).
+					Return(nil, &custom_error.SourceOfFundsUnavailable{})
 			},
 			want: helper.JsonResponse{
 				Code:    http.StatusBadRequest,
-				Message: custom_error.InvalidRequestBody{}.Error(),
+				Message: custom_error.SourceOfFundsUnavailable{}.Error(),
 				Data:    nil,
 			},
 		},
 		{
-			name:               "Error | Amount not between Min and Max amount of topup",
-			transactionService: mocks.NewITransactionService(t),
+			name:         "Error | Amount not between Min and Max amount of the source of funds",
+			topupService: mocks.NewITopupService(t),
 			body: MakeRequestBody(dto.TopupRequestBody{
-				Amount:   MAX_TOPUP_AMOUNT + 1,
+				Amount:   10,
 				SourceID: 1,
 			}),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITopupService) {
+				ts.On("CreateTopup", mock.Anything).
+					Return(nil, &custom_error.AmountNotInRange{
+						Minimum: 50000,
+						Maximum: 10000000,
+					})
 			},
 			want: helper.JsonResponse{
 				Code: http.StatusBadRequest,
 				Message: custom_error.AmountNotInRange{
-					Minimum: MIN_TOPUP_AMOUNT,
-					Maximum: MAX_TOPUP_AMOUNT,
+					Minimum: 50000,
+					Maximum: 10000000,
 				}.Error(),
 				Data: nil,
 			},
 		},
 		{
-			name:               "Error | Failed to get user key from middleware",
-			transactionService: mocks.NewITransactionService(t),
+			name:         "Error | Failed to get user key from middleware",
+			topupService: mocks.NewITopupService(t),
 			body: MakeRequestBody(dto.TopupRequestBody{
-				Amount:   MAX_TOPUP_AMOUNT - 1,
+				Amount:   100000,
 				SourceID: 1,
 			}),
 			mockUserFromMiddleware: false,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITopupService) {
 			},
 			want: helper.JsonResponse{
 				Code:    http.StatusInternalServerError,
@@ -109,17 +117,17 @@ func TestHandler_Topup(t *testing.T) {
 			},
 		},
 		{
-			name:               "Error | Error from services",
-			transactionService: mocks.NewITransactionService(t),
+			name:         "Error | Error from services",
+			topupService: mocks.NewITopupService(t),
 			body: MakeRequestBody(dto.TopupRequestBody{
-				Amount:   MAX_TOPUP_AMOUNT - 1,
+				Amount:   100000,
 				SourceID: 1,
 			}),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITopupService) {
 				ts.On("CreateTopup", mock.MatchedBy(func(i interface{}) bool {
 					topup := i.(*entity.Transaction)
-					return topup.Amount == MAX_TOPUP_AMOUNT-1
+					return topup.Amount == 100000
 				})).Return(nil, fmt.Errorf("error"))
 			},
 			want: helper.JsonResponse{
@@ -129,17 +137,17 @@ func TestHandler_Topup(t *testing.T) {
 			},
 		},
 		{
-			name:               "Success",
-			transactionService: mocks.NewITransactionService(t),
+			name:         "Success",
+			topupService: mocks.NewITopupService(t),
 			body: MakeRequestBody(dto.TopupRequestBody{
-				Amount:   MAX_TOPUP_AMOUNT - 1,
+				Amount:   100000,
 				SourceID: 1,
 			}),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITopupService) {
 				ts.On("CreateTopup", mock.MatchedBy(func(i interface{}) bool {
 					topup := i.(*entity.Transaction)
-					return topup.Amount == MAX_TOPUP_AMOUNT-1
+					return topup.Amount == 100000
 				})).Return(&entity.Transaction{}, nil)
 			},
 			want: helper.JsonResponse{
@@ -153,11 +161,11 @@ func TestHandler_Topup(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			h := &Handler{
 				services: &usecase.Services{
-					Transaction: tt.transactionService,
+					Topup: tt.topupService,
 				},
 			}
 
-			tt.mock(tt.transactionService)
+			tt.mock(tt.topupService)
 
 			r := SetUpRouter()
 
@@ -188,9 +196,10 @@ func TestHandler_Topup(t *testing.T) {
 
 func TestHandler_Transfer(t *testing.T) {
 	validBody := dto.TransferRequestBody{
-		Amount:      MIN_TRANSFER_AMOUNT,
+		Amount:      1000,
 		To:          2,
 		Description: "description",
+		Pin:         "123456",
 	}
 	mockTransfer := &entity.Transaction{
 		Amount:      validBody.Amount,
@@ -210,7 +219,7 @@ func TestHandler_Transfer(t *testing.T) {
 		transactionService     *mocks.ITransactionService
 		body                   io.Reader
 		mockUserFromMiddleware bool
-		mock                   func(*mocks.ITransactionService)
+		mock                   func(*mocks.ITransactionService, *mocks.IPinService)
 		want                   helper.JsonResponse
 	}{
 
@@ -219,7 +228,7 @@ func TestHandler_Transfer(t *testing.T) {
 			transactionService:     mocks.NewITransactionService(t),
 			body:                   MakeRequestBody(dto.TransferRequestBody{}),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
 			},
 			want: helper.JsonResponse{
 				Code:    http.StatusBadRequest,
@@ -228,23 +237,21 @@ func TestHandler_Transfer(t *testing.T) {
 			},
 		},
 		{
-			name:               "Error | Amount not between Min and Max amount of topup",
+			name:               "Error | Negative amount to an own wallet",
 			transactionService: mocks.NewITransactionService(t),
 			body: MakeRequestBody(dto.TransferRequestBody{
-				Amount:      MIN_TRANSFER_AMOUNT - 1,
-				To:          2,
-				Description: "description",
+				From:   MockTokenizedUser.WalletNumber,
+				To:     MockTokenizedUser.WalletNumber + 1,
+				Amount: -1000,
+				Pin:    validBody.Pin,
 			}),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
 			},
 			want: helper.JsonResponse{
-				Code: http.StatusBadRequest,
-				Message: custom_error.AmountNotInRange{
-					Minimum: MIN_TRANSFER_AMOUNT,
-					Maximum: MAX_TRANSFER_AMOUNT,
-				}.Error(),
-				Data: nil,
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidRequestBody{}.Error(),
+				Data:    nil,
 			},
 		},
 		{
@@ -252,7 +259,7 @@ func TestHandler_Transfer(t *testing.T) {
 			transactionService:     mocks.NewITransactionService(t),
 			body:                   MakeRequestBody(validBody),
 			mockUserFromMiddleware: false,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
 			},
 			want: helper.JsonResponse{
 				Code:    http.StatusInternalServerError,
@@ -264,16 +271,47 @@ func TestHandler_Transfer(t *testing.T) {
 			name:               "Error | Destination wallet is same as user's wallet",
 			transactionService: mocks.NewITransactionService(t),
 			body: MakeRequestBody(dto.TransferRequestBody{
-				Amount:      MIN_TRANSFER_AMOUNT,
+				Amount:      1000,
 				To:          MockTokenizedUser.WalletNumber,
 				Description: "description",
+				Pin:         validBody.Pin,
 			}),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
 			},
 			want: helper.JsonResponse{
 				Code:    http.StatusBadRequest,
-				Message: custom_error.CannotTransferToOwnWallet{}.Error(),
+				Message: custom_error.CannotTransferToSameWallet{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Wrong PIN",
+			transactionService:     mocks.NewITransactionService(t),
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).
+					Return(&custom_error.WrongPin{RemainingAttempts: 2})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusForbidden,
+				Message: custom_error.WrongPin{RemainingAttempts: 2}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | PIN not set",
+			transactionService:     mocks.NewITransactionService(t),
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).
+					Return(&custom_error.PinNotSet{})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusForbidden,
+				Message: custom_error.PinNotSet{}.Error(),
 				Data:    nil,
 			},
 		},
@@ -282,7 +320,8 @@ func TestHandler_Transfer(t *testing.T) {
 			transactionService:     mocks.NewITransactionService(t),
 			body:                   MakeRequestBody(validBody),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
 				ts.On("CreateTransaction", mock.MatchedBy(func(i interface{}) bool {
 					transfer := i.(*entity.Transaction)
 					return transfer.To == validBody.To &&
@@ -297,12 +336,29 @@ func TestHandler_Transfer(t *testing.T) {
 				Data:    nil,
 			},
 		},
+		{
+			name:                   "Error | Wallet is frozen",
+			transactionService:     mocks.NewITransactionService(t),
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ts.On("CreateTransaction", mock.Anything).
+					Return(nil, &custom_error.WalletCannotSend{Status: "FROZEN"})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusForbidden,
+				Message: custom_error.WalletCannotSend{Status: "FROZEN"}.Error(),
+				Data:    nil,
+			},
+		},
 		{
 			name:                   "Error | Insufficient balance to transfer from services",
 			transactionService:     mocks.NewITransactionService(t),
 			body:                   MakeRequestBody(validBody),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
 				ts.On("CreateTransaction", mock.MatchedBy(func(i interface{}) bool {
 					transfer := i.(*entity.Transaction)
 					return transfer.To == validBody.To &&
@@ -317,12 +373,50 @@ func TestHandler_Transfer(t *testing.T) {
 				Data:    nil,
 			},
 		},
+		{
+			name:                   "Error | Transfer to a house wallet from services",
+			transactionService:     mocks.NewITransactionService(t),
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ts.On("CreateTransaction", mock.Anything).
+					Return(nil, &custom_error.CannotTransferToHouseWallet{})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.CannotTransferToHouseWallet{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Daily limit exceeded from services",
+			transactionService:     mocks.NewITransactionService(t),
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ts.On("CreateTransaction", mock.MatchedBy(func(i interface{}) bool {
+					transfer := i.(*entity.Transaction)
+					return transfer.To == validBody.To &&
+						transfer.Amount == validBody.Amount &&
+						transfer.Description == validBody.Description
+				})).
+					Return(nil, &custom_error.DailyLimitExceeded{Remaining: 500})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.DailyLimitExceeded{Remaining: 500}.Error(),
+				Data:    nil,
+			},
+		},
 		{
 			name:                   "Error | Other error from transfer",
 			transactionService:     mocks.NewITransactionService(t),
 			body:                   MakeRequestBody(validBody),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
 				ts.On("CreateTransaction", mock.MatchedBy(func(i interface{}) bool {
 					transfer := i.(*entity.Transaction)
 					return transfer.To == validBody.To &&
@@ -342,7 +436,8 @@ func TestHandler_Transfer(t *testing.T) {
 			transactionService:     mocks.NewITransactionService(t),
 			body:                   MakeRequestBody(validBody),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
 				ts.On("CreateTransaction", mock.MatchedBy(func(i interface{}) bool {
 					transfer := i.(*entity.Transaction)
 					return transfer.To == validBody.To &&
@@ -360,13 +455,15 @@ func TestHandler_Transfer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			pinService := mocks.NewIPinService(t)
 			h := &Handler{
 				services: &usecase.Services{
 					Transaction: tt.transactionService,
+					Pin:         pinService,
 				},
 			}
 
-			tt.mock(tt.transactionService)
+			tt.mock(tt.transactionService, pinService)
 
 			r := SetUpRouter()
 
@@ -395,69 +492,121 @@ func TestHandler_Transfer(t *testing.T) {
 	}
 }
 
-func TestHandler_GetTransactionsByWalletNumber(t *testing.T) {
-	mockDefaultPagination := &entity.Pagination{
-		Limit:      10,
-		Page:       1,
-		Search:     "",
-		Sort:       "desc",
-		SortBy:     "datetime",
-		TotalRows:  0,
-		TotalPages: 0,
+func TestHandler_Refund(t *testing.T) {
+	referenceID := 1
+	validBody := dto.RefundRequestBody{
+		Amount: 500,
+		Pin:    "123456",
+	}
+	mockRefund := &entity.Transaction{
+		Amount:      validBody.Amount,
+		Type:        entity.Refund,
+		From:        MockTokenizedUser.WalletNumber,
+		To:          2,
+		ReferenceID: &referenceID,
 	}
 
 	mockDataInInterface, err := StructToMap(
-		&dto.GetTransactionsByWalletNumberResponseBody{
-			Pagination: *mockDefaultPagination,
-			Rows:       []*dto.FormattedTransaction{},
-		},
+		dto.FormatGetTransaction(mockRefund, MockTokenizedUser.WalletNumber),
 	)
 	require.NoError(t, err)
+	isRefund := mock.MatchedBy(func(refund *entity.Transaction) bool {
+		return *refund.ReferenceID == referenceID &&
+			refund.From == MockTokenizedUser.WalletNumber &&
+			refund.Amount == validBody.Amount &&
+			refund.Description == "Refund of transaction 1"
+	})
 
 	tests := []struct {
 		name                   string
-		transactionService     *mocks.ITransactionService
+		path                   string
+		body                   io.Reader
 		mockUserFromMiddleware bool
-		endpoint               string
-		mock                   func(*mocks.ITransactionService)
+		mock                   func(*mocks.ITransactionService, *mocks.IPinService)
 		want                   helper.JsonResponse
 	}{
-
 		{
-			name:                   "Error | Failed to get user key from middleware",
-			transactionService:     mocks.NewITransactionService(t),
-			mockUserFromMiddleware: false,
-			mock: func(ts *mocks.ITransactionService) {
+			name:                   "Error | Invalid ID",
+			path:                   "/api/transactions/abc/refund",
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock:                   func(ts *mocks.ITransactionService, ps *mocks.IPinService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Data:    nil,
 			},
+		},
+		{
+			name: "Error | Negative amount",
+			path: "/api/transactions/1/refund",
+			body: MakeRequestBody(dto.RefundRequestBody{
+				Amount: -1,
+				Pin:    validBody.Pin,
+			}),
+			mockUserFromMiddleware: true,
+			mock:                   func(ts *mocks.ITransactionService, ps *mocks.IPinService) {},
 			want: helper.JsonResponse{
-				Code:    http.StatusInternalServerError,
-				Message: custom_error.FailedToGetInfoFromToken{}.Error(),
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidRequestBody{}.Error(),
 				Data:    nil,
 			},
 		},
 		{
-			name:                   "Error | No Data Found from service",
-			transactionService:     mocks.NewITransactionService(t),
+			name:                   "Error | Wrong PIN",
+			path:                   "/api/transactions/1/refund",
+			body:                   MakeRequestBody(validBody),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
-				ts.On("FindByWalletNumber", MockTokenizedUser.WalletNumber, mockDefaultPagination).
-					Return(nil, nil, &custom_error.NoDataFound{DataType: "transaction"})
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).
+					Return(&custom_error.WrongPin{RemainingAttempts: 2})
 			},
 			want: helper.JsonResponse{
-				Code: http.StatusNotFound,
-				Message: custom_error.NoDataFound{
-					DataType: "transaction",
-				}.Error(),
-				Data: nil,
+				Code:    http.StatusForbidden,
+				Message: custom_error.WrongPin{RemainingAttempts: 2}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Transaction not found",
+			path:                   "/api/transactions/1/refund",
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ts.On("CreateRefund", isRefund).
+					Return(nil, &custom_error.NoDataFound{DataType: "transaction"})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusNotFound,
+				Message: custom_error.NoDataFound{DataType: "transaction"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Refund exceeds what is left of the transfer",
+			path:                   "/api/transactions/1/refund",
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ts.On("CreateRefund", isRefund).
+					Return(nil, &custom_error.RefundExceedsAmount{Refundable: 0})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.RefundExceedsAmount{Refundable: 0}.Error(),
+				Data:    nil,
 			},
 		},
 		{
 			name:                   "Error | Other error from service",
-			transactionService:     mocks.NewITransactionService(t),
+			path:                   "/api/transactions/1/refund",
+			body:                   MakeRequestBody(validBody),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
-				ts.On("FindByWalletNumber", MockTokenizedUser.WalletNumber, mockDefaultPagination).
-					Return(nil, nil, fmt.Errorf("error"))
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ts.On("CreateRefund", isRefund).Return(nil, fmt.Errorf("error"))
 			},
 			want: helper.JsonResponse{
 				Code:    http.StatusInternalServerError,
@@ -467,11 +616,12 @@ func TestHandler_GetTransactionsByWalletNumber(t *testing.T) {
 		},
 		{
 			name:                   "Success",
-			transactionService:     mocks.NewITransactionService(t),
+			path:                   "/api/transactions/1/refund",
+			body:                   MakeRequestBody(validBody),
 			mockUserFromMiddleware: true,
-			mock: func(ts *mocks.ITransactionService) {
-				ts.On("FindByWalletNumber", MockTokenizedUser.WalletNumber, mockDefaultPagination).
-					Return([]*entity.Transaction{}, mockDefaultPagination, nil)
+			mock: func(ts *mocks.ITransactionService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ts.On("CreateRefund", isRefund).Return(mockRefund, nil)
 			},
 			want: helper.JsonResponse{
 				Code:    http.StatusOK,
@@ -482,32 +632,27 @@ func TestHandler_GetTransactionsByWalletNumber(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			transactionService := mocks.NewITransactionService(t)
+			pinService := mocks.NewIPinService(t)
 			h := &Handler{
 				services: &usecase.Services{
-					Transaction: tt.transactionService,
+					Transaction: transactionService,
+					Pin:         pinService,
 				},
 			}
 
-			tt.mock(tt.transactionService)
+			tt.mock(transactionService, pinService)
 
 			r := SetUpRouter()
 
-			endpoint := "/api/transaction"
+			endpoint := "/api/transactions/:id/refund"
 			if tt.mockUserFromMiddleware {
-				r.GET(
-					endpoint,
-					MiddlewareMockUser,
-					h.GetTransactionsByWalletNumber,
-				)
+				r.POST(endpoint, MiddlewareMockUser, h.Refund)
 			} else {
-				r.GET(endpoint, h.GetTransactionsByWalletNumber)
+				r.POST(endpoint, h.Refund)
 			}
 
-			req, _ := http.NewRequest(
-				http.MethodGet,
-				endpoint,
-				nil,
-			)
+			req, _ := http.NewRequest(http.MethodPost, tt.path, tt.body)
 			w := httptest.NewRecorder()
 			r.ServeHTTP(w, req)
 
@@ -520,3 +665,705 @@ func TestHandler_GetTransactionsByWalletNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_Withdraw(t *testing.T) {
+	validBody := dto.WithdrawRequestBody{
+		BankAccountID: 1,
+		Amount:        10000,
+		Pin:           "123456",
+	}
+	mockWithdrawal := &entity.Transaction{
+		Amount:        validBody.Amount,
+		Type:          entity.Withdrawal,
+		Status:        entity.TransactionCompleted,
+		From:          MockTokenizedUser.WalletNumber,
+		To:            MockTokenizedUser.WalletNumber,
+		BankAccountID: &validBody.BankAccountID,
+	}
+
+	mockDataInInterface, err := StructToMap(
+		dto.FormatGetTransaction(mockWithdrawal, MockTokenizedUser.WalletNumber),
+	)
+	require.NoError(t, err)
+	isWithdrawal := mock.MatchedBy(func(withdrawal *entity.Transaction) bool {
+		return *withdrawal.BankAccountID == validBody.BankAccountID &&
+			withdrawal.From == MockTokenizedUser.WalletNumber &&
+			withdrawal.Amount == validBody.Amount
+	})
+
+	tests := []struct {
+		name                   string
+		body                   io.Reader
+		mockUserFromMiddleware bool
+		mock                   func(*mocks.IWithdrawalService, *mocks.IPinService)
+		want                   helper.JsonResponse
+	}{
+		{
+			name:                   "Error | Wrong PIN",
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ws *mocks.IWithdrawalService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).
+					Return(&custom_error.PinLocked{})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusForbidden,
+				Message: custom_error.PinLocked{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Bank account not found",
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ws *mocks.IWithdrawalService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ws.On("CreateWithdrawal", MockTokenizedUser.ID, isWithdrawal).
+					Return(nil, &custom_error.NoDataFound{DataType: "bank account"})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusNotFound,
+				Message: custom_error.NoDataFound{DataType: "bank account"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Wallet closed while the payout failed",
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ws *mocks.IWithdrawalService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ws.On("CreateWithdrawal", MockTokenizedUser.ID, isWithdrawal).
+					Return(nil, &custom_error.WalletClosed{})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusForbidden,
+				Message: custom_error.WalletClosed{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Insufficient balance",
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ws *mocks.IWithdrawalService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ws.On("CreateWithdrawal", MockTokenizedUser.ID, isWithdrawal).
+					Return(nil, &custom_error.InsufficientBalance{})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InsufficientBalance{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Amount not between Min and Max amount of withdrawal",
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ws *mocks.IWithdrawalService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ws.On("CreateWithdrawal", MockTokenizedUser.ID, isWithdrawal).
+					Return(nil, &custom_error.AmountNotInRange{Minimum: 20000, Maximum: 50000})
+			},
+			want: helper.JsonResponse{
+				Code: http.StatusBadRequest,
+				Message: custom_error.AmountNotInRange{
+					Minimum: 20000,
+					Maximum: 50000,
+				}.Error(),
+				Data: nil,
+			},
+		},
+		{
+			name:                   "Success",
+			body:                   MakeRequestBody(validBody),
+			mockUserFromMiddleware: true,
+			mock: func(ws *mocks.IWithdrawalService, ps *mocks.IPinService) {
+				ps.On("VerifyPin", MockTokenizedUser.ID, validBody.Pin).Return(nil)
+				ws.On("CreateWithdrawal", MockTokenizedUser.ID, isWithdrawal).
+					Return(mockWithdrawal, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockDataInInterface,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withdrawalService := mocks.NewIWithdrawalService(t)
+			pinService := mocks.NewIPinService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					Withdrawal: withdrawalService,
+					Pin:        pinService,
+				},
+			}
+
+			tt.mock(withdrawalService, pinService)
+
+			r := SetUpRouter()
+
+			endpoint := "/api/transactions/withdraw"
+			if tt.mockUserFromMiddleware {
+				r.POST(endpoint, MiddlewareMockUser, h.Withdraw)
+			} else {
+				r.POST(endpoint, h.Withdraw)
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, endpoint, tt.body)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_GetTransactionsByWalletNumber(t *testing.T) {
+	mockDefaultPagination := &entity.Pagination{
+		Limit:      10,
+		Page:       1,
+		Sort:       entity.Sort{{Field: "datetime", Descending: true}},
+		TotalRows:  0,
+		TotalPages: 0,
+	}
+
+	mockDataInInterface, err := StructToMap(
+		&dto.GetTransactionsByWalletNumberResponseBody{
+			Pagination: *mockDefaultPagination,
+			Rows:       []*dto.FormattedTransaction{},
+		},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                   string
+		transactionService     *mocks.ITransactionService
+		mockUserFromMiddleware bool
+		query                  string
+		mock                   func(*mocks.ITransactionService)
+		want                   helper.JsonResponse
+	}{
+
+		{
+			name:                   "Error | Failed to get user key from middleware",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: false,
+			mock: func(ts *mocks.ITransactionService) {
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: custom_error.FailedToGetInfoFromToken{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Unknown type",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			query:                  "?type=PAYMENT",
+			mock:                   func(ts *mocks.ITransactionService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Date range ends before it starts",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			query:                  "?from=2030-02-01&to=2030-01-01",
+			mock:                   func(ts *mocks.ITransactionService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Minimum amount above the maximum",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			query:                  "?min_amount=5000&max_amount=1000",
+			mock:                   func(ts *mocks.ITransactionService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Sort by a field that is not allowed",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			query:                  "?sort=-amount,description",
+			mock:                   func(ts *mocks.ITransactionService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidSortField{Field: "description"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Sort by a field twice",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			query:                  "?sort=amount,-amount",
+			mock:                   func(ts *mocks.ITransactionService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidSortField{Field: "-amount"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Sort by a field that is not allowed with sortBy",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			query:                  "?sortBy=amount%3BDROP%20TABLE%20users&sort=asc",
+			mock:                   func(ts *mocks.ITransactionService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidSortField{Field: "amount;DROP TABLE users"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Success | Sorted by several fields",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			query:                  "?sort=-amount,datetime",
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On(
+					"FindByWalletNumber",
+					MockTokenizedUser.WalletNumber,
+					&entity.TransactionFilter{},
+					mock.MatchedBy(func(pagination *entity.Pagination) bool {
+						return pagination.Sort.String() == "-amount,datetime"
+					}),
+				).Return([]*entity.Transaction{}, mockDefaultPagination, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockDataInInterface,
+			},
+		},
+		{
+			name:                   "Success | Sorted with sortBy",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			query:                  "?sortBy=amount&sort=asc",
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On(
+					"FindByWalletNumber",
+					MockTokenizedUser.WalletNumber,
+					&entity.TransactionFilter{},
+					mock.MatchedBy(func(pagination *entity.Pagination) bool {
+						return pagination.Sort.String() == "amount"
+					}),
+				).Return([]*entity.Transaction{}, mockDefaultPagination, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockDataInInterface,
+			},
+		},
+		{
+			name:                   "Success | Filtered",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			query: "?from=2030-01-01&to=2030-01-31&type=TRANSFER" +
+				"&direction=outgoing&min_amount=1000&max_amount=5000&counterparty=2",
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On(
+					"FindByWalletNumber",
+					MockTokenizedUser.WalletNumber,
+					mock.MatchedBy(func(filter *entity.TransactionFilter) bool {
+						return filter.From.Equal(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)) &&
+							filter.To.Equal(time.Date(2030, time.February, 1, 0, 0, 0, 0, time.UTC)) &&
+							filter.Type == entity.Transfer &&
+							filter.Direction == entity.Outgoing &&
+							*filter.MinAmount == 1000 &&
+							*filter.MaxAmount == 5000 &&
+							filter.Counterparty == 2
+					}),
+					mockDefaultPagination,
+				).Return([]*entity.Transaction{}, mockDefaultPagination, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockDataInInterface,
+			},
+		},
+		{
+			name:                   "Error | No Data Found from service",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On("FindByWalletNumber", MockTokenizedUser.WalletNumber, &entity.TransactionFilter{}, mockDefaultPagination).
+					Return(nil, nil, &custom_error.NoDataFound{DataType: "transaction"})
+			},
+			want: helper.JsonResponse{
+				Code: http.StatusNotFound,
+				Message: custom_error.NoDataFound{
+					DataType: "transaction",
+				}.Error(),
+				Data: nil,
+			},
+		},
+		{
+			name:                   "Error | Other error from service",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On("FindByWalletNumber", MockTokenizedUser.WalletNumber, &entity.TransactionFilter{}, mockDefaultPagination).
+					Return(nil, nil, fmt.Errorf("error"))
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Success",
+			transactionService:     mocks.NewITransactionService(t),
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On("FindByWalletNumber", MockTokenizedUser.WalletNumber, &entity.TransactionFilter{}, mockDefaultPagination).
+					Return([]*entity.Transaction{}, mockDefaultPagination, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockDataInInterface,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				services: &usecase.Services{
+					Transaction: tt.transactionService,
+				},
+			}
+
+			tt.mock(tt.transactionService)
+
+			r := SetUpRouter()
+
+			endpoint := "/api/transaction"
+			if tt.mockUserFromMiddleware {
+				r.GET(
+					endpoint,
+					MiddlewareMockUser,
+					h.GetTransactionsByWalletNumber,
+				)
+			} else {
+				r.GET(endpoint, h.GetTransactionsByWalletNumber)
+			}
+
+			req, _ := http.NewRequest(
+				http.MethodGet,
+				endpoint+tt.query,
+				nil,
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_GetTransactionsByWalletNumber_cursor(t *testing.T) {
+	after := &entity.TransactionCursor{
+		Datetime: time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC),
+		ID:       5,
+	}
+	mockPagination := &entity.CursorPagination{
+		Limit:      10,
+		Sort:       "desc",
+		NextCursor: "next",
+	}
+	mockDataInInterface, err := StructToMap(
+		&dto.GetTransactionsAfterCursorResponseBody{
+			Pagination: *mockPagination,
+			Rows:       []*dto.FormattedTransaction{},
+		},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		query string
+		mock  func(*mocks.ITransactionService)
+		want  helper.JsonResponse
+	}{
+		{
+			name:  "Error | Invalid cursor",
+			query: "?cursor=not-a-cursor",
+			mock:  func(ts *mocks.ITransactionService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Data:    nil,
+			},
+		},
+		{
+			name:  "Error | Sorted by something else than the datetime",
+			query: "?cursor=&sortBy=amount",
+			mock:  func(ts *mocks.ITransactionService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidSortField{Field: "-amount"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:  "Error | Sorted by more than the datetime",
+			query: "?cursor=&sort=-datetime,amount",
+			mock:  func(ts *mocks.ITransactionService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.InvalidSortField{Field: "amount"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:  "Success | Oldest first",
+			query: "?cursor=&sort=datetime",
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On(
+					"FindByWalletNumberAfterCursor",
+					MockTokenizedUser.WalletNumber,
+					&entity.TransactionFilter{},
+					&entity.CursorPagination{Limit: 10, Sort: "asc"},
+				).Return([]*entity.Transaction{}, mockPagination, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockDataInInterface,
+			},
+		},
+		{
+			name:  "Error | No data found from service",
+			query: "?cursor=",
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On(
+					"FindByWalletNumberAfterCursor",
+					MockTokenizedUser.WalletNumber,
+					&entity.TransactionFilter{},
+					&entity.CursorPagination{Limit: 10, Sort: "desc"},
+				).Return(nil, nil, &custom_error.NoDataFound{DataType: "transaction"})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusNotFound,
+				Message: custom_error.NoDataFound{DataType: "transaction"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:  "Success | Page after the cursor",
+			query: "?cursor=" + after.Encode(),
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On(
+					"FindByWalletNumberAfterCursor",
+					MockTokenizedUser.WalletNumber,
+					&entity.TransactionFilter{},
+					mock.MatchedBy(func(pagination *entity.CursorPagination) bool {
+						return pagination.Limit == 10 &&
+							pagination.Sort == "desc" &&
+							pagination.After.Datetime.Equal(after.Datetime) &&
+							pagination.After.ID == after.ID
+					}),
+				).Return([]*entity.Transaction{}, mockPagination, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockDataInInterface,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := mocks.NewITransactionService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					Transaction: ts,
+				},
+			}
+
+			tt.mock(ts)
+
+			r := SetUpRouter()
+			endpoint := "/api/transaction"
+			r.GET(endpoint, MiddlewareMockUser, h.GetTransactionsByWalletNumber)
+
+			req, _ := http.NewRequest(http.MethodGet, endpoint+tt.query, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_GetTransaction(t *testing.T) {
+	senderID := 2
+	senderBalance, recipientBalance := 40000, 60000
+	mockDetail := &entity.TransactionDetail{
+		Transaction: &entity.Transaction{
+			Base:             entity.Base{ID: 7},
+			Amount:           10000,
+			Type:             entity.Transfer,
+			Status:           entity.TransactionCompleted,
+			From:             100002,
+			FromWallet:       entity.Wallet{Number: 100002, UserID: &senderID},
+			To:               MockTokenizedUser.WalletNumber,
+			ToWallet:         entity.Wallet{Number: MockTokenizedUser.WalletNumber, UserID: &MockTokenizedUser.ID},
+			FromBalanceAfter: &senderBalance,
+			ToBalanceAfter:   &recipientBalance,
+		},
+		SenderName:    "Sender",
+		RecipientName: "Recipient",
+	}
+	mockDataInInterface, err := StructToMap(
+		dto.FormatTransactionDetail(mockDetail, MockTokenizedUser.ID),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                   string
+		path                   string
+		mockUserFromMiddleware bool
+		mock                   func(*mocks.ITransactionService)
+		want                   helper.JsonResponse
+	}{
+		{
+			name:                   "Error | Invalid ID",
+			path:                   "/api/transactions/abc",
+			mockUserFromMiddleware: true,
+			mock:                   func(ts *mocks.ITransactionService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Failed to get user key from middleware",
+			path:                   "/api/transactions/7",
+			mockUserFromMiddleware: false,
+			mock:                   func(ts *mocks.ITransactionService) {},
+			want: helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: custom_error.FailedToGetInfoFromToken{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Not found or not the user's",
+			path:                   "/api/transactions/7",
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On("FindDetailByID", 7, MockTokenizedUser.ID).
+					Return(nil, &custom_error.NoDataFound{DataType: "transaction"})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusNotFound,
+				Message: custom_error.NoDataFound{DataType: "transaction"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Error | Other error from service",
+			path:                   "/api/transactions/7",
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On("FindDetailByID", 7, MockTokenizedUser.ID).
+					Return(nil, fmt.Errorf("error"))
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+				Data:    nil,
+			},
+		},
+		{
+			name:                   "Success",
+			path:                   "/api/transactions/7",
+			mockUserFromMiddleware: true,
+			mock: func(ts *mocks.ITransactionService) {
+				ts.On("FindDetailByID", 7, MockTokenizedUser.ID).
+					Return(mockDetail, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockDataInInterface,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := mocks.NewITransactionService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					Transaction: ts,
+				},
+			}
+
+			tt.mock(ts)
+
+			r := SetUpRouter()
+
+			endpoint := "/api/transactions/:id"
+			if tt.mockUserFromMiddleware {
+				r.GET(endpoint, MiddlewareMockUser, h.GetTransaction)
+			} else {
+				r.GET(endpoint, h.GetTransaction)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+
+	t.Run("Success | Shown from the receiving wallet", func(t *testing.T) {
+		assert.Equal(t, float64(recipientBalance), mockDataInInterface["balance_after"])
+		assert.Equal(t, float64(mockDetail.Amount), mockDataInInterface["amount"])
+	})
+}
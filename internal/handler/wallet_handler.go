@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) initWalletRoutes(api *gin.RouterGroup) {
+	wallet := api.Group("/wallets")
+	{
+		wallet.GET("", h.GetWallets)
+		wallet.POST("", h.CreateWallet)
+		wallet.DELETE("/:number", h.CloseWallet)
+	}
+}
+
+func (h *Handler) initAdminWalletRoutes(admin *gin.RouterGroup) {
+	wallet := admin.Group("/wallets")
+	{
+		wallet.PUT("/:number/status", h.UpdateWalletStatus)
+	}
+}
+
+func (h *Handler) GetWallets(ctx *gin.Context) {
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.Wallet.FindByUserID(
+		user.(*entity.TokenizedUser).ID,
+	)
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatMultipleWallet(res),
+	)
+}
+
+func (h *Handler) CreateWallet(ctx *gin.Context) {
+	var input dto.CreateWalletRequestBody
+	err := ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	userID := user.(*entity.TokenizedUser).ID
+	res, err := h.services.Wallet.CreateWallet(&entity.Wallet{
+		UserID:     &userID,
+		Currency:   input.Currency,
+		Name:       input.Name,
+		GoalAmount: input.GoalAmount,
+		GoalDate:   input.GoalDate,
+	})
+
+	if err != nil {
+		writeWalletErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusCreated,
+		http.StatusText(http.StatusCreated),
+		dto.FormatWallet(res),
+	)
+}
+
+func (h *Handler) CloseWallet(ctx *gin.Context) {
+	number, err := strconv.Atoi(ctx.Param("number"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+	tokenizedUser := user.(*entity.TokenizedUser)
+
+	if number == tokenizedUser.WalletNumber {
+		writeWalletErrorResponse(ctx, &custom_error.CannotClosePrimaryWallet{})
+		return
+	}
+
+	err = h.services.Wallet.CloseWallet(number, tokenizedUser.ID)
+	if err != nil {
+		writeWalletErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		nil,
+	)
+}
+
+func (h *Handler) UpdateWalletStatus(ctx *gin.Context) {
+	number, err := strconv.Atoi(ctx.Param("number"))
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+			nil,
+		)
+		return
+	}
+
+	var input dto.UpdateWalletStatusRequestBody
+	err = ctx.ShouldBindJSON(&input)
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	user, ok := ctx.Get("user")
+	if !ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			custom_error.FailedToGetInfoFromToken{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	adminID := user.(*entity.TokenizedUser).ID
+	res, err := h.services.Wallet.UpdateStatus(
+		&entity.Wallet{
+			Number:          number,
+			Status:          input.Status,
+			StatusReason:    input.Reason,
+			StatusChangedBy: &adminID,
+		},
+		input.BankAccountID,
+	)
+
+	if err != nil {
+		writeWalletErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatWallet(res),
+	)
+}
+
+func writeWalletErrorResponse(ctx *gin.Context, err error) {
+	switch err.(type) {
+	case *custom_error.CurrencyNotSupported,
+		*custom_error.GoalDateInPast,
+		*custom_error.CannotClosePrimaryWallet,
+		*custom_error.WalletNotEmpty,
+		*custom_error.WalletClosed:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.NoDataFound:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.PayoutFailed:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadGateway,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.PayoutPending:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusConflict,
+			err.Error(),
+			nil,
+		)
+	default:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+	}
+}
+
+// ownedWalletNumber is the wallet a request acts on: number when the user
+// picked one of their wallets, otherwise the user's primary wallet. It
+// writes the error response and returns false when the user does not own
+// the wallet.
+func (h *Handler) ownedWalletNumber(
+	ctx *gin.Context,
+	tokenizedUser *entity.TokenizedUser,
+	number int,
+) (int, bool) {
+	if number == 0 || number == tokenizedUser.WalletNumber {
+		return tokenizedUser.WalletNumber, true
+	}
+
+	_, err := h.services.Wallet.FindOwned(number, tokenizedUser.ID)
+
+	if _, ok := err.(*custom_error.NoDataFound); ok {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+		return 0, false
+	}
+
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+		return 0, false
+	}
+
+	return number, true
+}
@@ -0,0 +1,481 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
+	"assignment-golang-backend/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type walletHandlerTest struct {
+	name                   string
+	path                   string
+	body                   io.Reader
+	mockUserFromMiddleware bool
+	mock                   func(*mocks.IWalletService)
+	want                   helper.JsonResponse
+}
+
+func runWalletHandlerTests(
+	t *testing.T,
+	method string,
+	route string,
+	defaultPath string,
+	handlerFunc func(*Handler) gin.HandlerFunc,
+	tests []walletHandlerTest,
+) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path
+			if path == "" {
+				path = defaultPath
+			}
+
+			walletService := mocks.NewIWalletService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					Wallet: walletService,
+				},
+			}
+
+			tt.mock(walletService)
+
+			r := SetUpRouter()
+			if tt.mockUserFromMiddleware {
+				r.Handle(method, route, MiddlewareMockUser, handlerFunc(h))
+			} else {
+				r.Handle(method, route, handlerFunc(h))
+			}
+			req, _ := http.NewRequest(method, path, tt.body)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_initWalletRoutes(t *testing.T) {
+	router := SetUpRouter()
+	handler := New(&usecase.Services{})
+	group := router.Group("/")
+
+	handler.initWalletRoutes(group)
+}
+
+func TestHandler_GetWallets(t *testing.T) {
+	mockWallet := &entity.Wallet{Number: 100001, Balance: 1000, Currency: entity.IDR}
+	mockDataInInterface, err := StructToMap(dto.FormatWallet(mockWallet))
+	require.NoError(t, err)
+
+	runWalletHandlerTests(
+		t,
+		http.MethodGet,
+		"/api/wallets",
+		"/api/wallets",
+		func(h *Handler) gin.HandlerFunc { return h.GetWallets },
+		[]walletHandlerTest{
+			{
+				name:                   "Error | Failed to get user key from middleware",
+				mockUserFromMiddleware: false,
+				mock:                   func(ws *mocks.IWalletService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: custom_error.FailedToGetInfoFromToken{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Error from service",
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("FindByUserID", MockTokenizedUser.ID).
+						Return(nil, fmt.Errorf("error"))
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: http.StatusText(http.StatusInternalServerError),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("FindByUserID", MockTokenizedUser.ID).
+						Return([]*entity.Wallet{mockWallet}, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    []interface{}{mockDataInInterface},
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_CreateWallet(t *testing.T) {
+	validBody := &dto.CreateWalletRequestBody{Name: "Holiday", Currency: entity.USD}
+	mockWallet := &entity.Wallet{Number: 100002, Currency: entity.USD, Name: "Holiday"}
+	mockDataInInterface, err := StructToMap(dto.FormatWallet(mockWallet))
+	require.NoError(t, err)
+	isValidBody := mock.MatchedBy(func(wallet *entity.Wallet) bool {
+		return *wallet.UserID == MockTokenizedUser.ID &&
+			wallet.Name == validBody.Name &&
+			wallet.Currency == validBody.Currency
+	})
+
+	runWalletHandlerTests(
+		t,
+		http.MethodPost,
+		"/api/wallets",
+		"/api/wallets",
+		func(h *Handler) gin.HandlerFunc { return h.CreateWallet },
+		[]walletHandlerTest{
+			{
+				name:                   "Error | Invalid Request Body",
+				body:                   MakeRequestBody(&dto.CreateWalletRequestBody{Currency: entity.USD}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ws *mocks.IWalletService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidRequestBody{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Goal date in the past",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("CreateWallet", isValidBody).
+						Return(nil, &custom_error.GoalDateInPast{})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.GoalDateInPast{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Error from service",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("CreateWallet", isValidBody).
+						Return(nil, fmt.Errorf("error"))
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusInternalServerError,
+					Message: http.StatusText(http.StatusInternalServerError),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("CreateWallet", isValidBody).Return(mockWallet, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusCreated,
+					Message: http.StatusText(http.StatusCreated),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_CloseWallet(t *testing.T) {
+	runWalletHandlerTests(
+		t,
+		http.MethodDelete,
+		"/api/wallets/:number",
+		"/api/wallets/100002",
+		func(h *Handler) gin.HandlerFunc { return h.CloseWallet },
+		[]walletHandlerTest{
+			{
+				name:                   "Error | Primary wallet",
+				path:                   fmt.Sprintf("/api/wallets/%d", MockTokenizedUser.WalletNumber),
+				mockUserFromMiddleware: true,
+				mock:                   func(ws *mocks.IWalletService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.CannotClosePrimaryWallet{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Wallet of another user",
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("CloseWallet", 100002, MockTokenizedUser.ID).
+						Return(&custom_error.NoDataFound{DataType: "wallet"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusNotFound,
+					Message: custom_error.NoDataFound{DataType: "wallet"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Wallet still holds money",
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("CloseWallet", 100002, MockTokenizedUser.ID).
+						Return(&custom_error.WalletNotEmpty{})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.WalletNotEmpty{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("CloseWallet", 100002, MockTokenizedUser.ID).Return(nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    nil,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_UpdateWalletStatus(t *testing.T) {
+	bankAccountID := 1
+	validBody := &dto.UpdateWalletStatusRequestBody{
+		Status: entity.WalletFrozen,
+		Reason: "Reported stolen",
+	}
+	closeBody := &dto.UpdateWalletStatusRequestBody{
+		Status:        entity.WalletClosed,
+		Reason:        "Account closed",
+		BankAccountID: &bankAccountID,
+	}
+	mockWallet := &entity.Wallet{
+		Number:       100002,
+		Status:       entity.WalletFrozen,
+		StatusReason: validBody.Reason,
+	}
+	mockDataInInterface, err := StructToMap(dto.FormatWallet(mockWallet))
+	require.NoError(t, err)
+	isChange := func(body *dto.UpdateWalletStatusRequestBody) interface{} {
+		return mock.MatchedBy(func(wallet *entity.Wallet) bool {
+			return wallet.Number == 100002 &&
+				wallet.Status == body.Status &&
+				wallet.StatusReason == body.Reason &&
+				*wallet.StatusChangedBy == MockTokenizedUser.ID
+		})
+	}
+
+	runWalletHandlerTests(
+		t,
+		http.MethodPut,
+		"/api/admin/wallets/:number/status",
+		"/api/admin/wallets/100002/status",
+		func(h *Handler) gin.HandlerFunc { return h.UpdateWalletStatus },
+		[]walletHandlerTest{
+			{
+				name: "Error | Unknown status",
+				body: MakeRequestBody(&dto.UpdateWalletStatusRequestBody{
+					Status: "LOCKED",
+					Reason: "reason",
+				}),
+				mockUserFromMiddleware: true,
+				mock:                   func(ws *mocks.IWalletService) {},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.InvalidRequestBody{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Wallet not found",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("UpdateStatus", isChange(validBody), (*int)(nil)).
+						Return(nil, &custom_error.NoDataFound{DataType: "wallet"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusNotFound,
+					Message: custom_error.NoDataFound{DataType: "wallet"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Wallet already closed",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("UpdateStatus", isChange(validBody), (*int)(nil)).
+						Return(nil, &custom_error.WalletClosed{})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadRequest,
+					Message: custom_error.WalletClosed{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Payout of the balance failed",
+				body:                   MakeRequestBody(closeBody),
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("UpdateStatus", isChange(closeBody), &bankAccountID).
+						Return(nil, &custom_error.PayoutFailed{Reason: "rejected"})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusBadGateway,
+					Message: custom_error.PayoutFailed{Reason: "rejected"}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Error | Payout of the balance still in flight",
+				body:                   MakeRequestBody(closeBody),
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("UpdateStatus", isChange(closeBody), &bankAccountID).
+						Return(nil, &custom_error.PayoutPending{})
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusConflict,
+					Message: custom_error.PayoutPending{}.Error(),
+					Data:    nil,
+				},
+			},
+			{
+				name:                   "Success",
+				body:                   MakeRequestBody(validBody),
+				mockUserFromMiddleware: true,
+				mock: func(ws *mocks.IWalletService) {
+					ws.On("UpdateStatus", isChange(validBody), (*int)(nil)).
+						Return(mockWallet, nil)
+				},
+				want: helper.JsonResponse{
+					Code:    http.StatusOK,
+					Message: http.StatusText(http.StatusOK),
+					Data:    mockDataInInterface,
+				},
+			},
+		},
+	)
+}
+
+func TestHandler_Transfer_fromWallet(t *testing.T) {
+	body := dto.TransferRequestBody{
+		From:   100002,
+		To:     2,
+		Amount: 1000,
+		Pin:    "123456",
+	}
+
+	tests := []struct {
+		name string
+		mock func(*mocks.IWalletService, *mocks.IPinService, *mocks.ITransactionService)
+		want helper.JsonResponse
+	}{
+		{
+			name: "Error | Wallet of another user",
+			mock: func(
+				ws *mocks.IWalletService,
+				ps *mocks.IPinService,
+				ts *mocks.ITransactionService,
+			) {
+				ws.On("FindOwned", body.From, MockTokenizedUser.ID).
+					Return(nil, &custom_error.NoDataFound{DataType: "wallet"})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusNotFound,
+				Message: custom_error.NoDataFound{DataType: "wallet"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name: "Error | No exchange rate to the destination wallet",
+			mock: func(
+				ws *mocks.IWalletService,
+				ps *mocks.IPinService,
+				ts *mocks.ITransactionService,
+			) {
+				ws.On("FindOwned", body.From, MockTokenizedUser.ID).
+					Return(&entity.Wallet{Number: body.From}, nil)
+				ps.On("VerifyPin", MockTokenizedUser.ID, body.Pin).Return(nil)
+				ts.On("CreateTransaction", mock.MatchedBy(func(transfer *entity.Transaction) bool {
+					return transfer.From == body.From
+				})).
+					Return(nil, &custom_error.ExchangeRateUnavailable{From: "USD", To: "SGD"})
+			},
+			want: helper.JsonResponse{
+				Code: http.StatusBadRequest,
+				Message: custom_error.ExchangeRateUnavailable{
+					From: "USD",
+					To:   "SGD",
+				}.Error(),
+				Data: nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			walletService := mocks.NewIWalletService(t)
+			pinService := mocks.NewIPinService(t)
+			transactionService := mocks.NewITransactionService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					Wallet:      walletService,
+					Pin:         pinService,
+					Transaction: transactionService,
+				},
+			}
+
+			tt.mock(walletService, pinService, transactionService)
+
+			r := SetUpRouter()
+			r.POST("/api/transactions/transfer", MiddlewareMockUser, h.Transfer)
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				"/api/transactions/transfer",
+				MakeRequestBody(body),
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+// initWebhookRoutes registers the routes payment providers call. They are
+// not behind the JWT middleware; each provider signs its own calls.
+func (h *Handler) initWebhookRoutes(api *gin.RouterGroup) {
+	webhook := api.Group("/webhooks")
+	{
+		webhook.POST("/topups/:provider", h.TopupWebhook)
+	}
+}
+
+func (h *Handler) TopupWebhook(ctx *gin.Context) {
+	body, err := ctx.GetRawData()
+	if err != nil {
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			custom_error.InvalidRequestBody{}.Error(),
+			nil,
+		)
+		return
+	}
+
+	res, err := h.services.Topup.HandleWebhook(
+		ctx.Param("provider"),
+		body,
+		ctx.Request.Header,
+	)
+
+	if err != nil {
+		writeTopupWebhookErrorResponse(ctx, err)
+		return
+	}
+
+	helper.WriteSuccessResponse(
+		ctx,
+		http.StatusOK,
+		http.StatusText(http.StatusOK),
+		dto.FormatGetTransaction(res, res.To),
+	)
+}
+
+func writeTopupWebhookErrorResponse(ctx *gin.Context, err error) {
+	switch err.(type) {
+	case *custom_error.InvalidWebhookSignature:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusUnauthorized,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.NoDataFound:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusNotFound,
+			err.Error(),
+			nil,
+		)
+	case *custom_error.InvalidRequestBody,
+		*custom_error.TransactionNotPending:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusBadRequest,
+			err.Error(),
+			nil,
+		)
+	default:
+		helper.WriteErrorResponse(
+			ctx,
+			http.StatusInternalServerError,
+			http.StatusText(http.StatusInternalServerError),
+			nil,
+		)
+	}
+}
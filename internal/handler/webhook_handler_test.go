@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/dto"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_initWebhookRoutes(t *testing.T) {
+	router := SetUpRouter()
+	handler := New(&usecase.Services{})
+	group := router.Group("/")
+
+	handler.initWebhookRoutes(group)
+}
+
+func TestHandler_TopupWebhook(t *testing.T) {
+	body := []byte(`{"reference":"FAKE-VA-1","status":"PAID"}`)
+	mockSourceID := entity.SourceOfFundsID(1)
+	mockTopup := &entity.Transaction{
+		Base:              entity.Base{ID: 1},
+		Amount:            50000,
+		Type:              entity.TopUp,
+		Status:            entity.TransactionCompleted,
+		SourceID:          &mockSourceID,
+		SourceOfFunds:     &entity.SourceOfFunds{Name: "Bank Transfer"},
+		From:              MockTokenizedUser.WalletNumber,
+		To:                MockTokenizedUser.WalletNumber,
+		ProviderReference: "FAKE-VA-1",
+	}
+	mockTopupInInterface, err := StructToMap(
+		dto.FormatGetTransaction(mockTopup, mockTopup.To),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		mock func(*mocks.ITopupService)
+		want helper.JsonResponse
+	}{
+		{
+			name: "Error | Invalid signature",
+			mock: func(ts *mocks.ITopupService) {
+				ts.On("HandleWebhook", "fake", body, mock.Anything).
+					Return(nil, &custom_error.InvalidWebhookSignature{})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusUnauthorized,
+				Message: custom_error.InvalidWebhookSignature{}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name: "Error | Top up not found",
+			mock: func(ts *mocks.ITopupService) {
+				ts.On("HandleWebhook", "fake", body, mock.Anything).
+					Return(nil, &custom_error.NoDataFound{DataType: "top up"})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusNotFound,
+				Message: custom_error.NoDataFound{DataType: "top up"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name: "Error | Top up already settled",
+			mock: func(ts *mocks.ITopupService) {
+				ts.On("HandleWebhook", "fake", body, mock.Anything).
+					Return(nil, &custom_error.TransactionNotPending{Status: "FAILED"})
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: custom_error.TransactionNotPending{Status: "FAILED"}.Error(),
+				Data:    nil,
+			},
+		},
+		{
+			name: "Error | Other errors from services",
+			mock: func(ts *mocks.ITopupService) {
+				ts.On("HandleWebhook", "fake", body, mock.Anything).
+					Return(nil, fmt.Errorf("error"))
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+				Data:    nil,
+			},
+		},
+		{
+			name: "Success",
+			mock: func(ts *mocks.ITopupService) {
+				ts.On("HandleWebhook", "fake", body, mock.Anything).
+					Return(mockTopup, nil)
+			},
+			want: helper.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockTopupInInterface,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topupService := mocks.NewITopupService(t)
+			h := &Handler{
+				services: &usecase.Services{
+					Topup: topupService,
+				},
+			}
+
+			tt.mock(topupService)
+
+			r := SetUpRouter()
+			r.POST("/api/webhooks/topups/:provider", h.TopupWebhook)
+
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				"/api/webhooks/topups/fake",
+				bytes.NewReader(body),
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helper.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"assignment-golang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	JWKS_MAX_AGE_SECOND = 300
+)
+
+func (h *Handler) initWellKnownRoutes(router *gin.Engine) {
+	wellKnown := router.Group("/.well-known")
+	{
+		wellKnown.GET("/jwks.json", h.GetJWKS)
+	}
+}
+
+// GetJWKS publishes the public token verification keys. The response is a
+// plain JWK set instead of helper.JsonResponse, so standard JWT libraries
+// can consume it directly.
+func (h *Handler) GetJWKS(ctx *gin.Context) {
+	ctx.Header(
+		"Cache-Control",
+		fmt.Sprintf("public, max-age=%d", JWKS_MAX_AGE_SECOND),
+	)
+	ctx.JSON(http.StatusOK, helper.GetJWKS())
+}
@@ -1,7 +1,6 @@
 package helper
 
 import (
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -29,10 +28,17 @@ func GenerateJWT(user *entity.User) (string, error) {
 		Name:         user.Name,
 		Email:        user.Email,
 		WalletNumber: user.WalletNumber,
+		Role:         user.Role,
+	}
+
+	jti, err := GenerateRandomToken()
+	if err != nil {
+		return "", err
 	}
 
 	claims := &IdTokenClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			Issuer:    config.GetEnv("TOKEN_ISSUER"),
 			ExpiresAt: &jwt.NumericDate{Time: time.Unix(tokenExp, 0)},
 			IssuedAt:  &jwt.NumericDate{Time: time.Now()},
@@ -40,10 +46,13 @@ func GenerateJWT(user *entity.User) (string, error) {
 		User: tokenizedUser,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(
-		[]byte(config.GetEnv("TOKEN_SECRET")),
-	)
+	key := getKeySet().activeKey()
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+
+	tokenString, err := token.SignedString(key.PrivateKey)
 	if err != nil {
 		return "", err
 	}
@@ -52,17 +61,7 @@ func GenerateJWT(user *entity.User) (string, error) {
 }
 
 func ValidateToken(token string) (*jwt.Token, error) {
-	return jwt.ParseWithClaims(
-		token,
-		&IdTokenClaims{},
-		func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, &custom_error.InvalidToken{}
-			}
-
-			return []byte(os.Getenv("TOKEN_SECRET")), nil
-		},
-	)
+	return jwt.ParseWithClaims(token, &IdTokenClaims{}, getKeySet().keyFunc)
 }
 
 func ParseAuthorizationHeader(authHeader string) (string, error) {
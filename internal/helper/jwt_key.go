@@ -0,0 +1,247 @@
+package helper
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"assignment-golang-backend/internal/config"
+	"assignment-golang-backend/internal/custom_error"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	JWT_KEY_FILE_EXTENSION = ".pem"
+)
+
+// SigningKey is one key of a KeySet. The file name of the PEM file without
+// its extension is used as the key ID. PrivateKey is nil for keys that are
+// only kept to verify tokens signed before a rotation.
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+// KeySet holds every key that tokens may be verified with and the ID of the
+// key new tokens are signed with.
+type KeySet struct {
+	ActiveKeyID string
+	Keys        map[string]*SigningKey
+}
+
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+var keySet *KeySet
+
+// LoadKeySet loads the signing keys from TOKEN_KEY_DIR. When TOKEN_KEY_DIR
+// is not set, tokens keep being signed with TOKEN_SECRET using HS256.
+func LoadKeySet() {
+	dir := config.GetEnv("TOKEN_KEY_DIR")
+	if dir == "" {
+		log.Println("TOKEN_KEY_DIR is not set, signing tokens with TOKEN_SECRET")
+		return
+	}
+
+	ks, err := NewKeySet(dir, config.GetEnv("TOKEN_ACTIVE_KEY_ID"))
+	if err != nil {
+		log.Fatalln("Error loading token signing keys with err:", err.Error())
+	}
+
+	keySet = ks
+}
+
+func SetKeySet(ks *KeySet) {
+	keySet = ks
+}
+
+func getKeySet() *KeySet {
+	if keySet != nil {
+		return keySet
+	}
+
+	secret := []byte(config.GetEnv("TOKEN_SECRET"))
+	return &KeySet{
+		Keys: map[string]*SigningKey{
+			"": {
+				Method:     jwt.SigningMethodHS256,
+				PrivateKey: secret,
+				PublicKey:  secret,
+			},
+		},
+	}
+}
+
+// NewKeySet reads every PEM file in dir. Private keys (PKCS#1 or PKCS#8,
+// RSA or Ed25519) can sign and verify, public keys (PKIX) only verify.
+// When activeKeyID is empty, the private key with the greatest ID signs.
+func NewKeySet(dir string, activeKeyID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+JWT_KEY_FILE_EXTENSION))
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &KeySet{Keys: map[string]*SigningKey{}}
+	var signingKeyIDs []string
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		id := strings.TrimSuffix(filepath.Base(path), JWT_KEY_FILE_EXTENSION)
+		key, err := parseSigningKey(id, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		ks.Keys[id] = key
+		if key.PrivateKey != nil {
+			signingKeyIDs = append(signingKeyIDs, id)
+		}
+	}
+
+	if activeKeyID == "" && len(signingKeyIDs) > 0 {
+		sort.Strings(signingKeyIDs)
+		activeKeyID = signingKeyIDs[len(signingKeyIDs)-1]
+	}
+
+	active, ok := ks.Keys[activeKeyID]
+	if !ok || active.PrivateKey == nil {
+		return nil, fmt.Errorf("no private key %q in %s", activeKeyID, dir)
+	}
+
+	ks.ActiveKeyID = activeKeyID
+	return ks, nil
+}
+
+func parseSigningKey(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{ID: id}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method = jwt.SigningMethodRS256
+		key.PrivateKey = k
+		key.PublicKey = &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+		key.PublicKey = k
+	case ed25519.PrivateKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.PrivateKey = k
+		key.PublicKey = k.Public()
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.PublicKey = k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+
+	return key, nil
+}
+
+func (ks *KeySet) activeKey() *SigningKey {
+	return ks.Keys[ks.ActiveKeyID]
+}
+
+// keyFunc picks the verification key by the kid header and rejects tokens
+// whose algorithm does not match that key.
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.Keys[kid]
+	if !ok || token.Method.Alg() != key.Method.Alg() {
+		return nil, &custom_error.InvalidToken{}
+	}
+
+	return key.PublicKey, nil
+}
+
+// JWKS returns the public keys of the set. Symmetric keys are never
+// published.
+func (ks *KeySet) JWKS() *JSONWebKeySet {
+	ids := make([]string, 0, len(ks.Keys))
+	for id := range ks.Keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	jwks := &JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, id := range ids {
+		key := ks.Keys[id]
+		jwk := JSONWebKey{
+			Use:       "sig",
+			Algorithm: key.Method.Alg(),
+			KeyID:     key.ID,
+		}
+
+		switch k := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(
+				big.NewInt(int64(k.E)).Bytes(),
+			)
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(k)
+		default:
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+func GetJWKS() *JSONWebKeySet {
+	return getKeySet().JWKS()
+}
@@ -0,0 +1,170 @@
+package helper
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"assignment-golang-backend/internal/entity"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRSAKey(t *testing.T, dir string, id string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	writePEM(t, dir, id, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key))
+}
+
+func writeEd25519Key(t *testing.T, dir string, id string) ed25519.PublicKey {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	writePEM(t, dir, id, "PRIVATE KEY", der)
+	return pub
+}
+
+func writePEM(t *testing.T, dir string, id string, blockType string, der []byte) {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	err := os.WriteFile(filepath.Join(dir, id+JWT_KEY_FILE_EXTENSION), data, 0600)
+	require.NoError(t, err)
+}
+
+func generateTestJWT(t *testing.T) string {
+	token, err := GenerateJWT(&entity.User{
+		Base:         entity.Base{ID: 1},
+		WalletNumber: 1,
+	})
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestNewKeySet(t *testing.T) {
+	t.Run("Error | Empty directory", func(t *testing.T) {
+		_, err := NewKeySet(t.TempDir(), "")
+		assert.Error(t, err)
+	})
+
+	t.Run("Error | Active key is not a private key", func(t *testing.T) {
+		dir := t.TempDir()
+		pub := writeEd25519Key(t, dir, "2024-01")
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		require.NoError(t, err)
+		writePEM(t, dir, "2023-12", "PUBLIC KEY", der)
+
+		_, err = NewKeySet(dir, "2023-12")
+		assert.Error(t, err)
+	})
+
+	t.Run("Success | Latest private key is active by default", func(t *testing.T) {
+		dir := t.TempDir()
+		writeRSAKey(t, dir, "2024-01")
+		writeEd25519Key(t, dir, "2024-02")
+
+		ks, err := NewKeySet(dir, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "2024-02", ks.ActiveKeyID)
+		assert.Equal(t, jwt.SigningMethodRS256, ks.Keys["2024-01"].Method)
+		assert.Equal(t, jwt.SigningMethodEdDSA, ks.Keys["2024-02"].Method)
+	})
+}
+
+func TestValidateToken_KeyRotation(t *testing.T) {
+	t.Setenv("TOKEN_EXP_MINUTE", "5")
+	t.Cleanup(func() { SetKeySet(nil) })
+
+	dir := t.TempDir()
+	writeRSAKey(t, dir, "2024-01")
+
+	ks, err := NewKeySet(dir, "")
+	require.NoError(t, err)
+	SetKeySet(ks)
+	oldToken := generateTestJWT(t)
+
+	writeEd25519Key(t, dir, "2024-02")
+	ks, err = NewKeySet(dir, "")
+	require.NoError(t, err)
+	SetKeySet(ks)
+	newToken := generateTestJWT(t)
+
+	for _, tokenStr := range []string{oldToken, newToken} {
+		token, err := ValidateToken(tokenStr)
+		require.NoError(t, err)
+		assert.True(t, token.Valid)
+	}
+
+	token, err := ValidateToken(newToken)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-02", token.Header["kid"])
+	assert.Equal(t, jwt.SigningMethodEdDSA.Alg(), token.Method.Alg())
+}
+
+func TestValidateToken_RejectsUnknownKeys(t *testing.T) {
+	t.Setenv("TOKEN_EXP_MINUTE", "5")
+	t.Setenv("TOKEN_SECRET", "secret")
+	t.Cleanup(func() { SetKeySet(nil) })
+
+	hmacToken := generateTestJWT(t)
+
+	dir := t.TempDir()
+	writeRSAKey(t, dir, "2024-01")
+	ks, err := NewKeySet(dir, "")
+	require.NoError(t, err)
+	SetKeySet(ks)
+
+	_, err = ValidateToken(hmacToken)
+	assert.Error(t, err)
+
+	otherDir := t.TempDir()
+	writeRSAKey(t, otherDir, "2024-01")
+	otherKs, err := NewKeySet(otherDir, "")
+	require.NoError(t, err)
+	SetKeySet(otherKs)
+	forgedToken := generateTestJWT(t)
+
+	SetKeySet(ks)
+	_, err = ValidateToken(forgedToken)
+	assert.Error(t, err)
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	dir := t.TempDir()
+	writeRSAKey(t, dir, "2024-01")
+	pub := writeEd25519Key(t, dir, "2024-02")
+
+	ks, err := NewKeySet(dir, "")
+	require.NoError(t, err)
+
+	jwks := ks.JWKS()
+	require.Len(t, jwks.Keys, 2)
+
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "RS256", jwks.Keys[0].Algorithm)
+	assert.Equal(t, "2024-01", jwks.Keys[0].KeyID)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+
+	assert.Equal(t, "OKP", jwks.Keys[1].KeyType)
+	assert.Equal(t, "EdDSA", jwks.Keys[1].Algorithm)
+	assert.Equal(t, "Ed25519", jwks.Keys[1].Curve)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(pub), jwks.Keys[1].X)
+}
+
+func TestGetJWKS_WithoutKeyDirectory(t *testing.T) {
+	SetKeySet(nil)
+
+	assert.Empty(t, GetJWKS().Keys)
+}
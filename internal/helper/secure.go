@@ -1,11 +1,24 @@
 package helper
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"log"
+	"regexp"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	RANDOM_TOKEN_BYTES = 32
+	PIN_LENGTH         = 6
+)
+
+var pinPattern = regexp.MustCompile(fmt.Sprintf(`^[0-9]{%d}$`, PIN_LENGTH))
+
 func HashAndSalt(pwd string) (string, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.MinCost)
 	if err != nil {
@@ -25,3 +38,26 @@ func ComparePasswords(hashedPwd string, plainPwd []byte) bool {
 
 	return true
 }
+
+// GenerateRandomToken returns a URL safe random string suitable for opaque
+// tokens and identifiers.
+func GenerateRandomToken() (string, error) {
+	b := make([]byte, RANDOM_TOKEN_BYTES)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken hashes an opaque token before it is stored, so a leaked table
+// cannot be used to authenticate.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func IsValidPin(pin string) bool {
+	return pinPattern.MatchString(pin)
+}
@@ -0,0 +1,40 @@
+package helper
+
+import (
+	"strings"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+)
+
+// ParseSort reads a sort such as "-amount,datetime": fields separated by
+// commas, each one descending when prefixed with a minus sign. Fields that
+// are not in allowed, empty or repeated are rejected by name.
+func ParseSort(spec string, allowed []string) (entity.Sort, error) {
+	var sort entity.Sort
+	for _, field := range strings.Split(spec, ",") {
+		sortField := entity.SortField{Field: strings.TrimSpace(field)}
+		if strings.HasPrefix(sortField.Field, "-") {
+			sortField.Field = sortField.Field[1:]
+			sortField.Descending = true
+		}
+
+		if !isAllowedSortField(sortField.Field, allowed) || sort.Has(sortField.Field) {
+			return nil, &custom_error.InvalidSortField{Field: field}
+		}
+
+		sort = append(sort, sortField)
+	}
+
+	return sort, nil
+}
+
+func isAllowedSortField(field string, allowed []string) bool {
+	for _, allowedField := range allowed {
+		if field == allowedField {
+			return true
+		}
+	}
+
+	return false
+}
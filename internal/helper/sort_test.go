@@ -0,0 +1,58 @@
+package helper
+
+import (
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	allowed := []string{"datetime", "amount"}
+
+	tests := []struct {
+		name    string
+		spec    string
+		want    entity.Sort
+		wantErr error
+	}{
+		{
+			name: "Success | Single field",
+			spec: "datetime",
+			want: entity.Sort{{Field: "datetime"}},
+		},
+		{
+			name: "Success | Several fields",
+			spec: "-amount, datetime",
+			want: entity.Sort{
+				{Field: "amount", Descending: true},
+				{Field: "datetime"},
+			},
+		},
+		{
+			name:    "Error | Field not allowed",
+			spec:    "amount,-id",
+			wantErr: &custom_error.InvalidSortField{Field: "-id"},
+		},
+		{
+			name:    "Error | Field repeated",
+			spec:    "amount,-amount",
+			wantErr: &custom_error.InvalidSortField{Field: "-amount"},
+		},
+		{
+			name:    "Error | Empty field",
+			spec:    "amount,",
+			wantErr: &custom_error.InvalidSortField{Field: ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSort(tt.spec, allowed)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Package ledger builds the double-entry journal entries posted for every
+// money movement. Wallet accounts are liabilities towards the user, so a
+// credit raises the wallet balance and a debit lowers it.
+package ledger
+
+import (
+	"fmt"
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+)
+
+// NewTopupEntry credits the amount of a top up to the wallet. The payer
+// pays its fee on top, which is credited to the house wallet.
+func NewTopupEntry(topup *entity.Transaction) *entity.JournalEntry {
+	sourceID := 0
+	if topup.SourceID != nil {
+		sourceID = int(*topup.SourceID)
+	}
+
+	entry := &entity.JournalEntry{
+		TransactionID: &topup.ID,
+		Description:   topup.Description,
+		Datetime:      topup.Datetime,
+		Postings: []entity.Posting{
+			{
+				AccountType:   entity.FundingSourceAccount,
+				AccountNumber: sourceID,
+				Direction:     entity.Debit,
+				Amount:        topup.Amount + topup.Fee,
+			},
+			{
+				AccountType:   entity.WalletAccount,
+				AccountNumber: topup.To,
+				Direction:     entity.Credit,
+				Amount:        topup.Amount,
+			},
+		},
+	}
+
+	appendFeePosting(entry, entity.BaseCurrency, topup.Fee)
+
+	return entry
+}
+
+// NewTransferEntry moves the amount of a transfer between the wallets. The
+// sender pays its fee on top, which is credited to the house wallet of its
+// currency. A transfer between currencies goes through the exchange account
+// of each wallet: the amount leaves the sender's currency through the one of
+// the sender and the converted amount enters the receiver's currency through
+// the one of the receiver.
+func NewTransferEntry(transfer *entity.Transaction) *entity.JournalEntry {
+	entry := &entity.JournalEntry{
+		TransactionID: &transfer.ID,
+		Description:   transfer.Description,
+		Datetime:      transfer.Datetime,
+		Postings: []entity.Posting{
+			{
+				AccountType:   entity.WalletAccount,
+				AccountNumber: transfer.From,
+				Direction:     entity.Debit,
+				Amount:        transfer.Amount + transfer.Fee,
+			},
+		},
+	}
+
+	if transfer.ConvertedAmount != nil {
+		entry.Postings = append(
+			entry.Postings,
+			entity.Posting{
+				AccountType:   entity.ExchangeAccount,
+				AccountNumber: transfer.From,
+				Direction:     entity.Credit,
+				Amount:        transfer.Amount,
+			},
+			entity.Posting{
+				AccountType:   entity.ExchangeAccount,
+				AccountNumber: transfer.To,
+				Direction:     entity.Debit,
+				Amount:        *transfer.ConvertedAmount,
+			},
+		)
+	}
+
+	entry.Postings = append(entry.Postings, entity.Posting{
+		AccountType:   entity.WalletAccount,
+		AccountNumber: transfer.To,
+		Direction:     entity.Credit,
+		Amount:        transfer.ReceivedAmount(),
+	})
+
+	appendFeePosting(entry, transfer.Currency, transfer.Fee)
+
+	return entry
+}
+
+// appendFeePosting credits a fee to the house wallet of its currency as a
+// line of its own.
+func appendFeePosting(
+	entry *entity.JournalEntry,
+	currency entity.Currency,
+	fee int,
+) {
+	if fee <= 0 {
+		return
+	}
+
+	entry.Postings = append(entry.Postings, entity.Posting{
+		AccountType:   entity.WalletAccount,
+		AccountNumber: currency.HouseWalletNumber(),
+		Direction:     entity.Credit,
+		Amount:        fee,
+	})
+}
+
+// NewWithdrawalEntry moves the amount of a withdrawal out of the wallet into
+// the payout account of its bank account while the payout is in flight.
+func NewWithdrawalEntry(withdrawal *entity.Transaction) *entity.JournalEntry {
+	return &entity.JournalEntry{
+		TransactionID: &withdrawal.ID,
+		Description:   withdrawal.Description,
+		Datetime:      withdrawal.Datetime,
+		Postings: []entity.Posting{
+			{
+				AccountType:   entity.WalletAccount,
+				AccountNumber: withdrawal.From,
+				Direction:     entity.Debit,
+				Amount:        withdrawal.Amount,
+			},
+			{
+				AccountType:   entity.PayoutAccount,
+				AccountNumber: *withdrawal.BankAccountID,
+				Direction:     entity.Credit,
+				Amount:        withdrawal.Amount,
+			},
+		},
+	}
+}
+
+// NewFailedWithdrawalEntry returns the amount of a failed withdrawal from
+// the payout account to the wallet.
+func NewFailedWithdrawalEntry(
+	withdrawal *entity.Transaction,
+	datetime time.Time,
+) *entity.JournalEntry {
+	return &entity.JournalEntry{
+		TransactionID: &withdrawal.ID,
+		Description:   fmt.Sprintf("Failed withdrawal %d", withdrawal.ID),
+		Datetime:      datetime,
+		Postings: []entity.Posting{
+			{
+				AccountType:   entity.PayoutAccount,
+				AccountNumber: *withdrawal.BankAccountID,
+				Direction:     entity.Debit,
+				Amount:        withdrawal.Amount,
+			},
+			{
+				AccountType:   entity.WalletAccount,
+				AccountNumber: withdrawal.From,
+				Direction:     entity.Credit,
+				Amount:        withdrawal.Amount,
+			},
+		},
+	}
+}
+
+// NewTransactionEntry returns the journal entry a transaction posts when
+// it settles, and false for one that moved no money: a top up that was not
+// paid or a withdrawal that failed.
+func NewTransactionEntry(
+	transaction *entity.Transaction,
+) (*entity.JournalEntry, bool) {
+	switch transaction.Type {
+	case entity.TopUp:
+		if transaction.Status != entity.TransactionCompleted {
+			return nil, false
+		}
+
+		return NewTopupEntry(transaction), true
+	case entity.Withdrawal:
+		if transaction.Status == entity.TransactionFailed {
+			return nil, false
+		}
+
+		return NewWithdrawalEntry(transaction), true
+	default:
+		return NewTransferEntry(transaction), true
+	}
+}
+
+// NewOpeningBalanceEntry moves the balance a wallet had before the ledger
+// existed into the ledger, so wallet balances can be derived from postings.
+func NewOpeningBalanceEntry(
+	wallet *entity.Wallet,
+	datetime time.Time,
+) *entity.JournalEntry {
+	walletDirection, openingDirection := entity.Credit, entity.Debit
+	amount := wallet.Balance
+	if amount < 0 {
+		walletDirection, openingDirection = entity.Debit, entity.Credit
+		amount = -amount
+	}
+
+	return &entity.JournalEntry{
+		Description: fmt.Sprintf("Opening balance of wallet %d", wallet.Number),
+		Datetime:    datetime,
+		Postings: []entity.Posting{
+			{
+				AccountType:   entity.OpeningBalanceAccount,
+				AccountNumber: wallet.Number,
+				Direction:     openingDirection,
+				Amount:        amount,
+			},
+			{
+				AccountType:   entity.WalletAccount,
+				AccountNumber: wallet.Number,
+				Direction:     walletDirection,
+				Amount:        amount,
+			},
+		},
+	}
+}
+
+// IsBalanced reports whether the debits of the entry equal its credits and
+// every posting moves a positive amount.
+func IsBalanced(entry *entity.JournalEntry) bool {
+	if len(entry.Postings) < 2 {
+		return false
+	}
+
+	total := 0
+	for _, posting := range entry.Postings {
+		if posting.Amount <= 0 {
+			return false
+		}
+
+		switch posting.Direction {
+		case entity.Debit:
+			total += posting.Amount
+		case entity.Credit:
+			total -= posting.Amount
+		default:
+			return false
+		}
+	}
+
+	return total == 0
+}
+
+// NewAdjustmentEntry records a manual correction of a wallet balance. A
+// positive difference credits the wallet, a negative one debits it.
+func NewAdjustmentEntry(
+	walletNumber, difference int,
+	datetime time.Time,
+) *entity.JournalEntry {
+	walletDirection, adjustmentDirection := entity.Credit, entity.Debit
+	amount := difference
+	if amount < 0 {
+		walletDirection, adjustmentDirection = entity.Debit, entity.Credit
+		amount = -amount
+	}
+
+	return &entity.JournalEntry{
+		Description: fmt.Sprintf("Balance adjustment of wallet %d", walletNumber),
+		Datetime:    datetime,
+		Postings: []entity.Posting{
+			{
+				AccountType:   entity.AdjustmentAccount,
+				AccountNumber: walletNumber,
+				Direction:     adjustmentDirection,
+				Amount:        amount,
+			},
+			{
+				AccountType:   entity.WalletAccount,
+				AccountNumber: walletNumber,
+				Direction:     walletDirection,
+				Amount:        amount,
+			},
+		},
+	}
+}
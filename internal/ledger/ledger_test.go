@@ -0,0 +1,297 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBalanced(t *testing.T) {
+	sourceID := entity.SourceOfFundsID(3)
+	bankAccountID := 1
+	withdrawal := &entity.Transaction{
+		Amount:        1000,
+		From:          100001,
+		To:            100001,
+		BankAccountID: &bankAccountID,
+	}
+
+	convertedAmount := 645
+
+	tests := []struct {
+		name  string
+		entry *entity.JournalEntry
+		want  bool
+	}{
+		{
+			name: "Top up entry",
+			entry: NewTopupEntry(&entity.Transaction{
+				Amount:   50000,
+				SourceID: &sourceID,
+				To:       100001,
+			}),
+			want: true,
+		},
+		{
+			name: "Top up entry with fee",
+			entry: NewTopupEntry(&entity.Transaction{
+				Amount:   50000,
+				Fee:      2500,
+				SourceID: &sourceID,
+				To:       100001,
+			}),
+			want: true,
+		},
+		{
+			name: "Transfer entry",
+			entry: NewTransferEntry(&entity.Transaction{
+				Amount: 1000,
+				From:   100001,
+				To:     100002,
+			}),
+			want: true,
+		},
+		{
+			name: "Transfer entry with fee",
+			entry: NewTransferEntry(&entity.Transaction{
+				Amount: 1000,
+				Fee:    500,
+				From:   100001,
+				To:     100002,
+			}),
+			want: true,
+		},
+		{
+			name: "Transfer entry converted to another currency",
+			entry: NewTransferEntry(&entity.Transaction{
+				Amount:            100000,
+				Fee:               1000,
+				Currency:          entity.IDR,
+				ConvertedAmount:   &convertedAmount,
+				ConvertedCurrency: entity.USD,
+				ExchangeRate:      "0.0000645161",
+				From:              100001,
+				To:                100002,
+			}),
+			want: true,
+		},
+		{
+			name:  "Withdrawal entry",
+			entry: NewWithdrawalEntry(withdrawal),
+			want:  true,
+		},
+		{
+			name:  "Failed withdrawal entry",
+			entry: NewFailedWithdrawalEntry(withdrawal, time.Now()),
+			want:  true,
+		},
+		{
+			name: "Opening balance entry",
+			entry: NewOpeningBalanceEntry(
+				&entity.Wallet{Number: 100001, Balance: 1000},
+				time.Now(),
+			),
+			want: true,
+		},
+		{
+			name: "Negative opening balance entry",
+			entry: NewOpeningBalanceEntry(
+				&entity.Wallet{Number: 100001, Balance: -1000},
+				time.Now(),
+			),
+			want: true,
+		},
+		{
+			name:  "Adjustment entry",
+			entry: NewAdjustmentEntry(100001, -500, time.Now()),
+			want:  true,
+		},
+		{
+			name: "Single posting",
+			entry: &entity.JournalEntry{Postings: []entity.Posting{
+				{Direction: entity.Debit, Amount: 1000},
+			}},
+			want: false,
+		},
+		{
+			name: "Debits not equal to credits",
+			entry: &entity.JournalEntry{Postings: []entity.Posting{
+				{Direction: entity.Debit, Amount: 1000},
+				{Direction: entity.Credit, Amount: 999},
+			}},
+			want: false,
+		},
+		{
+			name: "Zero amount posting",
+			entry: &entity.JournalEntry{Postings: []entity.Posting{
+				{Direction: entity.Debit, Amount: 0},
+				{Direction: entity.Credit, Amount: 0},
+			}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsBalanced(tt.entry))
+		})
+	}
+}
+
+func TestNewTransactionEntry(t *testing.T) {
+	sourceID := entity.SourceOfFundsID(1)
+	bankAccountID := 1
+
+	tests := []struct {
+		name        string
+		transaction *entity.Transaction
+		wantOK      bool
+	}{
+		{
+			name: "Completed top up",
+			transaction: &entity.Transaction{
+				Amount:   50000,
+				Type:     entity.TopUp,
+				Status:   entity.TransactionCompleted,
+				SourceID: &sourceID,
+				To:       100001,
+			},
+			wantOK: true,
+		},
+		{
+			name: "Pending top up",
+			transaction: &entity.Transaction{
+				Amount:   50000,
+				Type:     entity.TopUp,
+				Status:   entity.TransactionPending,
+				SourceID: &sourceID,
+				To:       100001,
+			},
+			wantOK: false,
+		},
+		{
+			name: "Transfer",
+			transaction: &entity.Transaction{
+				Amount: 1000,
+				Type:   entity.Transfer,
+				Status: entity.TransactionCompleted,
+				From:   100001,
+				To:     100002,
+			},
+			wantOK: true,
+		},
+		{
+			name: "Withdrawal being paid out",
+			transaction: &entity.Transaction{
+				Amount:        10000,
+				Type:          entity.Withdrawal,
+				Status:        entity.TransactionProcessing,
+				From:          100001,
+				To:            100001,
+				BankAccountID: &bankAccountID,
+			},
+			wantOK: true,
+		},
+		{
+			name: "Failed withdrawal",
+			transaction: &entity.Transaction{
+				Amount:        10000,
+				Type:          entity.Withdrawal,
+				Status:        entity.TransactionFailed,
+				From:          100001,
+				To:            100001,
+				BankAccountID: &bankAccountID,
+			},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NewTransactionEntry(tt.transaction)
+
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.True(t, IsBalanced(got))
+				assert.Equal(t, &tt.transaction.ID, got.TransactionID)
+			} else {
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+// The entries of transactions made before the ledger existed are dated when
+// the transactions were made, so a statement of a period before the ledger
+// starts from the balance the wallet had then.
+func TestNewTransactionEntry_statementBeforeLedger(t *testing.T) {
+	sourceID := entity.SourceOfFundsID(1)
+	history := []*entity.Transaction{
+		{
+			Amount:   50000,
+			Type:     entity.TopUp,
+			Status:   entity.TransactionCompleted,
+			SourceID: &sourceID,
+			To:       100001,
+			Datetime: time.Date(2022, time.January, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Amount:   10000,
+			Type:     entity.Transfer,
+			Status:   entity.TransactionCompleted,
+			From:     100001,
+			To:       100002,
+			Datetime: time.Date(2022, time.February, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Amount:   20000,
+			Type:     entity.TopUp,
+			Status:   entity.TransactionFailed,
+			SourceID: &sourceID,
+			To:       100001,
+			Datetime: time.Date(2022, time.February, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Amount:   5000,
+			Type:     entity.Transfer,
+			Status:   entity.TransactionCompleted,
+			From:     100002,
+			To:       100001,
+			Datetime: time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	from := time.Date(2022, time.February, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	openingBalance := 0
+	movements := []int{}
+	for _, transaction := range history {
+		entry, ok := NewTransactionEntry(transaction)
+		if !ok {
+			continue
+		}
+
+		for _, posting := range entry.Postings {
+			if posting.AccountType != entity.WalletAccount ||
+				posting.AccountNumber != 100001 {
+				continue
+			}
+
+			amount := posting.Amount
+			if posting.Direction == entity.Debit {
+				amount = -amount
+			}
+
+			switch {
+			case entry.Datetime.Before(from):
+				openingBalance += amount
+			case entry.Datetime.Before(to):
+				movements = append(movements, amount)
+			}
+		}
+	}
+
+	assert.Equal(t, 50000, openingBalance)
+	assert.Equal(t, []int{-10000}, movements)
+}
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthorizeAdmin lets only admins through. It must run after AuthorizeJWT.
+// The role is read from the token, so a role change applies from the next
+// token of the user.
+func AuthorizeAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := c.Get("user")
+		if !ok {
+			helper.WriteErrorResponse(
+				c,
+				http.StatusInternalServerError,
+				custom_error.FailedToGetInfoFromToken{}.Error(),
+				nil,
+			)
+			return
+		}
+
+		if user.(*entity.TokenizedUser).Role != entity.AdminRole {
+			helper.WriteErrorResponse(
+				c,
+				http.StatusForbidden,
+				custom_error.AdminOnly{}.Error(),
+				nil,
+			)
+			return
+		}
+
+		c.Next()
+	}
+}
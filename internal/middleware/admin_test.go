@@ -0,0 +1,69 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"assignment-golang-backend/internal/entity"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeAdmin(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *entity.TokenizedUser
+		wantCode       int
+		wantHandlerRun bool
+	}{
+		{
+			name:     "Error | No user from the JWT middleware",
+			user:     nil,
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:     "Error | User is not an admin",
+			user:     &entity.TokenizedUser{ID: 1, Role: entity.UserRoleUser},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "Error | Token issued without a role",
+			user:     &entity.TokenizedUser{ID: 1},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:           "Success",
+			user:           &entity.TokenizedUser{ID: 1, Role: entity.AdminRole},
+			wantCode:       http.StatusOK,
+			wantHandlerRun: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlerRun := false
+			r := gin.New()
+			r.GET(
+				"/",
+				func(c *gin.Context) {
+					if tt.user != nil {
+						c.Set("user", tt.user)
+					}
+				},
+				AuthorizeAdmin(),
+				func(c *gin.Context) {
+					handlerRun = true
+					c.Status(http.StatusOK)
+				},
+			)
+
+			req, _ := http.NewRequest(http.MethodGet, "/", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantHandlerRun, handlerRun)
+		})
+	}
+}
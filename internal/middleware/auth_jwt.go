@@ -3,45 +3,72 @@ package middlewares
 import (
 	"net/http"
 
+	"assignment-golang-backend/internal/custom_error"
 	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
 
 	"github.com/gin-gonic/gin"
 )
 
-func AuthorizeJWT(c *gin.Context) {
-	authorizationHeader := c.GetHeader("Authorization")
-	tokenStr, err := helper.ParseAuthorizationHeader(authorizationHeader)
-	if err != nil {
-		helper.WriteErrorResponse(
-			c,
-			http.StatusUnauthorized,
-			err.Error(),
-			nil,
-		)
-		return
-	}
+func AuthorizeJWT(s usecase.IAuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorizationHeader := c.GetHeader("Authorization")
+		tokenStr, err := helper.ParseAuthorizationHeader(authorizationHeader)
+		if err != nil {
+			helper.WriteErrorResponse(
+				c,
+				http.StatusUnauthorized,
+				err.Error(),
+				nil,
+			)
+			return
+		}
 
-	token, err := helper.ValidateToken(tokenStr)
-	if err != nil || !token.Valid {
-		helper.WriteErrorResponse(
-			c,
-			http.StatusUnauthorized,
-			err.Error(),
-			nil,
-		)
-		return
-	}
+		token, err := helper.ValidateToken(tokenStr)
+		if err != nil || !token.Valid {
+			helper.WriteErrorResponse(
+				c,
+				http.StatusUnauthorized,
+				custom_error.InvalidToken{}.Error(),
+				nil,
+			)
+			return
+		}
+
+		claims, ok := token.Claims.(*helper.IdTokenClaims)
+		if !ok {
+			helper.WriteErrorResponse(
+				c,
+				http.StatusUnauthorized,
+				http.StatusText(http.StatusUnauthorized),
+				nil,
+			)
+			return
+		}
+
+		revoked, err := s.IsTokenRevoked(claims.ID)
+		if err != nil {
+			helper.WriteErrorResponse(
+				c,
+				http.StatusInternalServerError,
+				http.StatusText(http.StatusInternalServerError),
+				nil,
+			)
+			return
+		}
+
+		if revoked {
+			helper.WriteErrorResponse(
+				c,
+				http.StatusUnauthorized,
+				custom_error.InvalidToken{}.Error(),
+				nil,
+			)
+			return
+		}
 
-	if claims, ok := token.Claims.(*helper.IdTokenClaims); ok {
 		c.Set("user", claims.User)
+		c.Set("claims", claims)
 		c.Next()
-	} else {
-		helper.WriteErrorResponse(
-			c,
-			http.StatusUnauthorized,
-			http.StatusText(http.StatusUnauthorized),
-			nil,
-		)
-		return
 	}
 }
@@ -0,0 +1,100 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizeJWT(t *testing.T) {
+	t.Setenv("TOKEN_SECRET", "secret")
+	t.Setenv("TOKEN_EXP_MINUTE", "5")
+
+	validToken, err := helper.GenerateJWT(&entity.User{
+		Base:         entity.Base{ID: 1},
+		WalletNumber: 1,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		header         string
+		mock           func(*mocks.IAuthService)
+		wantCode       int
+		wantHandlerRun bool
+	}{
+		{
+			name:     "Error | Missing authorization header",
+			header:   "",
+			mock:     func(as *mocks.IAuthService) {},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "Error | Invalid token",
+			header:   "Bearer invalid",
+			mock:     func(as *mocks.IAuthService) {},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "Error | Revoked token",
+			header: "Bearer " + validToken,
+			mock: func(as *mocks.IAuthService) {
+				as.On("IsTokenRevoked", mock.Anything).Return(true, nil)
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "Error | Failed to check revocation",
+			header: "Bearer " + validToken,
+			mock: func(as *mocks.IAuthService) {
+				as.On("IsTokenRevoked", mock.Anything).
+					Return(false, fmt.Errorf("error"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "Success",
+			header: "Bearer " + validToken,
+			mock: func(as *mocks.IAuthService) {
+				as.On("IsTokenRevoked", mock.Anything).Return(false, nil)
+			},
+			wantCode:       http.StatusOK,
+			wantHandlerRun: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authService := mocks.NewIAuthService(t)
+			tt.mock(authService)
+
+			handlerRun := false
+			r := gin.New()
+			r.GET("/", AuthorizeJWT(authService), func(c *gin.Context) {
+				handlerRun = true
+				_, ok := c.Get("claims")
+				assert.True(t, ok)
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantHandlerRun, handlerRun)
+		})
+	}
+}
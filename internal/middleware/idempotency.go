@@ -0,0 +1,153 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IDEMPOTENCY_KEY_HEADER     = "Idempotency-Key"
+	IDEMPOTENCY_REPLAY_HEADER  = "Idempotent-Replayed"
+	IDEMPOTENCY_KEY_MAX_LENGTH = 255
+)
+
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *idempotencyResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Idempotency stores the first response for each Idempotency-Key header of a
+// user and replays it for later requests with the same key, so retried
+// requests do not move money twice. Requests without the header are passed
+// through untouched.
+func Idempotency(s usecase.IIdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IDEMPOTENCY_KEY_HEADER)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > IDEMPOTENCY_KEY_MAX_LENGTH {
+			helper.WriteErrorResponse(
+				c,
+				http.StatusBadRequest,
+				http.StatusText(http.StatusBadRequest),
+				nil,
+			)
+			return
+		}
+
+		user, ok := c.Get("user")
+		if !ok {
+			helper.WriteErrorResponse(
+				c,
+				http.StatusInternalServerError,
+				custom_error.FailedToGetInfoFromToken{}.Error(),
+				nil,
+			)
+			return
+		}
+		tokenizedUser := user.(*entity.TokenizedUser)
+
+		requestBody, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			helper.WriteErrorResponse(
+				c,
+				http.StatusBadRequest,
+				custom_error.InvalidRequestBody{}.Error(),
+				nil,
+			)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
+
+		idempotencyKey, err := s.Reserve(
+			tokenizedUser.ID,
+			key,
+			hashRequest(c.Request.Method, c.Request.URL.Path, requestBody),
+		)
+
+		if _, ok := err.(*custom_error.IdempotencyKeyReused); ok {
+			helper.WriteErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+
+		if _, ok := err.(*custom_error.IdempotentRequestInProgress); ok {
+			helper.WriteErrorResponse(c, http.StatusConflict, err.Error(), nil)
+			return
+		}
+
+		if err != nil {
+			helper.WriteErrorResponse(
+				c,
+				http.StatusInternalServerError,
+				http.StatusText(http.StatusInternalServerError),
+				nil,
+			)
+			return
+		}
+
+		if idempotencyKey.ResponseCode != 0 {
+			c.Header(IDEMPOTENCY_REPLAY_HEADER, "true")
+			c.Data(
+				idempotencyKey.ResponseCode,
+				gin.MIMEJSON+"; charset=utf-8",
+				idempotencyKey.ResponseBody,
+			)
+			c.Abort()
+			return
+		}
+
+		writer := &idempotencyResponseWriter{
+			ResponseWriter: c.Writer,
+			body:           &bytes.Buffer{},
+		}
+		c.Writer = writer
+
+		completed := false
+		defer func() {
+			// Free the key when the handler panics so the client can retry.
+			if !completed {
+				_ = s.Release(idempotencyKey)
+			}
+		}()
+
+		c.Next()
+
+		// Server errors are not stored, the request did not go through and
+		// the client should be able to retry with the same key.
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			_ = s.Release(idempotencyKey)
+		} else {
+			_ = s.Complete(idempotencyKey, c.Writer.Status(), writer.body.Bytes())
+		}
+		completed = true
+	}
+}
+
+// hashRequest hashes the actual path rather than the route, so one key
+// cannot be reused for the same route with other path parameters, such as
+// refunds of two different transactions.
+func hashRequest(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte(path))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,206 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotency(t *testing.T) {
+	mockUser := &entity.TokenizedUser{ID: 1, WalletNumber: 1}
+	mockNewKey := &entity.IdempotencyKey{UserID: 1, Key: "key"}
+	mockStoredKey := &entity.IdempotencyKey{
+		UserID:       1,
+		Key:          "key",
+		ResponseCode: http.StatusOK,
+		ResponseBody: []byte(`{"code":200}`),
+	}
+
+	tests := []struct {
+		name           string
+		key            string
+		handlerCode    int
+		mock           func(*mocks.IIdempotencyService)
+		wantCode       int
+		wantBody       string
+		wantReplayed   bool
+		wantHandlerRun bool
+	}{
+		{
+			name:           "Success | No key passes through",
+			key:            "",
+			handlerCode:    http.StatusOK,
+			mock:           func(is *mocks.IIdempotencyService) {},
+			wantCode:       http.StatusOK,
+			wantBody:       `{"code":200}`,
+			wantHandlerRun: true,
+		},
+		{
+			name:        "Error | Key reused with different body",
+			key:         "key",
+			handlerCode: http.StatusOK,
+			mock: func(is *mocks.IIdempotencyService) {
+				is.On("Reserve", 1, "key", mock.Anything).
+					Return(nil, &custom_error.IdempotencyKeyReused{})
+			},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:        "Error | Key in progress",
+			key:         "key",
+			handlerCode: http.StatusOK,
+			mock: func(is *mocks.IIdempotencyService) {
+				is.On("Reserve", 1, "key", mock.Anything).
+					Return(nil, &custom_error.IdempotentRequestInProgress{})
+			},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:        "Success | Stored response is replayed",
+			key:         "key",
+			handlerCode: http.StatusOK,
+			mock: func(is *mocks.IIdempotencyService) {
+				is.On("Reserve", 1, "key", mock.Anything).
+					Return(mockStoredKey, nil)
+			},
+			wantCode:     http.StatusOK,
+			wantBody:     `{"code":200}`,
+			wantReplayed: true,
+		},
+		{
+			name:        "Success | First response is stored",
+			key:         "key",
+			handlerCode: http.StatusOK,
+			mock: func(is *mocks.IIdempotencyService) {
+				is.On("Reserve", 1, "key", mock.Anything).
+					Return(mockNewKey, nil)
+				is.On("Complete", mockNewKey, http.StatusOK, []byte(`{"code":200}`)).
+					Return(nil)
+			},
+			wantCode:       http.StatusOK,
+			wantBody:       `{"code":200}`,
+			wantHandlerRun: true,
+		},
+		{
+			name:        "Success | Server errors release the key",
+			key:         "key",
+			handlerCode: http.StatusInternalServerError,
+			mock: func(is *mocks.IIdempotencyService) {
+				is.On("Reserve", 1, "key", mock.Anything).
+					Return(mockNewKey, nil)
+				is.On("Release", mockNewKey).Return(nil)
+			},
+			wantCode:       http.StatusInternalServerError,
+			wantBody:       `{"code":500}`,
+			wantHandlerRun: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idempotencyService := mocks.NewIIdempotencyService(t)
+			tt.mock(idempotencyService)
+
+			handlerRun := false
+			r := gin.New()
+			r.POST(
+				"/",
+				func(c *gin.Context) {
+					c.Set("user", mockUser)
+					c.Next()
+				},
+				Idempotency(idempotencyService),
+				func(c *gin.Context) {
+					handlerRun = true
+					c.JSON(tt.handlerCode, gin.H{"code": tt.handlerCode})
+				},
+			)
+
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				"/",
+				strings.NewReader(`{"amount":1000}`),
+			)
+			if tt.key != "" {
+				req.Header.Set(IDEMPOTENCY_KEY_HEADER, tt.key)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantHandlerRun, handlerRun)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+			assert.Equal(
+				t,
+				tt.wantReplayed,
+				w.Header().Get(IDEMPOTENCY_REPLAY_HEADER) == "true",
+			)
+		})
+	}
+}
+
+func TestIdempotency_PathParams(t *testing.T) {
+	mockUser := &entity.TokenizedUser{ID: 1, WalletNumber: 1}
+	mockNewKey := &entity.IdempotencyKey{UserID: 1, Key: "key"}
+	body := `{"amount":1000}`
+
+	idempotencyService := mocks.NewIIdempotencyService(t)
+	idempotencyService.On(
+		"Reserve",
+		1,
+		"key",
+		hashRequest(http.MethodPost, "/transactions/7/refund", []byte(body)),
+	).Return(mockNewKey, nil)
+	idempotencyService.On("Complete", mockNewKey, http.StatusOK, []byte(`{"code":200}`)).
+		Return(nil)
+	idempotencyService.On(
+		"Reserve",
+		1,
+		"key",
+		hashRequest(http.MethodPost, "/transactions/9/refund", []byte(body)),
+	).Return(nil, &custom_error.IdempotencyKeyReused{})
+
+	handlerRuns := 0
+	r := gin.New()
+	r.POST(
+		"/transactions/:id/refund",
+		func(c *gin.Context) {
+			c.Set("user", mockUser)
+			c.Next()
+		},
+		Idempotency(idempotencyService),
+		func(c *gin.Context) {
+			handlerRuns++
+			c.JSON(http.StatusOK, gin.H{"code": http.StatusOK})
+		},
+	)
+
+	wantCodes := map[string]int{
+		"/transactions/7/refund": http.StatusOK,
+		"/transactions/9/refund": http.StatusConflict,
+	}
+	for _, path := range []string{"/transactions/7/refund", "/transactions/9/refund"} {
+		req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set(IDEMPOTENCY_KEY_HEADER, "key")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, wantCodes[path], w.Code, path)
+	}
+	assert.Equal(t, 1, handlerRuns)
+	assert.NotEqual(
+		t,
+		hashRequest(http.MethodPost, "/transactions/7/refund", []byte(body)),
+		hashRequest(http.MethodPost, "/transactions/9/refund", []byte(body)),
+	)
+}
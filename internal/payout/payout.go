@@ -0,0 +1,50 @@
+// Package payout sends withdrawn money to bank accounts through a payout
+// provider.
+package payout
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"assignment-golang-backend/internal/entity"
+)
+
+const (
+	STUB_REJECTED_ACCOUNT_SUFFIX = "0000"
+)
+
+// IPayoutProvider pays a withdrawal out to a bank account and returns the
+// provider's reference of the payout. An error means no money was sent.
+type IPayoutProvider interface {
+	Payout(*entity.Transaction, *entity.BankAccount) (string, error)
+}
+
+// StubProvider pretends to pay out for local development. It rejects bank
+// accounts whose number ends with STUB_REJECTED_ACCOUNT_SUFFIX, so the
+// failed withdrawal path can be tried too.
+type StubProvider struct{}
+
+func NewStubProvider() IPayoutProvider {
+	return &StubProvider{}
+}
+
+func (p *StubProvider) Payout(
+	withdrawal *entity.Transaction,
+	bankAccount *entity.BankAccount,
+) (string, error) {
+	if strings.HasSuffix(bankAccount.AccountNumber, STUB_REJECTED_ACCOUNT_SUFFIX) {
+		return "", errors.New("Bank account rejected the payout")
+	}
+
+	log.Printf(
+		"Stub payout of %d to %s %s for withdrawal %d",
+		withdrawal.Amount,
+		bankAccount.BankCode,
+		bankAccount.AccountNumber,
+		withdrawal.ID,
+	)
+
+	return fmt.Sprintf("STUB-%d", withdrawal.ID), nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"assignment-golang-backend/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type IBankAccountRepository interface {
+	CreateBankAccount(*entity.BankAccount) (*entity.BankAccount, int, error)
+	FindByUserID(int) ([]*entity.BankAccount, int, error)
+	FindByIDAndUserID(int, int) (*entity.BankAccount, int, error)
+	DeleteByIDAndUserID(int, int) (int, error)
+}
+
+type bankAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewBankAccountRepository(db *gorm.DB) IBankAccountRepository {
+	return &bankAccountRepository{
+		db: db,
+	}
+}
+
+func (r *bankAccountRepository) CreateBankAccount(
+	bankAccount *entity.BankAccount,
+) (*entity.BankAccount, int, error) {
+	result := r.db.Create(&bankAccount)
+	return bankAccount, int(result.RowsAffected), result.Error
+}
+
+func (r *bankAccountRepository) FindByUserID(
+	userID int,
+) ([]*entity.BankAccount, int, error) {
+	var bankAccounts []*entity.BankAccount
+	result := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&bankAccounts)
+	return bankAccounts, int(result.RowsAffected), result.Error
+}
+
+func (r *bankAccountRepository) FindByIDAndUserID(
+	id int,
+	userID int,
+) (*entity.BankAccount, int, error) {
+	var bankAccount *entity.BankAccount
+	result := r.db.
+		Where("id = ? AND user_id = ?", id, userID).
+		Find(&bankAccount)
+	return bankAccount, int(result.RowsAffected), result.Error
+}
+
+// DeleteByIDAndUserID soft deletes the bank account, so withdrawals made to
+// it keep pointing at an existing row.
+func (r *bankAccountRepository) DeleteByIDAndUserID(
+	id int,
+	userID int,
+) (int, error) {
+	result := r.db.
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&entity.BankAccount{})
+	return int(result.RowsAffected), result.Error
+}
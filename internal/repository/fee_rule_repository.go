@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"assignment-golang-backend/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type IFeeRuleRepository interface {
+	FindMatching(
+		entity.TransactionType,
+		entity.Currency,
+		*entity.SourceOfFundsID,
+		int,
+	) (*entity.FeeRule, int, error)
+}
+
+type feeRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewFeeRuleRepository(db *gorm.DB) IFeeRuleRepository {
+	return &feeRuleRepository{
+		db: db,
+	}
+}
+
+// FindMatching returns the rule pricing a transaction of the type,
+// currency, source of funds and amount. Rules of the source win over rules
+// for any source, and of those the one with the highest minimum amount
+// wins.
+func (r *feeRuleRepository) FindMatching(
+	transactionType entity.TransactionType,
+	currency entity.Currency,
+	sourceID *entity.SourceOfFundsID,
+	amount int,
+) (*entity.FeeRule, int, error) {
+	query := r.db.
+		Where("type = ?", transactionType).
+		Where("currency = ?", currency).
+		Where("min_amount <= ?", amount).
+		Where("max_amount = 0 OR max_amount >= ?", amount)
+
+	if sourceID != nil {
+		query = query.Where("source_id = ? OR source_id IS NULL", *sourceID)
+	} else {
+		query = query.Where("source_id IS NULL")
+	}
+
+	var rule *entity.FeeRule
+	result := query.
+		Order("source_id IS NULL").
+		Order("min_amount DESC").
+		Limit(1).
+		Find(&rule)
+	return rule, int(result.RowsAffected), result.Error
+}
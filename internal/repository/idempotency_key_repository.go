@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"assignment-golang-backend/internal/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IIdempotencyKeyRepository interface {
+	CreateKey(*entity.IdempotencyKey) (*entity.IdempotencyKey, int, error)
+	FindByUserIDAndKey(int, string) (*entity.IdempotencyKey, int, error)
+	UpdateResponse(*entity.IdempotencyKey) (*entity.IdempotencyKey, int, error)
+	DeleteKey(*entity.IdempotencyKey) (int, error)
+}
+
+type idempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) IIdempotencyKeyRepository {
+	return &idempotencyKeyRepository{
+		db: db,
+	}
+}
+
+// CreateKey inserts the key unless the user already has the same key, in
+// which case no row is affected.
+func (r *idempotencyKeyRepository) CreateKey(
+	idempotencyKey *entity.IdempotencyKey,
+) (*entity.IdempotencyKey, int, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&idempotencyKey)
+	return idempotencyKey, int(result.RowsAffected), result.Error
+}
+
+func (r *idempotencyKeyRepository) FindByUserIDAndKey(
+	userID int,
+	key string,
+) (*entity.IdempotencyKey, int, error) {
+	var idempotencyKey *entity.IdempotencyKey
+	result := r.db.Where("user_id = ? AND key = ?", userID, key).
+		Find(&idempotencyKey)
+	return idempotencyKey, int(result.RowsAffected), result.Error
+}
+
+func (r *idempotencyKeyRepository) UpdateResponse(
+	idempotencyKey *entity.IdempotencyKey,
+) (*entity.IdempotencyKey, int, error) {
+	result := r.db.Model(&idempotencyKey).Updates(map[string]interface{}{
+		"response_code": idempotencyKey.ResponseCode,
+		"response_body": idempotencyKey.ResponseBody,
+	})
+	return idempotencyKey, int(result.RowsAffected), result.Error
+}
+
+func (r *idempotencyKeyRepository) DeleteKey(
+	idempotencyKey *entity.IdempotencyKey,
+) (int, error) {
+	result := r.db.Unscoped().Delete(&idempotencyKey)
+	return int(result.RowsAffected), result.Error
+}
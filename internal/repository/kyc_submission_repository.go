@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"assignment-golang-backend/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type IKycSubmissionRepository interface {
+	CreateKycSubmission(
+		*entity.KycSubmission,
+	) (*entity.KycSubmission, int, error)
+	FindByID(int) (*entity.KycSubmission, int, error)
+	FindLatestByUserID(int) (*entity.KycSubmission, int, error)
+	FindByStatus(entity.KycStatus) ([]*entity.KycSubmission, int, error)
+	UpdateReview(*entity.KycSubmission) (int, error)
+}
+
+type kycSubmissionRepository struct {
+	db *gorm.DB
+}
+
+func NewKycSubmissionRepository(db *gorm.DB) IKycSubmissionRepository {
+	return &kycSubmissionRepository{
+		db: db,
+	}
+}
+
+func (r *kycSubmissionRepository) CreateKycSubmission(
+	submission *entity.KycSubmission,
+) (*entity.KycSubmission, int, error) {
+	result := r.db.Create(&submission)
+	return submission, int(result.RowsAffected), result.Error
+}
+
+func (r *kycSubmissionRepository) FindByID(
+	id int,
+) (*entity.KycSubmission, int, error) {
+	var submission *entity.KycSubmission
+	result := r.db.Where("id = ?", id).Find(&submission)
+	return submission, int(result.RowsAffected), result.Error
+}
+
+func (r *kycSubmissionRepository) FindLatestByUserID(
+	userID int,
+) (*entity.KycSubmission, int, error) {
+	var submission *entity.KycSubmission
+	result := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&submission)
+	return submission, int(result.RowsAffected), result.Error
+}
+
+// FindByStatus returns the submissions with the status, oldest first so
+// reviewers work through them in order.
+func (r *kycSubmissionRepository) FindByStatus(
+	status entity.KycStatus,
+) ([]*entity.KycSubmission, int, error) {
+	var submissions []*entity.KycSubmission
+	result := r.db.
+		Where("status = ?", status).
+		Order("created_at ASC").
+		Find(&submissions)
+	return submissions, int(result.RowsAffected), result.Error
+}
+
+// UpdateReview stores the outcome of the review of a PENDING submission.
+// Only one reviewer can win the conditional update.
+func (r *kycSubmissionRepository) UpdateReview(
+	submission *entity.KycSubmission,
+) (int, error) {
+	result := r.db.Model(&entity.KycSubmission{}).
+		Where("id = ? AND status = ?", submission.ID, entity.KycPending).
+		Updates(map[string]interface{}{
+			"status":           submission.Status,
+			"rejection_reason": submission.RejectionReason,
+			"reviewed_by":      submission.ReviewedBy,
+			"reviewed_at":      submission.ReviewedAt,
+		})
+	return int(result.RowsAffected), result.Error
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type ILedgerRepository interface {
+	CreateJournalEntry(
+		*entity.JournalEntry,
+	) (*entity.JournalEntry, int, error)
+	SumBalanceByAccountBefore(
+		entity.LedgerAccountType,
+		int,
+		time.Time,
+	) (int, error)
+	FindStatementLinesAfter(
+		int,
+		time.Time,
+		time.Time,
+		*entity.StatementLine,
+		int,
+	) ([]*entity.StatementLine, int, error)
+}
+
+type ledgerRepository struct {
+	db *gorm.DB
+}
+
+func NewLedgerRepository(db *gorm.DB) ILedgerRepository {
+	return &ledgerRepository{
+		db: db,
+	}
+}
+
+func (r *ledgerRepository) CreateJournalEntry(
+	journalEntry *entity.JournalEntry,
+) (*entity.JournalEntry, int, error) {
+	result := r.db.Create(&journalEntry)
+	return journalEntry, int(result.RowsAffected), result.Error
+}
+
+// SumBalanceByAccountBefore returns the credits minus the debits posted to
+// the account by journal entries dated before the given time.
+func (r *ledgerRepository) SumBalanceByAccountBefore(
+	accountType entity.LedgerAccountType,
+	accountNumber int,
+	before time.Time,
+) (int, error) {
+	var balance int64
+	result := r.db.Model(&entity.Posting{}).
+		Select(
+			"COALESCE(SUM(CASE WHEN postings.direction = ? THEN postings.amount ELSE -postings.amount END), 0)",
+			entity.Credit,
+		).
+		Joins("JOIN journal_entries ON journal_entries.id = postings.journal_entry_id").
+		Where(
+			"postings.account_type = ? AND postings.account_number = ? AND journal_entries.datetime < ?",
+			accountType,
+			accountNumber,
+			before,
+		).
+		Scan(&balance)
+
+	return int(balance), result.Error
+}
+
+// FindStatementLinesAfter returns what each journal entry dated from from
+// until to moved in or out of the wallet, ordered by datetime with the ID
+// of the entry breaking ties. It starts after the line after, from the
+// first one when after is nil, and returns up to limit lines and one more
+// when there are more to read.
+func (r *ledgerRepository) FindStatementLinesAfter(
+	walletNumber int,
+	from time.Time,
+	to time.Time,
+	after *entity.StatementLine,
+	limit int,
+) ([]*entity.StatementLine, int, error) {
+	query := r.db.Model(&entity.Posting{}).
+		Select(
+			"journal_entries.id AS journal_entry_id, "+
+				"journal_entries.transaction_id, "+
+				"COALESCE(transactions.type, ?) AS type, "+
+				"journal_entries.description, "+
+				"journal_entries.datetime, "+
+				"SUM(CASE WHEN postings.direction = ? THEN postings.amount ELSE -postings.amount END) AS amount",
+			entity.StatementAdjustment,
+			entity.Credit,
+		).
+		Joins("JOIN journal_entries ON journal_entries.id = postings.journal_entry_id").
+		Joins("LEFT JOIN transactions ON transactions.id = journal_entries.transaction_id").
+		Where(
+			"postings.account_type = ? AND postings.account_number = ?",
+			entity.WalletAccount,
+			walletNumber,
+		).
+		Where(
+			"journal_entries.datetime >= ? AND journal_entries.datetime < ?",
+			from,
+			to,
+		)
+
+	if after != nil {
+		query = query.Where(
+			"(journal_entries.datetime, journal_entries.id) > (?, ?)",
+			after.Datetime,
+			after.JournalEntryID,
+		)
+	}
+
+	var lines []*entity.StatementLine
+	result := query.
+		Group("journal_entries.id, transactions.type").
+		Order("journal_entries.datetime, journal_entries.id").
+		Limit(limit + 1).
+		Scan(&lines)
+	return lines, int(result.RowsAffected), result.Error
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IPaymentRequestRepository interface {
+	CreatePaymentRequest(
+		*entity.PaymentRequest,
+	) (*entity.PaymentRequest, int, error)
+	FindByID(int) (*entity.PaymentRequest, int, error)
+	FindPendingByPayerNumber(int, time.Time) ([]*entity.PaymentRequest, int, error)
+	FindByRequesterNumber(int) ([]*entity.PaymentRequest, int, error)
+	UpdatePendingStatus(
+		int,
+		int,
+		entity.PaymentRequestStatus,
+		time.Time,
+	) (*entity.PaymentRequest, int, error)
+	UpdateTransactionID(int, int) (int, error)
+}
+
+type paymentRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRequestRepository(db *gorm.DB) IPaymentRequestRepository {
+	return &paymentRequestRepository{
+		db: db,
+	}
+}
+
+func (r *paymentRequestRepository) CreatePaymentRequest(
+	paymentRequest *entity.PaymentRequest,
+) (*entity.PaymentRequest, int, error) {
+	result := r.db.Create(&paymentRequest)
+	return paymentRequest, int(result.RowsAffected), result.Error
+}
+
+func (r *paymentRequestRepository) FindByID(
+	id int,
+) (*entity.PaymentRequest, int, error) {
+	var paymentRequest *entity.PaymentRequest
+	result := r.db.Where("id = ?", id).Find(&paymentRequest)
+	return paymentRequest, int(result.RowsAffected), result.Error
+}
+
+func (r *paymentRequestRepository) FindPendingByPayerNumber(
+	payerNumber int,
+	now time.Time,
+) ([]*entity.PaymentRequest, int, error) {
+	var paymentRequests []*entity.PaymentRequest
+	result := r.db.
+		Where(
+			"payer_number = ? AND status = ? AND expires_at > ?",
+			payerNumber,
+			entity.PaymentRequestPending,
+			now,
+		).
+		Order("created_at DESC").
+		Find(&paymentRequests)
+	return paymentRequests, int(result.RowsAffected), result.Error
+}
+
+func (r *paymentRequestRepository) FindByRequesterNumber(
+	requesterNumber int,
+) ([]*entity.PaymentRequest, int, error) {
+	var paymentRequests []*entity.PaymentRequest
+	result := r.db.
+		Where("requester_number = ?", requesterNumber).
+		Order("created_at DESC").
+		Find(&paymentRequests)
+	return paymentRequests, int(result.RowsAffected), result.Error
+}
+
+// UpdatePendingStatus moves a request of the payer out of PENDING. Only one
+// caller can win the conditional update, so a request is never approved
+// twice or approved after it was declined or expired.
+func (r *paymentRequestRepository) UpdatePendingStatus(
+	id int,
+	payerNumber int,
+	status entity.PaymentRequestStatus,
+	now time.Time,
+) (*entity.PaymentRequest, int, error) {
+	var paymentRequest *entity.PaymentRequest
+	result := r.db.Model(&paymentRequest).
+		Clauses(clause.Returning{}).
+		Where(
+			"id = ? AND payer_number = ? AND status = ? AND expires_at > ?",
+			id,
+			payerNumber,
+			entity.PaymentRequestPending,
+			now,
+		).
+		Update("status", status)
+	return paymentRequest, int(result.RowsAffected), result.Error
+}
+
+func (r *paymentRequestRepository) UpdateTransactionID(
+	id int,
+	transactionID int,
+) (int, error) {
+	result := r.db.Model(&entity.PaymentRequest{}).
+		Where("id = ?", id).
+		Update("transaction_id", transactionID)
+	return int(result.RowsAffected), result.Error
+}
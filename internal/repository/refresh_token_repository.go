@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type IRefreshTokenRepository interface {
+	CreateRefreshToken(*entity.RefreshToken) (*entity.RefreshToken, int, error)
+	FindByTokenHash(string) (*entity.RefreshToken, int, error)
+	RevokeByID(int) (int, error)
+	RevokeAllByUserID(int) (int, error)
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) IRefreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
+
+func (r *refreshTokenRepository) CreateRefreshToken(
+	refreshToken *entity.RefreshToken,
+) (*entity.RefreshToken, int, error) {
+	result := r.db.Create(&refreshToken)
+	return refreshToken, int(result.RowsAffected), result.Error
+}
+
+func (r *refreshTokenRepository) FindByTokenHash(
+	tokenHash string,
+) (*entity.RefreshToken, int, error) {
+	var refreshToken *entity.RefreshToken
+	result := r.db.Where("token_hash = ?", tokenHash).Find(&refreshToken)
+	return refreshToken, int(result.RowsAffected), result.Error
+}
+
+// RevokeByID revokes the token only if it is not revoked yet, so two
+// concurrent refreshes with the same token cannot both succeed.
+func (r *refreshTokenRepository) RevokeByID(id int) (int, error) {
+	result := r.db.Model(&entity.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *refreshTokenRepository) RevokeAllByUserID(userID int) (int, error) {
+	result := r.db.Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now())
+	return int(result.RowsAffected), result.Error
+}
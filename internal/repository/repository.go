@@ -3,15 +3,39 @@ package repository
 import "gorm.io/gorm"
 
 type Repositories struct {
-	Users        IUserRepository
-	Wallets      IWalletRepository
-	Transactions ITransactionRepository
+	Users           IUserRepository
+	Wallets         IWalletRepository
+	Transactions    ITransactionRepository
+	IdempotencyKeys IIdempotencyKeyRepository
+	Ledger          ILedgerRepository
+	RefreshTokens   IRefreshTokenRepository
+	RevokedTokens   IRevokedTokenRepository
+	PaymentRequests IPaymentRequestRepository
+	Schedules       IScheduledTransferRepository
+	BankAccounts    IBankAccountRepository
+	SourcesOfFunds  ISourceOfFundsRepository
+	Limits          ITransactionLimitRepository
+	KycSubmissions  IKycSubmissionRepository
+	FeeRules        IFeeRuleRepository
+	UnitOfWork      IUnitOfWork
 }
 
 func New(db *gorm.DB) *Repositories {
 	return &Repositories{
-		Users:        NewUserRepository(db),
-		Wallets:      NewWalletRepository(db),
-		Transactions: NewTransactionRepository(db),
+		Users:           NewUserRepository(db),
+		Wallets:         NewWalletRepository(db),
+		Transactions:    NewTransactionRepository(db),
+		IdempotencyKeys: NewIdempotencyKeyRepository(db),
+		Ledger:          NewLedgerRepository(db),
+		RefreshTokens:   NewRefreshTokenRepository(db),
+		RevokedTokens:   NewRevokedTokenRepository(db),
+		PaymentRequests: NewPaymentRequestRepository(db),
+		Schedules:       NewScheduledTransferRepository(db),
+		BankAccounts:    NewBankAccountRepository(db),
+		SourcesOfFunds:  NewSourceOfFundsRepository(db),
+		Limits:          NewTransactionLimitRepository(db),
+		KycSubmissions:  NewKycSubmissionRepository(db),
+		FeeRules:        NewFeeRuleRepository(db),
+		UnitOfWork:      NewUnitOfWork(db),
 	}
 }
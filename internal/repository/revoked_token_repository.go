@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"assignment-golang-backend/internal/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRevokedTokenRepository interface {
+	CreateRevokedToken(*entity.RevokedToken) (*entity.RevokedToken, int, error)
+	FindByJTI(string) (*entity.RevokedToken, int, error)
+}
+
+type revokedTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRevokedTokenRepository(db *gorm.DB) IRevokedTokenRepository {
+	return &revokedTokenRepository{
+		db: db,
+	}
+}
+
+func (r *revokedTokenRepository) CreateRevokedToken(
+	revokedToken *entity.RevokedToken,
+) (*entity.RevokedToken, int, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&revokedToken)
+	return revokedToken, int(result.RowsAffected), result.Error
+}
+
+func (r *revokedTokenRepository) FindByJTI(
+	jti string,
+) (*entity.RevokedToken, int, error) {
+	var revokedToken *entity.RevokedToken
+	result := r.db.Where("jti = ?", jti).Find(&revokedToken)
+	return revokedToken, int(result.RowsAffected), result.Error
+}
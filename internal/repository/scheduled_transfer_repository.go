@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type IScheduledTransferRepository interface {
+	CreateScheduledTransfer(
+		*entity.ScheduledTransfer,
+	) (*entity.ScheduledTransfer, int, error)
+	FindByUserID(int) ([]*entity.ScheduledTransfer, int, error)
+	FindByIDAndUserID(int, int) (*entity.ScheduledTransfer, int, error)
+	UpdateActiveScheduledTransfer(*entity.ScheduledTransfer) (int, error)
+	CancelScheduledTransfer(int, int) (int, error)
+	FindDue(time.Time, int) ([]*entity.ScheduledTransfer, int, error)
+	ClaimRun(*entity.ScheduledTransfer, *time.Time, int) (int, error)
+	CreateRun(*entity.ScheduledTransferRun) (*entity.ScheduledTransferRun, int, error)
+}
+
+type scheduledTransferRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduledTransferRepository(db *gorm.DB) IScheduledTransferRepository {
+	return &scheduledTransferRepository{
+		db: db,
+	}
+}
+
+func (r *scheduledTransferRepository) CreateScheduledTransfer(
+	scheduledTransfer *entity.ScheduledTransfer,
+) (*entity.ScheduledTransfer, int, error) {
+	result := r.db.Create(&scheduledTransfer)
+	return scheduledTransfer, int(result.RowsAffected), result.Error
+}
+
+func (r *scheduledTransferRepository) FindByUserID(
+	userID int,
+) ([]*entity.ScheduledTransfer, int, error) {
+	var scheduledTransfers []*entity.ScheduledTransfer
+	result := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&scheduledTransfers)
+	return scheduledTransfers, int(result.RowsAffected), result.Error
+}
+
+func (r *scheduledTransferRepository) FindByIDAndUserID(
+	id int,
+	userID int,
+) (*entity.ScheduledTransfer, int, error) {
+	var scheduledTransfer *entity.ScheduledTransfer
+	result := r.db.
+		Preload("Runs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("scheduled_at DESC")
+		}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Find(&scheduledTransfer)
+	return scheduledTransfer, int(result.RowsAffected), result.Error
+}
+
+// UpdateActiveScheduledTransfer replaces the editable fields of a schedule
+// that is still active.
+func (r *scheduledTransferRepository) UpdateActiveScheduledTransfer(
+	scheduledTransfer *entity.ScheduledTransfer,
+) (int, error) {
+	result := r.db.Model(&entity.ScheduledTransfer{}).
+		Where(
+			"id = ? AND user_id = ? AND status = ?",
+			scheduledTransfer.ID,
+			scheduledTransfer.UserID,
+			entity.ScheduledTransferActive,
+		).
+		Select(
+			"to_number",
+			"amount",
+			"description",
+			"recurrence",
+			"start_at",
+			"next_run_at",
+			"occurrence",
+		).
+		Updates(scheduledTransfer)
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *scheduledTransferRepository) CancelScheduledTransfer(
+	id int,
+	userID int,
+) (int, error) {
+	result := r.db.Model(&entity.ScheduledTransfer{}).
+		Where(
+			"id = ? AND user_id = ? AND status = ?",
+			id,
+			userID,
+			entity.ScheduledTransferActive,
+		).
+		Updates(map[string]interface{}{
+			"status":      entity.ScheduledTransferCancelled,
+			"next_run_at": nil,
+		})
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *scheduledTransferRepository) FindDue(
+	now time.Time,
+	limit int,
+) ([]*entity.ScheduledTransfer, int, error) {
+	var scheduledTransfers []*entity.ScheduledTransfer
+	result := r.db.
+		Where(
+			"status = ? AND next_run_at <= ?",
+			entity.ScheduledTransferActive,
+			now,
+		).
+		Order("next_run_at").
+		Limit(limit).
+		Find(&scheduledTransfers)
+	return scheduledTransfers, int(result.RowsAffected), result.Error
+}
+
+// ClaimRun moves a due schedule to its next occurrence, or completes it when
+// nextRunAt is nil. The update only matches while next_run_at still holds
+// the due time that was read, so when several workers pick the same
+// schedule only one of them runs it.
+func (r *scheduledTransferRepository) ClaimRun(
+	scheduledTransfer *entity.ScheduledTransfer,
+	nextRunAt *time.Time,
+	occurrence int,
+) (int, error) {
+	status := entity.ScheduledTransferActive
+	if nextRunAt == nil {
+		status = entity.ScheduledTransferCompleted
+	}
+
+	result := r.db.Model(&entity.ScheduledTransfer{}).
+		Where(
+			"id = ? AND status = ? AND next_run_at = ?",
+			scheduledTransfer.ID,
+			entity.ScheduledTransferActive,
+			scheduledTransfer.NextRunAt,
+		).
+		Updates(map[string]interface{}{
+			"next_run_at": nextRunAt,
+			"occurrence":  occurrence,
+			"status":      status,
+		})
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *scheduledTransferRepository) CreateRun(
+	run *entity.ScheduledTransferRun,
+) (*entity.ScheduledTransferRun, int, error) {
+	result := r.db.Create(&run)
+	return run, int(result.RowsAffected), result.Error
+}
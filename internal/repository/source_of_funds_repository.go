@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"assignment-golang-backend/internal/entity"
+
+	"gorm.io/gorm"
+)
+
+type ISourceOfFundsRepository interface {
+	FindEnabled() ([]*entity.SourceOfFunds, int, error)
+	FindByID(entity.SourceOfFundsID) (*entity.SourceOfFunds, int, error)
+}
+
+type sourceOfFundsRepository struct {
+	db *gorm.DB
+}
+
+func NewSourceOfFundsRepository(db *gorm.DB) ISourceOfFundsRepository {
+	return &sourceOfFundsRepository{
+		db: db,
+	}
+}
+
+func (r *sourceOfFundsRepository) FindEnabled() (
+	[]*entity.SourceOfFunds,
+	int,
+	error,
+) {
+	var sources []*entity.SourceOfFunds
+	result := r.db.
+		Where("enabled = ?", true).
+		Order("id").
+		Find(&sources)
+	return sources, int(result.RowsAffected), result.Error
+}
+
+func (r *sourceOfFundsRepository) FindByID(
+	id entity.SourceOfFundsID,
+) (*entity.SourceOfFunds, int, error) {
+	var source *entity.SourceOfFunds
+	result := r.db.Where("id = ?", id).Find(&source)
+	return source, int(result.RowsAffected), result.Error
+}
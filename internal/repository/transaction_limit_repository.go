@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ITransactionLimitRepository interface {
+	FindByWalletNumber(int) (*entity.TransactionLimit, int, error)
+	LockOwnerOfWallet(int) (int, error)
+	SumOutgoingSince(int, time.Time) (int, error)
+}
+
+type transactionLimitRepository struct {
+	db *gorm.DB
+}
+
+func NewTransactionLimitRepository(db *gorm.DB) ITransactionLimitRepository {
+	return &transactionLimitRepository{
+		db: db,
+	}
+}
+
+// FindByWalletNumber returns the limits of the tier of the wallet's user
+// in the currency of the wallet.
+func (r *transactionLimitRepository) FindByWalletNumber(
+	walletNumber int,
+) (*entity.TransactionLimit, int, error) {
+	var limit *entity.TransactionLimit
+	result := r.db.
+		Joins("JOIN wallets ON wallets.currency = transaction_limits.currency").
+		Joins("JOIN users ON users.id = wallets.user_id").
+		Where("users.tier = transaction_limits.tier").
+		Where("wallets.number = ? AND users.deleted_at IS NULL", walletNumber).
+		Find(&limit)
+	return limit, int(result.RowsAffected), result.Error
+}
+
+// LockOwnerOfWallet locks the row of the user of the wallet until the end
+// of the transaction. The outgoing limits are shared by all the wallets of
+// the user, so this lock, not the wallet's, serializes the payments summed
+// against them.
+func (r *transactionLimitRepository) LockOwnerOfWallet(
+	walletNumber int,
+) (int, error) {
+	var userIDs []int
+	result := r.db.Model(&entity.User{}).
+		Select("users.id").
+		Joins("JOIN wallets ON wallets.user_id = users.id").
+		Where("wallets.number = ?", walletNumber).
+		Clauses(clause.Locking{
+			Strength: "UPDATE",
+			Table:    clause.Table{Name: "users"},
+		}).
+		Scan(&userIDs)
+	return int(result.RowsAffected), result.Error
+}
+
+// SumOutgoingSince totals what the user of the wallet sent since the given
+// time from all their wallets of its currency: the transfers to other
+// users and the withdrawals that did not fail. Moves between their own
+// wallets are not counted.
+func (r *transactionLimitRepository) SumOutgoingSince(
+	walletNumber int,
+	since time.Time,
+) (int, error) {
+	var total int64
+	err := r.db.Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(transactions.amount), 0)").
+		Joins("JOIN wallets AS senders ON senders.number = transactions.from_number").
+		Joins("JOIN wallets ON wallets.user_id = senders.user_id AND wallets.currency = senders.currency").
+		Joins("LEFT JOIN wallets AS receivers ON receivers.number = transactions.to_number").
+		Where("wallets.number = ?", walletNumber).
+		Where("transactions.datetime >= ?", since).
+		Where(
+			"(transactions.type = ? AND receivers.user_id IS DISTINCT FROM senders.user_id) OR "+
+				"(transactions.type = ? AND transactions.status <> ?)",
+			entity.Transfer,
+			entity.Withdrawal,
+			entity.TransactionFailed,
+		).
+		Scan(&total).Error
+
+	return int(total), err
+}
@@ -6,6 +6,7 @@ import (
 	"assignment-golang-backend/internal/entity"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ITransactionRepository interface {
@@ -14,9 +15,26 @@ type ITransactionRepository interface {
 	) (*entity.Transaction, int, error)
 	FindByWalletNumberWithQuery(
 		int,
+		*entity.TransactionFilter,
 		*entity.Pagination,
 	) ([]*entity.Transaction, int, error)
-	CountTransactionByWalletNumber(int, string) int
+	CountTransactionByWalletNumber(int, *entity.TransactionFilter) int
+	FindByWalletNumberAfterCursor(
+		int,
+		*entity.TransactionFilter,
+		*entity.CursorPagination,
+	) ([]*entity.Transaction, int, error)
+	FindByID(int) (*entity.Transaction, int, error)
+	FindByIDWithWallets(int) (*entity.Transaction, int, error)
+	FindByProviderReference(
+		entity.TransactionType,
+		string,
+	) (*entity.Transaction, int, error)
+	AddRefundedAmount(int, int) (*entity.Transaction, int, error)
+	UpdateStatus(
+		*entity.Transaction,
+		entity.TransactionStatus,
+	) (*entity.Transaction, int, error)
 }
 
 type transactionRepository struct {
@@ -38,12 +56,13 @@ func (r *transactionRepository) CreateTransaction(
 
 func (r *transactionRepository) FindByWalletNumberWithQuery(
 	walletNumber int,
+	filter *entity.TransactionFilter,
 	pagination *entity.Pagination,
 ) ([]*entity.Transaction, int, error) {
 	var transactions []*entity.Transaction
-	result := r.db.Where("transactions.from_number = ? OR transactions.to_number = ?", walletNumber, walletNumber).
-		Where("description ILIKE ?", "%"+pagination.Search+"%").
-		Order(fmt.Sprintf("transactions.%s %s", pagination.SortBy, pagination.Sort)).
+	result := r.db.Preload("SourceOfFunds").
+		Scopes(walletTransactions(walletNumber, filter)).
+		Clauses(orderBy("transactions", pagination.Sort)).
 		Offset((pagination.Page - 1) * pagination.Limit).
 		Limit(pagination.Limit).
 		Find(&transactions)
@@ -52,13 +71,210 @@ func (r *transactionRepository) FindByWalletNumberWithQuery(
 
 func (r *transactionRepository) CountTransactionByWalletNumber(
 	walletNumber int,
-	descriptionQuery string,
+	filter *entity.TransactionFilter,
 ) int {
 	var totalRows int64
 	r.db.Model(&entity.Transaction{}).
-		Where("transactions.from_number = ? OR transactions.to_number = ?", walletNumber, walletNumber).
-		Where("description ILIKE ?", "%"+descriptionQuery+"%").
+		Scopes(walletTransactions(walletNumber, filter)).
 		Count(&totalRows)
 
 	return int(totalRows)
 }
+
+// FindByWalletNumberAfterCursor returns the transactions of the wallet that
+// follow pagination.After, seeking on the datetime and ID instead of
+// skipping rows. It returns one transaction more than the limit when
+// another page follows.
+func (r *transactionRepository) FindByWalletNumberAfterCursor(
+	walletNumber int,
+	filter *entity.TransactionFilter,
+	pagination *entity.CursorPagination,
+) ([]*entity.Transaction, int, error) {
+	comparison := "<"
+	if pagination.Sort == "asc" {
+		comparison = ">"
+	}
+
+	query := r.db.Preload("SourceOfFunds").
+		Scopes(walletTransactions(walletNumber, filter))
+
+	if pagination.After != nil {
+		query = query.Where(
+			fmt.Sprintf("(transactions.datetime, transactions.id) %s (?, ?)", comparison),
+			pagination.After.Datetime,
+			pagination.After.ID,
+		)
+	}
+
+	var transactions []*entity.Transaction
+	result := query.
+		Order(fmt.Sprintf("transactions.datetime %s, transactions.id %s", pagination.Sort, pagination.Sort)).
+		Limit(pagination.Limit + 1).
+		Find(&transactions)
+	return transactions, int(result.RowsAffected), result.Error
+}
+
+// orderBy orders the rows of table by sort, with the ID breaking any ties
+// so that pages never overlap. The columns are quoted by gorm, so a field
+// that slipped past validation cannot change the query.
+func orderBy(table string, sort entity.Sort) clause.OrderBy {
+	columns := make([]clause.OrderByColumn, 0, len(sort)+1)
+	for _, field := range sort {
+		columns = append(columns, clause.OrderByColumn{
+			Column: clause.Column{Table: table, Name: field.Field},
+			Desc:   field.Descending,
+		})
+	}
+
+	if !sort.Has("id") {
+		columns = append(columns, clause.OrderByColumn{
+			Column: clause.Column{Table: table, Name: "id"},
+			Desc:   true,
+		})
+	}
+
+	return clause.OrderBy{Columns: columns}
+}
+
+// walletTransactions selects the transactions of the wallet that match
+// filter. Pages and their total count share it, so they always agree.
+func walletTransactions(
+	walletNumber int,
+	filter *entity.TransactionFilter,
+) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch filter.Direction {
+		case entity.Incoming:
+			db = db.Where(
+				"transactions.to_number = ? AND transactions.type <> ?",
+				walletNumber,
+				entity.Withdrawal,
+			)
+		case entity.Outgoing:
+			db = db.Where(
+				"transactions.from_number = ? AND transactions.type <> ?",
+				walletNumber,
+				entity.TopUp,
+			)
+		default:
+			db = db.Where(
+				"transactions.from_number = ? OR transactions.to_number = ?",
+				walletNumber,
+				walletNumber,
+			)
+		}
+
+		if filter.Search != "" {
+			db = db.Where("transactions.description ILIKE ?", "%"+filter.Search+"%")
+		}
+
+		if filter.From != nil {
+			db = db.Where("transactions.datetime >= ?", *filter.From)
+		}
+
+		if filter.To != nil {
+			db = db.Where("transactions.datetime < ?", *filter.To)
+		}
+
+		if filter.Type != "" {
+			db = db.Where("transactions.type = ?", filter.Type)
+		}
+
+		if filter.MinAmount != nil {
+			db = db.Where("transactions.amount >= ?", *filter.MinAmount)
+		}
+
+		if filter.MaxAmount != nil {
+			db = db.Where("transactions.amount <= ?", *filter.MaxAmount)
+		}
+
+		if filter.Counterparty != 0 {
+			db = db.Where(
+				"(transactions.from_number = ? AND transactions.to_number = ?) OR "+
+					"(transactions.from_number = ? AND transactions.to_number = ?)",
+				walletNumber,
+				filter.Counterparty,
+				filter.Counterparty,
+				walletNumber,
+			)
+		}
+
+		return db
+	}
+}
+
+func (r *transactionRepository) FindByID(
+	id int,
+) (*entity.Transaction, int, error) {
+	var transaction *entity.Transaction
+	result := r.db.Where("id = ?", id).First(&transaction)
+	return transaction, int(result.RowsAffected), result.Error
+}
+
+// FindByIDWithWallets returns the transaction with its wallets and source
+// of funds.
+func (r *transactionRepository) FindByIDWithWallets(
+	id int,
+) (*entity.Transaction, int, error) {
+	var transaction *entity.Transaction
+	result := r.db.Preload("SourceOfFunds").
+		Preload("FromWallet").
+		Preload("ToWallet").
+		Where("id = ?", id).
+		First(&transaction)
+	return transaction, int(result.RowsAffected), result.Error
+}
+
+func (r *transactionRepository) FindByProviderReference(
+	transactionType entity.TransactionType,
+	reference string,
+) (*entity.Transaction, int, error) {
+	var transaction *entity.Transaction
+	result := r.db.Preload("SourceOfFunds").Where(
+		"type = ? AND provider_reference = ?",
+		transactionType,
+		reference,
+	).First(&transaction)
+	return transaction, int(result.RowsAffected), result.Error
+}
+
+// AddRefundedAmount adds amount to what was refunded of a transfer in a
+// single conditional UPDATE, so concurrent refunds can never return more than
+// the transfer moved. The amount is in the currency of the wallet that
+// received the transfer. No row is affected when amount exceeds what is
+// left.
+func (r *transactionRepository) AddRefundedAmount(
+	id, amount int,
+) (*entity.Transaction, int, error) {
+	var transaction entity.Transaction
+	result := r.db.Model(&transaction).
+		Clauses(clause.Returning{}).
+		Where(
+			"id = ? AND type = ? AND "+
+				"refunded_amount + ? <= COALESCE(converted_amount, amount)",
+			id,
+			entity.Transfer,
+			amount,
+		).
+		Update("refunded_amount", gorm.Expr("refunded_amount + ?", amount))
+
+	return &transaction, int(result.RowsAffected), result.Error
+}
+
+// UpdateStatus writes the status, provider reference, failure reason and
+// balance after of the receiving wallet of the transaction only while it
+// still has the from status, so only one caller can move a transaction out
+// of a status.
+func (r *transactionRepository) UpdateStatus(
+	transaction *entity.Transaction,
+	from entity.TransactionStatus,
+) (*entity.Transaction, int, error) {
+	var updated entity.Transaction
+	result := r.db.Model(&updated).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND status = ?", transaction.ID, from).
+		Select("status", "provider_reference", "failure_reason", "to_balance_after").
+		Updates(transaction)
+
+	return &updated, int(result.RowsAffected), result.Error
+}
@@ -0,0 +1,26 @@
+package repository
+
+import "gorm.io/gorm"
+
+type IUnitOfWork interface {
+	WithinTransaction(func(*Repositories) error) error
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) IUnitOfWork {
+	return &unitOfWork{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn with repositories bound to a single database
+// transaction. The transaction is committed when fn returns nil and rolled
+// back when fn returns an error or panics.
+func (u *unitOfWork) WithinTransaction(fn func(*Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(New(tx))
+	})
+}
@@ -1,15 +1,24 @@
 package repository
 
 import (
+	"time"
+
 	"assignment-golang-backend/internal/entity"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IUserRepository interface {
 	CreateUser(*entity.User) (*entity.User, int, error)
 	FindByID(int) (*entity.User, int, error)
+	FindByIDForUpdate(int) (*entity.User, int, error)
 	FindByEmail(string) (*entity.User, int, error)
+	UpdatePin(int, string) (int, error)
+	IncrementPinFailedAttempts(int, int, time.Time) (*entity.User, int, error)
+	ResetPinFailedAttempts(int) (int, error)
+	UpdateKycStatus(int, entity.KycStatus, ...entity.KycStatus) (int, error)
+	UpdateTier(int, entity.UserTier) (int, error)
 }
 
 type userRepository struct {
@@ -35,8 +44,83 @@ func (r *userRepository) FindByID(id int) (*entity.User, int, error) {
 	return user, int(result.RowsAffected), result.Error
 }
 
+// FindByIDForUpdate returns the user and locks its row until the end of
+// the transaction, so the PIN attempts of a user are checked and counted
+// one at a time.
+func (r *userRepository) FindByIDForUpdate(id int) (*entity.User, int, error) {
+	var user *entity.User
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		Find(&user)
+	return user, int(result.RowsAffected), result.Error
+}
+
 func (r *userRepository) FindByEmail(email string) (*entity.User, int, error) {
 	var user *entity.User
 	result := r.db.Where("email = ?", email).Find(&user)
 	return user, int(result.RowsAffected), result.Error
 }
+
+// UpdatePin stores the hashed PIN and clears any failed attempts and lockout.
+func (r *userRepository) UpdatePin(id int, pin string) (int, error) {
+	result := r.db.Model(&entity.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"pin":                 pin,
+			"pin_failed_attempts": 0,
+			"pin_locked_until":    nil,
+		})
+	return int(result.RowsAffected), result.Error
+}
+
+// IncrementPinFailedAttempts counts a failed attempt in a single UPDATE so
+// concurrent attempts cannot be lost. The attempt that reaches maxAttempts,
+// and every failed attempt after it, locks the PIN until lockedUntil. The
+// count is only reset by the right PIN or a new PIN.
+func (r *userRepository) IncrementPinFailedAttempts(
+	id int,
+	maxAttempts int,
+	lockedUntil time.Time,
+) (*entity.User, int, error) {
+	var user *entity.User
+	result := r.db.Model(&user).
+		Clauses(clause.Returning{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"pin_failed_attempts": gorm.Expr("pin_failed_attempts + 1"),
+			"pin_locked_until": gorm.Expr(
+				"CASE WHEN pin_failed_attempts + 1 >= ? THEN ?::timestamptz ELSE pin_locked_until END",
+				maxAttempts,
+				lockedUntil,
+			),
+		})
+	return user, int(result.RowsAffected), result.Error
+}
+
+func (r *userRepository) ResetPinFailedAttempts(id int) (int, error) {
+	result := r.db.Model(&entity.User{}).
+		Where("id = ? AND pin_failed_attempts > 0", id).
+		Update("pin_failed_attempts", 0)
+	return int(result.RowsAffected), result.Error
+}
+
+// UpdateKycStatus moves the KYC status of a user to status, but only from
+// one of the given statuses, so concurrent submissions and reviews cannot
+// both win.
+func (r *userRepository) UpdateKycStatus(
+	id int,
+	status entity.KycStatus,
+	from ...entity.KycStatus,
+) (int, error) {
+	result := r.db.Model(&entity.User{}).
+		Where("id = ? AND kyc_status IN ?", id, from).
+		Update("kyc_status", status)
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *userRepository) UpdateTier(id int, tier entity.UserTier) (int, error) {
+	result := r.db.Model(&entity.User{}).
+		Where("id = ?", id).
+		Update("tier", tier)
+	return int(result.RowsAffected), result.Error
+}
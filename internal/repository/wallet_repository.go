@@ -4,6 +4,7 @@ import (
 	"assignment-golang-backend/internal/entity"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
@@ -13,12 +14,18 @@ const (
 type IWalletRepository interface {
 	CreateWallet(*entity.Wallet) (*entity.Wallet, int, error)
 	FindByNumber(int) (*entity.Wallet, int, error)
+	FindByUserID(int) ([]*entity.Wallet, int, error)
+	FindByNumberAndUserID(int, int) (*entity.Wallet, int, error)
+	UpdateUserID(int, int) (int, error)
+	UpdateStatus(*entity.Wallet, entity.WalletStatus) (*entity.Wallet, int, error)
 	IncrementBalanceByValue(
 		int, int,
 	) (*entity.Wallet, int, error)
 	DecrementBalanceByValue(
 		int, int,
 	) (*entity.Wallet, int, error)
+	UpdateBalance(int, int, int) (*entity.Wallet, int, error)
+	FindAllWithTransactionBalance() ([]*entity.BalanceReconciliation, int, error)
 }
 
 type walletRepository struct {
@@ -52,24 +59,156 @@ func (r *walletRepository) FindByNumber(
 	return wallet, int(result.RowsAffected), result.Error
 }
 
+func (r *walletRepository) FindByUserID(
+	userID int,
+) ([]*entity.Wallet, int, error) {
+	var wallets []*entity.Wallet
+	result := r.db.Where("user_id = ?", userID).Order("number").Find(&wallets)
+	return wallets, int(result.RowsAffected), result.Error
+}
+
+func (r *walletRepository) FindByNumberAndUserID(
+	number, userID int,
+) (*entity.Wallet, int, error) {
+	var wallet *entity.Wallet
+	result := r.db.
+		Where("number = ? AND user_id = ?", number, userID).
+		Find(&wallet)
+	return wallet, int(result.RowsAffected), result.Error
+}
+
+// UpdateUserID gives a wallet without a user to the user.
+func (r *walletRepository) UpdateUserID(number, userID int) (int, error) {
+	result := r.db.Model(&entity.Wallet{}).
+		Where("number = ? AND user_id IS NULL", number).
+		Update("user_id", userID)
+	return int(result.RowsAffected), result.Error
+}
+
+// UpdateStatus sets the status of the wallet, with its reason and who and
+// when changed it, only while it still has the status from. A wallet is
+// only closed while its balance is zero and none of its withdrawals is
+// still being paid out, since a failed payout returns its amount to the
+// wallet.
+func (r *walletRepository) UpdateStatus(
+	wallet *entity.Wallet,
+	from entity.WalletStatus,
+) (*entity.Wallet, int, error) {
+	var updated entity.Wallet
+	query := r.db.Model(&updated).
+		Clauses(clause.Returning{}).
+		Where("number = ? AND status = ?", wallet.Number, from)
+
+	if wallet.Status == entity.WalletClosed {
+		inFlight := r.db.Model(&entity.Transaction{}).
+			Select("1").
+			Where("transactions.from_number = wallets.number").
+			Where("transactions.type = ?", entity.Withdrawal).
+			Where(
+				"transactions.status IN ?",
+				[]entity.TransactionStatus{
+					entity.TransactionPending,
+					entity.TransactionProcessing,
+				},
+			)
+		query = query.Where("balance = 0 AND NOT EXISTS (?)", inFlight)
+	}
+
+	result := query.
+		Select("status", "status_reason", "status_changed_by", "status_changed_at").
+		Updates(wallet)
+
+	return &updated, int(result.RowsAffected), result.Error
+}
+
 func (r *walletRepository) IncrementBalanceByValue(
 	id, value int,
 ) (*entity.Wallet, int, error) {
 	var wallet entity.Wallet
-	r.db.Where("number = ?", id).First(&wallet)
-
-	result := r.db.Model(&wallet).Update("balance", wallet.Balance+value)
+	result := r.db.Model(&wallet).
+		Clauses(clause.Returning{}).
+		Where("number = ?", id).
+		Update("balance", gorm.Expr("balance + ?", value))
 
 	return &wallet, int(result.RowsAffected), result.Error
 }
 
+// DecrementBalanceByValue subtracts value from the wallet balance in a single
+// conditional UPDATE, so concurrent callers cannot overdraw the wallet. No row
+// is affected when the balance is lower than value.
 func (r *walletRepository) DecrementBalanceByValue(
 	id, value int,
 ) (*entity.Wallet, int, error) {
 	var wallet entity.Wallet
-	r.db.Where("number = ?", id).First(&wallet)
+	result := r.db.Model(&wallet).
+		Clauses(clause.Returning{}).
+		Where("number = ? AND balance >= ?", id, value).
+		Update("balance", gorm.Expr("balance - ?", value))
 
-	result := r.db.Model(&wallet).Update("balance", wallet.Balance-value)
+	return &wallet, int(result.RowsAffected), result.Error
+}
+
+// UpdateBalance overwrites the balance of the wallet only while it still
+// holds the expected balance, so a concurrent transfer is never clobbered.
+func (r *walletRepository) UpdateBalance(
+	number, expectedBalance, balance int,
+) (*entity.Wallet, int, error) {
+	var wallet entity.Wallet
+	result := r.db.Model(&wallet).
+		Clauses(clause.Returning{}).
+		Where("number = ? AND balance = ?", number, expectedBalance).
+		Update("balance", balance)
 
 	return &wallet, int(result.RowsAffected), result.Error
 }
+
+// FindAllWithTransactionBalance recomputes the balance of every wallet from
+// the transactions table: everything received, in the wallet's currency,
+// minus every outgoing transfer, refund and withdrawal with its fee. Top ups
+// are only received once COMPLETED. Withdrawals are recorded from and to the
+// same wallet, so they only count as outgoing, and not at all once they
+// failed. The house wallet of a currency also receives the fees of every
+// COMPLETED transaction paid in that currency.
+func (r *walletRepository) FindAllWithTransactionBalance() (
+	[]*entity.BalanceReconciliation,
+	int,
+	error,
+) {
+	var reconciliations []*entity.BalanceReconciliation
+
+	incoming := r.db.Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(COALESCE(transactions.converted_amount, transactions.amount)), 0)").
+		Where("transactions.to_number = wallets.number").
+		Where("transactions.type <> ?", entity.Withdrawal).
+		Where("transactions.status = ?", entity.TransactionCompleted)
+	outgoing := r.db.Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(transactions.amount + transactions.fee), 0)").
+		Where("transactions.from_number = wallets.number").
+		Where(
+			"transactions.type IN ? OR "+
+				"(transactions.type = ? AND transactions.status <> ?)",
+			[]entity.TransactionType{entity.Transfer, entity.Refund},
+			entity.Withdrawal,
+			entity.TransactionFailed,
+		)
+
+	fees := r.db.Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(transactions.fee), 0)").
+		Where("wallets.user_id IS NULL AND wallets.number <= ?", entity.HouseWalletNumber).
+		Where("transactions.currency = wallets.currency").
+		Where("transactions.status = ?", entity.TransactionCompleted)
+
+	result := r.db.Model(&entity.Wallet{}).
+		Select(
+			"wallets.number AS wallet_number, "+
+				"wallets.balance AS wallet_balance, "+
+				"(?) - (?) + (?) AS transaction_balance",
+			incoming,
+			outgoing,
+			fees,
+		).
+		Order("wallets.number").
+		Scan(&reconciliations)
+
+	return reconciliations, int(result.RowsAffected), result.Error
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"assignment-golang-backend/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SetUpTestDB connects to the postgres database in TEST_DATABASE_DSN. Tests
+// that need a real database are skipped when it is not set.
+func SetUpTestDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.Wallet{}))
+
+	return db
+}
+
+func Test_walletRepository_ConcurrentTransfers(t *testing.T) {
+	const (
+		startingBalance = 1000
+		transferAmount  = 100
+		transferCount   = 50
+	)
+
+	db := SetUpTestDB(t)
+	rp := New(db)
+
+	fromWallet, _, err := rp.Wallets.CreateWallet(&entity.Wallet{})
+	require.NoError(t, err)
+	toWallet, _, err := rp.Wallets.CreateWallet(&entity.Wallet{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Unscoped().Delete(&entity.Wallet{}, []int{fromWallet.ID, toWallet.ID})
+	})
+
+	_, _, err = rp.Wallets.IncrementBalanceByValue(
+		fromWallet.Number,
+		startingBalance,
+	)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+
+	for i := 0; i < transferCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := rp.UnitOfWork.WithinTransaction(func(r *Repositories) error {
+				_, rowsAffected, err := r.Wallets.DecrementBalanceByValue(
+					fromWallet.Number,
+					transferAmount,
+				)
+				if err != nil || rowsAffected == 0 {
+					return gorm.ErrRecordNotFound
+				}
+
+				_, _, err = r.Wallets.IncrementBalanceByValue(
+					toWallet.Number,
+					transferAmount,
+				)
+				return err
+			})
+
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	gotFrom, _, err := rp.Wallets.FindByNumber(fromWallet.Number)
+	require.NoError(t, err)
+	gotTo, _, err := rp.Wallets.FindByNumber(toWallet.Number)
+	require.NoError(t, err)
+
+	assert.Equal(t, startingBalance/transferAmount, succeeded)
+	assert.GreaterOrEqual(t, gotFrom.Balance, 0)
+	assert.Equal(t, startingBalance-succeeded*transferAmount, gotFrom.Balance)
+	assert.Equal(t, succeeded*transferAmount, gotTo.Balance)
+	assert.Equal(t, startingBalance, gotFrom.Balance+gotTo.Balance)
+}
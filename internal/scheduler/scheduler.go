@@ -0,0 +1,75 @@
+// Package scheduler runs due scheduled transfers in the background of the
+// API process.
+package scheduler
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"assignment-golang-backend/internal/config"
+	"assignment-golang-backend/internal/usecase"
+)
+
+const (
+	DEFAULT_SCHEDULER_INTERVAL_SECOND = 60
+)
+
+type Worker struct {
+	service  usecase.IScheduledTransferService
+	interval time.Duration
+	now      func() time.Time
+}
+
+func New(s usecase.IScheduledTransferService, interval time.Duration) *Worker {
+	return &Worker{
+		service:  s,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// IntervalFromEnv reads SCHEDULER_INTERVAL_SECOND, the time between two
+// checks for due schedules.
+func IntervalFromEnv() time.Duration {
+	second, err := strconv.Atoi(config.GetEnv("SCHEDULER_INTERVAL_SECOND"))
+	if err != nil || second <= 0 {
+		second = DEFAULT_SCHEDULER_INTERVAL_SECOND
+	}
+
+	return time.Duration(second) * time.Second
+}
+
+// Start runs due schedules on every tick until ctx is done. It blocks, so
+// call it in its own goroutine.
+func (w *Worker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.RunOnce()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce runs the schedules that are due now. A full batch means more
+// schedules may be due, so it keeps going until a batch comes back short.
+func (w *Worker) RunOnce() {
+	for {
+		runCount, err := w.service.RunDueScheduledTransfers(w.now())
+		if err != nil {
+			log.Println("Error running scheduled transfers with err:", err.Error())
+			return
+		}
+
+		if runCount < usecase.SCHEDULED_TRANSFER_BATCH_SIZE {
+			return
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+)
+
+const (
+	OPENING_BALANCE_TYPE = "OPENING_BALANCE"
+	CLOSING_BALANCE_TYPE = "CLOSING_BALANCE"
+)
+
+// CSVWriter writes a statement as one table, with the opening balance as
+// its first row and the closing balance as its last. Amounts are in the
+// major unit of the currency of the wallet.
+type CSVWriter struct {
+	w         *csv.Writer
+	statement *entity.Statement
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{
+		w: csv.NewWriter(w),
+	}
+}
+
+func (w *CSVWriter) ContentType() string {
+	return "text/csv"
+}
+
+func (w *CSVWriter) WriteHeader(statement *entity.Statement) error {
+	w.statement = statement
+
+	err := w.w.Write([]string{
+		"datetime",
+		"id",
+		"type",
+		"description",
+		"amount",
+		"balance",
+		"currency",
+	})
+	if err != nil {
+		return err
+	}
+
+	return w.writeBalance(
+		statement.From,
+		OPENING_BALANCE_TYPE,
+		"Opening balance",
+		statement.OpeningBalance,
+	)
+}
+
+func (w *CSVWriter) WriteRow(row *Row) error {
+	return w.w.Write([]string{
+		row.Datetime.Format(time.RFC3339),
+		formatID(row.ID),
+		string(row.Type),
+		row.Description,
+		formatAmount(row.Amount, w.statement.Wallet.Currency, false),
+		formatAmount(row.Balance, w.statement.Wallet.Currency, false),
+		string(w.statement.Wallet.Currency),
+	})
+}
+
+func (w *CSVWriter) Close(closingBalance int) error {
+	err := w.writeBalance(
+		w.statement.To,
+		CLOSING_BALANCE_TYPE,
+		"Closing balance",
+		closingBalance,
+	)
+	if err != nil {
+		return err
+	}
+
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *CSVWriter) writeBalance(
+	datetime time.Time,
+	balanceType string,
+	description string,
+	balance int,
+) error {
+	return w.w.Write([]string{
+		datetime.Format(time.RFC3339),
+		"",
+		balanceType,
+		description,
+		"",
+		formatAmount(balance, w.statement.Wallet.Currency, false),
+		string(w.statement.Wallet.Currency),
+	})
+}
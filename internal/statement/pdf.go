@@ -0,0 +1,345 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"assignment-golang-backend/internal/entity"
+)
+
+// The page is A4 in points, and text is set in the standard Helvetica
+// fonts, which every PDF reader has, so no font is embedded.
+const (
+	PDF_PAGE_WIDTH    = 595
+	PDF_PAGE_HEIGHT   = 842
+	PDF_MARGIN        = 40
+	PDF_FONT_SIZE     = 9
+	PDF_LINE_HEIGHT   = 14
+	PDF_MAX_DESC_RUNE = 42
+)
+
+// The objects every statement has. Pages are numbered after them.
+const (
+	pdfCatalogObject = iota + 1
+	pdfPagesObject
+	pdfRegularFontObject
+	pdfBoldFontObject
+	pdfFirstPageObject
+)
+
+type pdfColumn struct {
+	title string
+	x     float64
+	// right aligns the column to x instead of starting it at x.
+	right bool
+}
+
+var pdfColumns = []pdfColumn{
+	{title: "Date", x: PDF_MARGIN},
+	{title: "ID", x: 125},
+	{title: "Type", x: 165},
+	{title: "Description", x: 235},
+	{title: "Amount", x: 475, right: true},
+	{title: "Balance", x: PDF_PAGE_WIDTH - PDF_MARGIN, right: true},
+}
+
+// PDFWriter writes a statement as a PDF document. Every page is written as
+// soon as it is full, and the page tree and cross-reference table that
+// point to them are written last.
+type PDFWriter struct {
+	w         *countingWriter
+	statement *entity.Statement
+	offsets   map[int]int64
+	objects   int
+	pages     []int
+	page      *bytes.Buffer
+	y         float64
+}
+
+func NewPDFWriter(w io.Writer) *PDFWriter {
+	return &PDFWriter{
+		w:       &countingWriter{w: w},
+		offsets: map[int]int64{},
+		objects: pdfFirstPageObject - 1,
+	}
+}
+
+func (w *PDFWriter) ContentType() string {
+	return "application/pdf"
+}
+
+func (w *PDFWriter) WriteHeader(statement *entity.Statement) error {
+	w.statement = statement
+
+	_, err := io.WriteString(w.w, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	if err != nil {
+		return err
+	}
+
+	err = w.writeFont(pdfRegularFontObject, "Helvetica")
+	if err != nil {
+		return err
+	}
+
+	err = w.writeFont(pdfBoldFontObject, "Helvetica-Bold")
+	if err != nil {
+		return err
+	}
+
+	w.newPage()
+	w.text(PDF_MARGIN, w.y, "F2", 14, fmt.Sprintf("Statement of wallet %d", statement.Wallet.Number))
+	w.y -= PDF_LINE_HEIGHT * 2
+	w.text(PDF_MARGIN, w.y, "F1", PDF_FONT_SIZE, fmt.Sprintf(
+		"Period: %s to %s",
+		statement.From.Format(DATE_LAYOUT),
+		LastDay(statement).Format(DATE_LAYOUT),
+	))
+	w.y -= PDF_LINE_HEIGHT
+	w.text(PDF_MARGIN, w.y, "F1", PDF_FONT_SIZE, fmt.Sprintf("Currency: %s", statement.Wallet.Currency))
+	w.y -= PDF_LINE_HEIGHT
+	w.text(PDF_MARGIN, w.y, "F1", PDF_FONT_SIZE, fmt.Sprintf(
+		"Opening balance: %s",
+		formatAmount(statement.OpeningBalance, statement.Wallet.Currency, true),
+	))
+	w.y -= PDF_LINE_HEIGHT * 2
+	w.writeColumnTitles()
+
+	return nil
+}
+
+func (w *PDFWriter) WriteRow(row *Row) error {
+	if w.y < PDF_MARGIN+PDF_LINE_HEIGHT {
+		err := w.writePage()
+		if err != nil {
+			return err
+		}
+
+		w.newPage()
+		w.writeColumnTitles()
+	}
+
+	description := []rune(row.Description)
+	if len(description) > PDF_MAX_DESC_RUNE {
+		description = append(description[:PDF_MAX_DESC_RUNE-3], []rune("...")...)
+	}
+
+	w.writeColumns("F1", []string{
+		row.Datetime.Format(DATETIME_LAYOUT),
+		formatID(row.ID),
+		string(row.Type),
+		string(description),
+		formatAmount(row.Amount, w.statement.Wallet.Currency, true),
+		formatAmount(row.Balance, w.statement.Wallet.Currency, true),
+	})
+
+	return nil
+}
+
+func (w *PDFWriter) Close(closingBalance int) error {
+	if w.y < PDF_MARGIN+PDF_LINE_HEIGHT*2 {
+		err := w.writePage()
+		if err != nil {
+			return err
+		}
+
+		w.newPage()
+	}
+
+	w.y -= PDF_LINE_HEIGHT
+	w.text(PDF_MARGIN, w.y, "F2", PDF_FONT_SIZE, fmt.Sprintf(
+		"Closing balance: %s",
+		formatAmount(closingBalance, w.statement.Wallet.Currency, true),
+	))
+
+	err := w.writePage()
+	if err != nil {
+		return err
+	}
+
+	kids := make([]string, len(w.pages))
+	for i, page := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+	}
+
+	err = w.writeObject(pdfPagesObject, fmt.Sprintf(
+		"<< /Type /Pages /Kids [%s] /Count %d >>",
+		strings.Join(kids, " "),
+		len(w.pages),
+	))
+	if err != nil {
+		return err
+	}
+
+	err = w.writeObject(pdfCatalogObject, fmt.Sprintf(
+		"<< /Type /Catalog /Pages %d 0 R >>",
+		pdfPagesObject,
+	))
+	if err != nil {
+		return err
+	}
+
+	return w.writeTrailer()
+}
+
+func (w *PDFWriter) writeFont(object int, font string) error {
+	return w.writeObject(object, fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>",
+		font,
+	))
+}
+
+func (w *PDFWriter) newPage() {
+	w.page = &bytes.Buffer{}
+	w.y = PDF_PAGE_HEIGHT - PDF_MARGIN
+	w.text(PDF_MARGIN, PDF_MARGIN/2, "F1", PDF_FONT_SIZE-1, fmt.Sprintf("Page %d", len(w.pages)+1))
+}
+
+func (w *PDFWriter) writeColumnTitles() {
+	titles := make([]string, len(pdfColumns))
+	for i, column := range pdfColumns {
+		titles[i] = column.title
+	}
+
+	w.writeColumns("F2", titles)
+}
+
+func (w *PDFWriter) writeColumns(font string, values []string) {
+	for i, column := range pdfColumns {
+		x := column.x
+		if column.right {
+			x -= textWidth(values[i], PDF_FONT_SIZE)
+		}
+
+		w.text(x, w.y, font, PDF_FONT_SIZE, values[i])
+	}
+
+	w.y -= PDF_LINE_HEIGHT
+}
+
+func (w *PDFWriter) text(x, y float64, font string, size int, text string) {
+	fmt.Fprintf(
+		w.page,
+		"BT /%s %d Tf %.2f %.2f Td (%s) Tj ET\n",
+		font,
+		size,
+		x,
+		y,
+		escapeText(text),
+	)
+}
+
+// writePage writes the content of the current page and the page itself.
+func (w *PDFWriter) writePage() error {
+	content := w.nextObject()
+	err := w.writeObject(content, fmt.Sprintf(
+		"<< /Length %d >>\nstream\n%sendstream",
+		w.page.Len(),
+		w.page.String(),
+	))
+	if err != nil {
+		return err
+	}
+
+	page := w.nextObject()
+	w.pages = append(w.pages, page)
+
+	return w.writeObject(page, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObject,
+		PDF_PAGE_WIDTH,
+		PDF_PAGE_HEIGHT,
+		pdfRegularFontObject,
+		pdfBoldFontObject,
+		content,
+	))
+}
+
+func (w *PDFWriter) nextObject() int {
+	w.objects++
+	return w.objects
+}
+
+func (w *PDFWriter) writeObject(object int, body string) error {
+	w.offsets[object] = w.w.n
+	_, err := fmt.Fprintf(w.w, "%d 0 obj\n%s\nendobj\n", object, body)
+	return err
+}
+
+// writeTrailer writes the cross-reference table of every object and the
+// trailer that points readers to it.
+func (w *PDFWriter) writeTrailer() error {
+	xref := w.w.n
+	size := len(w.offsets) + 1
+
+	var trailer bytes.Buffer
+	fmt.Fprintf(&trailer, "xref\n0 %d\n0000000000 65535 f \n", size)
+	for object := 1; object < size; object++ {
+		fmt.Fprintf(&trailer, "%010d 00000 n \n", w.offsets[object])
+	}
+	fmt.Fprintf(
+		&trailer,
+		"trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		size,
+		pdfCatalogObject,
+		xref,
+	)
+
+	_, err := w.w.Write(trailer.Bytes())
+	return err
+}
+
+// countingWriter counts the bytes written through it, which the
+// cross-reference table needs to locate the objects.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// escapeText encodes text for a PDF string in WinAnsiEncoding. Characters
+// the encoding lacks are replaced by a question mark.
+func escapeText(text string) string {
+	var escaped strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			escaped.WriteByte('\\')
+			escaped.WriteRune(r)
+		case r < ' ':
+			escaped.WriteByte(' ')
+		case r < 0x80 || (r >= 0xa0 && r <= 0xff):
+			escaped.WriteByte(byte(r))
+		default:
+			escaped.WriteByte('?')
+		}
+	}
+
+	return escaped.String()
+}
+
+// textWidth is the width of text in Helvetica, in points. Only the
+// characters of amounts have their own widths, which is all that is right
+// aligned.
+func textWidth(text string, size int) float64 {
+	units := 0
+	for _, r := range text {
+		switch r {
+		case ',', '.', ' ':
+			units += 278
+		case '-':
+			units += 333
+		default:
+			units += 556
+		}
+	}
+
+	return float64(units*size) / 1000
+}
@@ -0,0 +1,120 @@
+// Package statement writes the statement of a wallet, the money that moved
+// in a period with the balance of the wallet after each line, as CSV or PDF.
+// Statements are written as their rows are read, so they can be streamed.
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+)
+
+const (
+	CSV = "csv"
+	PDF = "pdf"
+
+	DATE_LAYOUT     = "2006-01-02"
+	DATETIME_LAYOUT = "2006-01-02 15:04"
+)
+
+// Row is a line of a statement. ID is the transaction of the line, 0 when
+// no transaction caused it. Amount is negative when the money left the
+// wallet, and Balance is the balance of the wallet after it.
+type Row struct {
+	Datetime    time.Time
+	ID          int
+	Type        entity.TransactionType
+	Description string
+	Amount      int
+	Balance     int
+}
+
+// IWriter writes a statement: its header first, then every row in order,
+// and the closing balance last.
+type IWriter interface {
+	ContentType() string
+	WriteHeader(*entity.Statement) error
+	WriteRow(*Row) error
+	Close(int) error
+}
+
+// NewWriter returns the writer of the format, or false when the format is
+// not supported.
+func NewWriter(format string, w io.Writer) (IWriter, bool) {
+	switch format {
+	case CSV:
+		return NewCSVWriter(w), true
+	case PDF:
+		return NewPDFWriter(w), true
+	default:
+		return nil, false
+	}
+}
+
+// FileName is the name a statement is downloaded as.
+func FileName(statement *entity.Statement, format string) string {
+	return fmt.Sprintf(
+		"statement-%d-%s-%s.%s",
+		statement.Wallet.Number,
+		statement.From.Format(DATE_LAYOUT),
+		LastDay(statement).Format(DATE_LAYOUT),
+		format,
+	)
+}
+
+// LastDay is the last day of the period of the statement.
+func LastDay(statement *entity.Statement) time.Time {
+	return statement.To.AddDate(0, 0, -1)
+}
+
+// formatAmount writes an amount of minor units in the major unit of the
+// currency, e.g. 123456 cents of USD as 1234.56, with the thousands
+// separated by commas when grouped.
+func formatAmount(amount int, currency entity.Currency, grouped bool) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	unit := 1
+	for i := 0; i < currency.Exponent(); i++ {
+		unit *= 10
+	}
+
+	whole := strconv.Itoa(amount / unit)
+	if grouped {
+		whole = groupThousands(whole)
+	}
+
+	if unit == 1 {
+		return sign + whole
+	}
+
+	return fmt.Sprintf("%s%s.%0*d", sign, whole, currency.Exponent(), amount%unit)
+}
+
+// formatID writes the transaction ID of a row, nothing when it has none.
+func formatID(id int) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(id)
+}
+
+func groupThousands(digits string) string {
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return grouped.String()
+}
@@ -0,0 +1,155 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatement(currency entity.Currency) *entity.Statement {
+	return &entity.Statement{
+		Wallet:         &entity.Wallet{Number: 100001, Currency: currency},
+		From:           time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(2030, time.February, 1, 0, 0, 0, 0, time.UTC),
+		OpeningBalance: 100000,
+	}
+}
+
+func newRows(count int) []*Row {
+	rows := []*Row{}
+	balance := 100000
+	for i := 1; i <= count; i++ {
+		balance -= 1000
+		rows = append(rows, &Row{
+			Datetime:    time.Date(2030, time.January, 2, 9, 0, i, 0, time.UTC),
+			ID:          i,
+			Type:        entity.Transfer,
+			Description: fmt.Sprintf("Lunch (%d)", i),
+			Amount:      -1000,
+			Balance:     balance,
+		})
+	}
+
+	return rows
+}
+
+func writeStatement(
+	t *testing.T,
+	writer IWriter,
+	statement *entity.Statement,
+	rows []*Row,
+) {
+	require.NoError(t, writer.WriteHeader(statement))
+	closingBalance := statement.OpeningBalance
+	for _, row := range rows {
+		require.NoError(t, writer.WriteRow(row))
+		closingBalance = row.Balance
+	}
+	require.NoError(t, writer.Close(closingBalance))
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int
+		currency entity.Currency
+		grouped  bool
+		want     string
+	}{
+		{
+			name:     "Whole rupiah",
+			amount:   1234567,
+			currency: entity.IDR,
+			want:     "1234567",
+		},
+		{
+			name:     "Whole rupiah grouped",
+			amount:   -1234567,
+			currency: entity.IDR,
+			grouped:  true,
+			want:     "-1,234,567",
+		},
+		{
+			name:     "Cents",
+			amount:   123405,
+			currency: entity.USD,
+			grouped:  true,
+			want:     "1,234.05",
+		},
+		{
+			name:     "Less than a dollar",
+			amount:   -5,
+			currency: entity.USD,
+			want:     "-0.05",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatAmount(tt.amount, tt.currency, tt.grouped))
+		})
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	writeStatement(t, NewCSVWriter(&buffer), newStatement(entity.USD), newRows(2))
+
+	assert.Equal(
+		t,
+		"datetime,id,type,description,amount,balance,currency\n"+
+			"2030-01-01T00:00:00Z,,OPENING_BALANCE,Opening balance,,1000.00,USD\n"+
+			"2030-01-02T09:00:01Z,1,TRANSFER,Lunch (1),-10.00,990.00,USD\n"+
+			"2030-01-02T09:00:02Z,2,TRANSFER,Lunch (2),-10.00,980.00,USD\n"+
+			"2030-02-01T00:00:00Z,,CLOSING_BALANCE,Closing balance,,980.00,USD\n",
+		buffer.String(),
+	)
+}
+
+func TestPDFWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	writeStatement(t, NewPDFWriter(&buffer), newStatement(entity.IDR), newRows(120))
+	document := buffer.String()
+
+	assert.True(t, strings.HasPrefix(document, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(document, "%%EOF\n"))
+	assert.Contains(t, document, "(Opening balance: 100,000) Tj")
+	assert.Contains(t, document, "(Lunch \\(120\\)) Tj")
+	assert.Contains(t, document, "(Closing balance: -20,000) Tj")
+
+	pages := regexp.MustCompile(`/Count (\d+)`).FindStringSubmatch(document)
+	require.NotNil(t, pages)
+	assert.Equal(t, "3", pages[1])
+
+	// Every entry of the cross-reference table points to its object.
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(document)
+	require.NotNil(t, startxref)
+	xref, err := strconv.Atoi(startxref[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(document[xref:], "xref\n"))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(document[xref:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(entry[1])
+		require.NoError(t, err)
+		assert.True(
+			t,
+			strings.HasPrefix(document[offset:], fmt.Sprintf("%d 0 obj\n", i+1)),
+			"object %d",
+			i+1,
+		)
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	assert.Equal(t, "Caf\xe9 \\(50%\\) ? \\\\", escapeText("Café (50%) → \\"))
+}
@@ -0,0 +1,86 @@
+// Package storage keeps uploaded files, e.g. KYC documents.
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"assignment-golang-backend/internal/config"
+)
+
+const (
+	DEFAULT_STORAGE_DIR = "storage"
+)
+
+// IFileStorage stores files under slash separated names such as
+// "kyc/1/document.png".
+type IFileStorage interface {
+	Save(string, io.Reader) error
+	Open(string) (io.ReadCloser, error)
+	Delete(string) error
+}
+
+// LocalStorage keeps files in a directory of the local file system. Only
+// the API process should be able to read it.
+type LocalStorage struct {
+	dir string
+}
+
+func NewLocalStorage(dir string) *LocalStorage {
+	return &LocalStorage{
+		dir: dir,
+	}
+}
+
+// DirFromEnv reads STORAGE_DIR, the directory LocalStorage keeps files in.
+func DirFromEnv() string {
+	dir := config.GetEnv("STORAGE_DIR")
+	if dir == "" {
+		return DEFAULT_STORAGE_DIR
+	}
+
+	return dir
+}
+
+// Save writes content to a new file. It never overwrites a file, so a
+// name that is taken is an error.
+func (s *LocalStorage) Save(name string, content io.Reader) error {
+	path := s.path(name)
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, content)
+	if err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+
+	return file.Close()
+}
+
+func (s *LocalStorage) Open(name string) (io.ReadCloser, error) {
+	return os.Open(s.path(name))
+}
+
+func (s *LocalStorage) Delete(name string) error {
+	err := os.Remove(s.path(name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+// path keeps name inside the storage directory, whatever ".." it holds.
+func (s *LocalStorage) path(name string) string {
+	return filepath.Join(s.dir, filepath.Clean("/"+filepath.FromSlash(name)))
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalStorage(dir)
+
+	err := s.Save("kyc/1/document.png", strings.NewReader("content"))
+	require.NoError(t, err)
+
+	err = s.Save("kyc/1/document.png", strings.NewReader("other"))
+	assert.Error(t, err, "Save must not overwrite a file")
+
+	file, err := s.Open("kyc/1/document.png")
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	file.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+
+	err = s.Delete("kyc/1/document.png")
+	require.NoError(t, err)
+	_, err = s.Open("kyc/1/document.png")
+	assert.Error(t, err)
+
+	err = s.Delete("kyc/1/document.png")
+	assert.NoError(t, err, "Deleting a missing file is not an error")
+}
+
+func TestLocalStorage_path(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalStorage(dir)
+
+	err := s.Save("../../escaped.txt", strings.NewReader("content"))
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(dir, "escaped.txt"))
+	assert.NoError(t, err, "Names cannot leave the storage directory")
+}
@@ -0,0 +1,81 @@
+package topup
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+)
+
+const (
+	FAKE_PROVIDER_NAME          = "fake"
+	FAKE_SIGNATURE_HEADER       = "X-Fake-Signature"
+	FAKE_PAYMENT_PAID           = "PAID"
+	FAKE_PAYMENT_FAILED         = "FAILED"
+	FAKE_VIRTUAL_ACCOUNT_PREFIX = "FAKE-VA-"
+)
+
+// FakeProvider stands in for a real payment provider locally and in tests.
+// The default sources of funds are all collected by it.
+// It hands out a virtual account per top up, and its webhook is a JSON
+// FakeWebhookBody signed with the shared secret in FAKE_SIGNATURE_HEADER.
+type FakeProvider struct {
+	secret []byte
+}
+
+type FakeWebhookBody struct {
+	Reference     string `json:"reference"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{
+		secret: []byte(secret),
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return FAKE_PROVIDER_NAME
+}
+
+func (p *FakeProvider) CreatePayment(
+	topup *entity.Transaction,
+) (string, error) {
+	return fmt.Sprintf("%s%d", FAKE_VIRTUAL_ACCOUNT_PREFIX, topup.ID), nil
+}
+
+func (p *FakeProvider) ParseWebhook(
+	body []byte,
+	header http.Header,
+) (*WebhookEvent, error) {
+	if !VerifySignature(p.secret, body, header.Get(FAKE_SIGNATURE_HEADER)) {
+		return nil, &custom_error.InvalidWebhookSignature{}
+	}
+
+	var input FakeWebhookBody
+	err := json.Unmarshal(body, &input)
+	if err != nil || input.Reference == "" {
+		return nil, &custom_error.InvalidRequestBody{}
+	}
+
+	switch input.Status {
+	case FAKE_PAYMENT_PAID:
+		return &WebhookEvent{Reference: input.Reference, Paid: true}, nil
+	case FAKE_PAYMENT_FAILED:
+		return &WebhookEvent{
+			Reference:     input.Reference,
+			FailureReason: input.FailureReason,
+		}, nil
+	default:
+		return nil, &custom_error.InvalidRequestBody{}
+	}
+}
+
+// Sign signs a webhook body the way the fake provider would, so local
+// callers and tests can confirm top ups.
+func (p *FakeProvider) Sign(body []byte) string {
+	return Sign(p.secret, body)
+}
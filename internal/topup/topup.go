@@ -0,0 +1,79 @@
+// Package topup collects top ups through the payment provider of each
+// source of funds. A top up only credits the wallet once its provider
+// confirms the payment through a signed webhook.
+package topup
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"assignment-golang-backend/internal/entity"
+)
+
+// ITopupProvider asks a payment provider to collect a pending top up and
+// reads the provider's webhook calls about it.
+type ITopupProvider interface {
+	Name() string
+	// CreatePayment returns the reference the payer pays the amount and fee
+	// of the top up to, e.g. a virtual account number. The provider quotes
+	// it back in its webhook calls.
+	CreatePayment(*entity.Transaction) (string, error)
+	// ParseWebhook checks the signature of a webhook call before reading
+	// the payment outcome from it.
+	ParseWebhook([]byte, http.Header) (*WebhookEvent, error)
+}
+
+// WebhookEvent is the outcome of a top up payment reported by a provider.
+type WebhookEvent struct {
+	Reference     string
+	Paid          bool
+	FailureReason string
+}
+
+// Registry holds the providers by name. Each source of funds names the
+// provider that collects it.
+type Registry struct {
+	providers map[string]ITopupProvider
+}
+
+func NewRegistry(providers ...ITopupProvider) *Registry {
+	registry := &Registry{
+		providers: map[string]ITopupProvider{},
+	}
+	for _, provider := range providers {
+		registry.providers[provider.Name()] = provider
+	}
+
+	return registry
+}
+
+func (r *Registry) Get(name string) (ITopupProvider, bool) {
+	provider, ok := r.providers[name]
+	return provider, ok
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of body.
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature compares signature with the HMAC-SHA256 of body in
+// constant time. Nothing verifies without a secret.
+func VerifySignature(secret []byte, body []byte, signature string) bool {
+	if len(secret) == 0 {
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,63 @@
+package topup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"reference":"FAKE-VA-1","status":"PAID"}`)
+	secret := []byte("secret")
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{
+			name:      "Valid signature",
+			secret:    secret,
+			body:      body,
+			signature: Sign(secret, body),
+			want:      true,
+		},
+		{
+			name:      "Signed with another secret",
+			secret:    secret,
+			body:      body,
+			signature: Sign([]byte("other"), body),
+			want:      false,
+		},
+		{
+			name:      "Body changed after signing",
+			secret:    secret,
+			body:      []byte(`{"reference":"FAKE-VA-2","status":"PAID"}`),
+			signature: Sign(secret, body),
+			want:      false,
+		},
+		{
+			name:      "Signature is not hex",
+			secret:    secret,
+			body:      body,
+			signature: "not hex",
+			want:      false,
+		},
+		{
+			name:      "No secret configured",
+			secret:    nil,
+			body:      body,
+			signature: Sign(nil, body),
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := VerifySignature(tt.secret, tt.body, tt.signature)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
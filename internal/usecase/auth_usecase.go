@@ -1,29 +1,46 @@
 package usecase
 
 import (
+	"strconv"
+	"time"
+
+	"assignment-golang-backend/internal/config"
 	"assignment-golang-backend/internal/custom_error"
 	"assignment-golang-backend/internal/entity"
 	"assignment-golang-backend/internal/helper"
 	"assignment-golang-backend/internal/repository"
 )
 
+const (
+	DEFAULT_REFRESH_TOKEN_EXP_HOUR = 24 * 30
+)
+
 type IAuthService interface {
 	Login(string, string) (*entity.Token, error)
 	Register(*entity.User) (*entity.Token, error)
+	Refresh(string) (*entity.Token, error)
+	Logout(*helper.IdTokenClaims, string) error
+	IsTokenRevoked(string) (bool, error)
 }
 
 type authService struct {
-	userRepository   repository.IUserRepository
-	walletRepository repository.IWalletRepository
+	userRepository         repository.IUserRepository
+	walletRepository       repository.IWalletRepository
+	refreshTokenRepository repository.IRefreshTokenRepository
+	revokedTokenRepository repository.IRevokedTokenRepository
 }
 
 func NewAuthService(
 	ur repository.IUserRepository,
 	wr repository.IWalletRepository,
+	rtr repository.IRefreshTokenRepository,
+	vtr repository.IRevokedTokenRepository,
 ) IAuthService {
 	return &authService{
-		userRepository:   ur,
-		walletRepository: wr,
+		userRepository:         ur,
+		walletRepository:       wr,
+		refreshTokenRepository: rtr,
+		revokedTokenRepository: vtr,
 	}
 }
 
@@ -48,12 +65,7 @@ func (s *authService) Login(
 		return nil, err
 	}
 
-	tokenString, err := helper.GenerateJWT(user)
-	if err != nil {
-		return nil, err
-	}
-
-	return &entity.Token{IDToken: tokenString, User: *user}, nil
+	return s.generateToken(user)
 }
 
 func (s *authService) Register(user *entity.User) (*entity.Token, error) {
@@ -75,7 +87,7 @@ func (s *authService) Register(user *entity.User) (*entity.Token, error) {
 		return nil, err
 	}
 
-	wallet := &entity.Wallet{}
+	wallet := &entity.Wallet{Currency: entity.BaseCurrency}
 	wallet, rowsAffected, err = s.walletRepository.CreateWallet(wallet)
 
 	if rowsAffected == 0 || err != nil {
@@ -91,11 +103,159 @@ func (s *authService) Register(user *entity.User) (*entity.Token, error) {
 		return nil, &custom_error.FailedToCreateData{DataType: "User"}
 	}
 
+	rowsAffected, err = s.walletRepository.UpdateUserID(wallet.Number, user.ID)
+
+	if rowsAffected == 0 || err != nil {
+		return nil, &custom_error.FailedToCreateData{DataType: "Wallet"}
+	}
+
+	return s.generateToken(user)
+}
+
+// Refresh rotates the refresh token: the given token is revoked and a new
+// access and refresh token pair is issued. Presenting a token that was
+// already rotated revokes every refresh token of the user, since it means
+// the token has leaked.
+func (s *authService) Refresh(refreshToken string) (*entity.Token, error) {
+	storedToken, rowsAffected, err := s.refreshTokenRepository.FindByTokenHash(
+		helper.HashToken(refreshToken),
+	)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.InvalidToken{}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if storedToken.RevokedAt != nil {
+		_, err = s.refreshTokenRepository.RevokeAllByUserID(storedToken.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, &custom_error.InvalidToken{}
+	}
+
+	if time.Now().After(storedToken.ExpiresAt) {
+		return nil, &custom_error.InvalidToken{}
+	}
+
+	rowsAffected, err = s.refreshTokenRepository.RevokeByID(storedToken.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.InvalidToken{}
+	}
+
+	user, rowsAffected, err := s.userRepository.FindByID(storedToken.UserID)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "user"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.generateToken(user)
+}
+
+// Logout revokes the access token in claims until it expires, together with
+// the refresh token of the session when it is given.
+func (s *authService) Logout(
+	claims *helper.IdTokenClaims,
+	refreshToken string,
+) error {
+	if claims.ID != "" && claims.ExpiresAt != nil {
+		_, _, err := s.revokedTokenRepository.CreateRevokedToken(
+			&entity.RevokedToken{
+				JTI:       claims.ID,
+				ExpiresAt: claims.ExpiresAt.Time,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	storedToken, rowsAffected, err := s.refreshTokenRepository.FindByTokenHash(
+		helper.HashToken(refreshToken),
+	)
+
+	if rowsAffected == 0 {
+		return &custom_error.InvalidToken{}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if storedToken.UserID != claims.User.ID {
+		return &custom_error.InvalidToken{}
+	}
+
+	_, err = s.refreshTokenRepository.RevokeByID(storedToken.ID)
+
+	return err
+}
+
+func (s *authService) IsTokenRevoked(jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+
+	_, rowsAffected, err := s.revokedTokenRepository.FindByJTI(jti)
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected != 0, nil
+}
+
+func (s *authService) generateToken(user *entity.User) (*entity.Token, error) {
 	tokenString, err := helper.GenerateJWT(user)
+	if err != nil {
+		return nil, err
+	}
 
+	refreshToken, err := helper.GenerateRandomToken()
 	if err != nil {
 		return nil, err
 	}
 
-	return &entity.Token{IDToken: tokenString, User: *user}, nil
+	_, rowsAffected, err := s.refreshTokenRepository.CreateRefreshToken(
+		&entity.RefreshToken{
+			UserID:    user.ID,
+			TokenHash: helper.HashToken(refreshToken),
+			ExpiresAt: time.Now().Add(refreshTokenDuration()),
+		},
+	)
+
+	if rowsAffected == 0 || err != nil {
+		return nil, &custom_error.FailedToCreateData{DataType: "refresh token"}
+	}
+
+	return &entity.Token{
+		IDToken:      tokenString,
+		RefreshToken: refreshToken,
+		User:         *user,
+	}, nil
+}
+
+func refreshTokenDuration() time.Duration {
+	expHour, err := strconv.Atoi(config.GetEnv("REFRESH_TOKEN_EXP_HOUR"))
+	if err != nil || expHour <= 0 {
+		expHour = DEFAULT_REFRESH_TOKEN_EXP_HOUR
+	}
+
+	return time.Duration(expHour) * time.Hour
 }
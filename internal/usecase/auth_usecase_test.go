@@ -3,17 +3,25 @@ package usecase
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"assignment-golang-backend/internal/custom_error"
 	"assignment-golang-backend/internal/entity"
 	"assignment-golang-backend/internal/helper"
 	"assignment-golang-backend/mocks"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewAuthService(t *testing.T) {
-	NewAuthService(mocks.NewIUserRepository(t), mocks.NewIWalletRepository(t))
+	NewAuthService(
+		mocks.NewIUserRepository(t),
+		mocks.NewIWalletRepository(t),
+		mocks.NewIRefreshTokenRepository(t),
+		mocks.NewIRevokedTokenRepository(t),
+	)
 }
 
 func Test_authService_Register(t *testing.T) {
@@ -26,9 +34,8 @@ func Test_authService_Register(t *testing.T) {
 		Password: "password",
 	}
 
-	mockWallet := &entity.Wallet{}
-
-	mockTokenString, _ := helper.GenerateJWT(mockUser)
+	mockWallet := &entity.Wallet{Currency: entity.BaseCurrency}
+	mockCreatedWallet := &entity.Wallet{Number: 100001, Currency: entity.BaseCurrency}
 
 	tests := []struct {
 		name             string
@@ -74,7 +81,7 @@ func Test_authService_Register(t *testing.T) {
 			mock: func(ir *mocks.IUserRepository, wr *mocks.IWalletRepository) {
 				ir.On("FindByEmail", mockUser.Email).
 					Return(mockUser, 0, nil)
-				wr.On("CreateWallet", &entity.Wallet{}).
+				wr.On("CreateWallet", &entity.Wallet{Currency: entity.BaseCurrency}).
 					Return(&entity.Wallet{}, 0, &custom_error.FailedToCreateData{DataType: "Wallet"})
 			},
 			want:        nil,
@@ -90,7 +97,7 @@ func Test_authService_Register(t *testing.T) {
 				ir.On("FindByEmail", mockUser.Email).
 					Return(mockUser, 0, nil)
 				wr.On("CreateWallet", mockWallet).
-					Return(mockWallet, 1, nil)
+					Return(mockCreatedWallet, 1, nil)
 				ir.On("CreateUser", mockUser).
 					Return(mockUser, 1, fmt.Errorf("error"))
 			},
@@ -98,6 +105,25 @@ func Test_authService_Register(t *testing.T) {
 			wantErr:     true,
 			expectedErr: &custom_error.FailedToCreateData{DataType: "User"},
 		},
+		{
+			name:             "ERROR | NoDataCreated error if the wallet cannot be given to the user",
+			user:             mockUser,
+			userRepository:   mocks.NewIUserRepository(t),
+			walletRepository: mocks.NewIWalletRepository(t),
+			mock: func(ir *mocks.IUserRepository, wr *mocks.IWalletRepository) {
+				ir.On("FindByEmail", mockUser.Email).
+					Return(mockUser, 0, nil)
+				wr.On("CreateWallet", mockWallet).
+					Return(mockCreatedWallet, 1, nil)
+				ir.On("CreateUser", mockUser).
+					Return(mockUser, 1, nil)
+				wr.On("UpdateUserID", mockCreatedWallet.Number, mockUser.ID).
+					Return(0, nil)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToCreateData{DataType: "Wallet"},
+		},
 		{
 			name:             "SUCCESS",
 			user:             mockUser,
@@ -107,11 +133,13 @@ func Test_authService_Register(t *testing.T) {
 				ir.On("FindByEmail", mockUser.Email).
 					Return(mockUser, 0, nil)
 				wr.On("CreateWallet", mockWallet).
-					Return(mockWallet, 1, nil)
+					Return(mockCreatedWallet, 1, nil)
 				ir.On("CreateUser", mockUser).
 					Return(mockUser, 1, nil)
+				wr.On("UpdateUserID", mockCreatedWallet.Number, mockUser.ID).
+					Return(1, nil)
 			},
-			want:        &entity.Token{IDToken: mockTokenString},
+			want:        &entity.Token{User: *mockUser},
 			wantErr:     false,
 			expectedErr: nil,
 		},
@@ -119,9 +147,16 @@ func Test_authService_Register(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			refreshTokenRepository := mocks.NewIRefreshTokenRepository(t)
+			if !tt.wantErr {
+				refreshTokenRepository.On("CreateRefreshToken", mock.Anything).
+					Return(&entity.RefreshToken{}, 1, nil)
+			}
+
 			s := &authService{
-				userRepository:   tt.userRepository,
-				walletRepository: tt.walletRepository,
+				userRepository:         tt.userRepository,
+				walletRepository:       tt.walletRepository,
+				refreshTokenRepository: refreshTokenRepository,
 			}
 
 			tt.mock(tt.userRepository, tt.walletRepository)
@@ -130,7 +165,9 @@ func Test_authService_Register(t *testing.T) {
 
 			if !tt.wantErr {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.want.IDToken, got.IDToken)
+				assert.NotEmpty(t, got.IDToken)
+				assert.NotEmpty(t, got.RefreshToken)
+				assert.Equal(t, tt.want.User.Email, got.User.Email)
 			} else {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tt.expectedErr.Error())
@@ -151,7 +188,6 @@ func Test_authService_Login(t *testing.T) {
 		Password: mockHashed,
 	}
 
-	mockTokenString, _ := helper.GenerateJWT(mockUser)
 	type args struct {
 		email    string
 		password string
@@ -211,15 +247,22 @@ func Test_authService_Login(t *testing.T) {
 			mock: func(ir *mocks.IUserRepository) {
 				ir.On("FindByEmail", mockUser.Email).Return(mockUser, 1, nil)
 			},
-			want:        &entity.Token{IDToken: mockTokenString},
+			want:        &entity.Token{User: *mockUser},
 			wantErr:     false,
 			expectedErr: nil,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			refreshTokenRepository := mocks.NewIRefreshTokenRepository(t)
+			if !tt.wantErr {
+				refreshTokenRepository.On("CreateRefreshToken", mock.Anything).
+					Return(&entity.RefreshToken{}, 1, nil)
+			}
+
 			s := &authService{
-				userRepository: tt.userRepository,
+				userRepository:         tt.userRepository,
+				refreshTokenRepository: refreshTokenRepository,
 			}
 
 			tt.mock(tt.userRepository)
@@ -228,7 +271,9 @@ func Test_authService_Login(t *testing.T) {
 
 			if !tt.wantErr {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.want.IDToken, got.IDToken)
+				assert.NotEmpty(t, got.IDToken)
+				assert.NotEmpty(t, got.RefreshToken)
+				assert.Equal(t, tt.want.User.Email, got.User.Email)
 			} else {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tt.expectedErr.Error())
@@ -237,3 +282,233 @@ func Test_authService_Login(t *testing.T) {
 		})
 	}
 }
+
+func Test_authService_Refresh(t *testing.T) {
+	mockRefreshToken := "refresh-token"
+	mockTokenHash := helper.HashToken(mockRefreshToken)
+	mockRevokedAt := time.Now()
+	mockUser := &entity.User{
+		Base:  entity.Base{ID: 1},
+		Email: "email@email.com",
+	}
+	mockOtherError := fmt.Errorf("error")
+
+	tests := []struct {
+		name        string
+		mock        func(*mocks.IRefreshTokenRepository, *mocks.IUserRepository)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Unknown refresh token",
+			mock: func(rtr *mocks.IRefreshTokenRepository, ur *mocks.IUserRepository) {
+				rtr.On("FindByTokenHash", mockTokenHash).Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.InvalidToken{},
+		},
+		{
+			name: "Error | Reused refresh token revokes every session",
+			mock: func(rtr *mocks.IRefreshTokenRepository, ur *mocks.IUserRepository) {
+				rtr.On("FindByTokenHash", mockTokenHash).Return(&entity.RefreshToken{
+					Base:      entity.Base{ID: 1},
+					UserID:    mockUser.ID,
+					ExpiresAt: time.Now().Add(time.Hour),
+					RevokedAt: &mockRevokedAt,
+				}, 1, nil)
+				rtr.On("RevokeAllByUserID", mockUser.ID).Return(2, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.InvalidToken{},
+		},
+		{
+			name: "Error | Expired refresh token",
+			mock: func(rtr *mocks.IRefreshTokenRepository, ur *mocks.IUserRepository) {
+				rtr.On("FindByTokenHash", mockTokenHash).Return(&entity.RefreshToken{
+					Base:      entity.Base{ID: 1},
+					UserID:    mockUser.ID,
+					ExpiresAt: time.Now().Add(-time.Hour),
+				}, 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.InvalidToken{},
+		},
+		{
+			name: "Error | Refresh token rotated concurrently",
+			mock: func(rtr *mocks.IRefreshTokenRepository, ur *mocks.IUserRepository) {
+				rtr.On("FindByTokenHash", mockTokenHash).Return(&entity.RefreshToken{
+					Base:      entity.Base{ID: 1},
+					UserID:    mockUser.ID,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, 1, nil)
+				rtr.On("RevokeByID", 1).Return(0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.InvalidToken{},
+		},
+		{
+			name: "Error | Other error from user repository",
+			mock: func(rtr *mocks.IRefreshTokenRepository, ur *mocks.IUserRepository) {
+				rtr.On("FindByTokenHash", mockTokenHash).Return(&entity.RefreshToken{
+					Base:      entity.Base{ID: 1},
+					UserID:    mockUser.ID,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, 1, nil)
+				rtr.On("RevokeByID", 1).Return(1, nil)
+				ur.On("FindByID", mockUser.ID).Return(nil, 1, mockOtherError)
+			},
+			wantErr:     true,
+			expectedErr: mockOtherError,
+		},
+		{
+			name: "Success",
+			mock: func(rtr *mocks.IRefreshTokenRepository, ur *mocks.IUserRepository) {
+				rtr.On("FindByTokenHash", mockTokenHash).Return(&entity.RefreshToken{
+					Base:      entity.Base{ID: 1},
+					UserID:    mockUser.ID,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, 1, nil)
+				rtr.On("RevokeByID", 1).Return(1, nil)
+				ur.On("FindByID", mockUser.ID).Return(mockUser, 1, nil)
+				rtr.On("CreateRefreshToken", mock.MatchedBy(
+					func(refreshToken *entity.RefreshToken) bool {
+						return refreshToken.UserID == mockUser.ID &&
+							refreshToken.TokenHash != mockTokenHash
+					},
+				)).Return(&entity.RefreshToken{}, 1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refreshTokenRepository := mocks.NewIRefreshTokenRepository(t)
+			userRepository := mocks.NewIUserRepository(t)
+			s := &authService{
+				userRepository:         userRepository,
+				refreshTokenRepository: refreshTokenRepository,
+			}
+
+			tt.mock(refreshTokenRepository, userRepository)
+
+			got, err := s.Refresh(mockRefreshToken)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, got.IDToken)
+				assert.NotEqual(t, mockRefreshToken, got.RefreshToken)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_authService_Logout(t *testing.T) {
+	mockRefreshToken := "refresh-token"
+	mockTokenHash := helper.HashToken(mockRefreshToken)
+	mockExpiresAt := time.Now().Add(time.Hour)
+	mockClaims := &helper.IdTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti",
+			ExpiresAt: jwt.NewNumericDate(mockExpiresAt),
+		},
+		User: &entity.TokenizedUser{ID: 1},
+	}
+
+	tests := []struct {
+		name         string
+		refreshToken string
+		mock         func(*mocks.IRefreshTokenRepository, *mocks.IRevokedTokenRepository)
+		wantErr      bool
+		expectedErr  error
+	}{
+		{
+			name:         "Success | Revoke access token only",
+			refreshToken: "",
+			mock: func(rtr *mocks.IRefreshTokenRepository, vtr *mocks.IRevokedTokenRepository) {
+				vtr.On("CreateRevokedToken", mock.MatchedBy(
+					func(revokedToken *entity.RevokedToken) bool {
+						return revokedToken.JTI == "jti" &&
+							revokedToken.ExpiresAt.Equal(mockClaims.ExpiresAt.Time)
+					},
+				)).Return(&entity.RevokedToken{}, 1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+		{
+			name:         "Error | Refresh token of another user",
+			refreshToken: mockRefreshToken,
+			mock: func(rtr *mocks.IRefreshTokenRepository, vtr *mocks.IRevokedTokenRepository) {
+				vtr.On("CreateRevokedToken", mock.Anything).
+					Return(&entity.RevokedToken{}, 1, nil)
+				rtr.On("FindByTokenHash", mockTokenHash).
+					Return(&entity.RefreshToken{UserID: 2}, 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.InvalidToken{},
+		},
+		{
+			name:         "Success | Revoke access and refresh token",
+			refreshToken: mockRefreshToken,
+			mock: func(rtr *mocks.IRefreshTokenRepository, vtr *mocks.IRevokedTokenRepository) {
+				vtr.On("CreateRevokedToken", mock.Anything).
+					Return(&entity.RevokedToken{}, 1, nil)
+				rtr.On("FindByTokenHash", mockTokenHash).Return(&entity.RefreshToken{
+					Base:   entity.Base{ID: 3},
+					UserID: 1,
+				}, 1, nil)
+				rtr.On("RevokeByID", 3).Return(1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refreshTokenRepository := mocks.NewIRefreshTokenRepository(t)
+			revokedTokenRepository := mocks.NewIRevokedTokenRepository(t)
+			s := &authService{
+				refreshTokenRepository: refreshTokenRepository,
+				revokedTokenRepository: revokedTokenRepository,
+			}
+
+			tt.mock(refreshTokenRepository, revokedTokenRepository)
+
+			err := s.Logout(mockClaims, tt.refreshToken)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func Test_authService_IsTokenRevoked(t *testing.T) {
+	revokedTokenRepository := mocks.NewIRevokedTokenRepository(t)
+	revokedTokenRepository.On("FindByJTI", "revoked").
+		Return(&entity.RevokedToken{}, 1, nil)
+	revokedTokenRepository.On("FindByJTI", "active").
+		Return(nil, 0, nil)
+
+	s := &authService{
+		revokedTokenRepository: revokedTokenRepository,
+	}
+
+	revoked, err := s.IsTokenRevoked("revoked")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = s.IsTokenRevoked("active")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	revoked, err = s.IsTokenRevoked("")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
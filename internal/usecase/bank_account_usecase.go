@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+)
+
+type IBankAccountService interface {
+	CreateBankAccount(*entity.BankAccount) (*entity.BankAccount, error)
+	FindByUserID(int) ([]*entity.BankAccount, error)
+	DeleteBankAccount(int, int) error
+}
+
+type bankAccountService struct {
+	bankAccountRepository repository.IBankAccountRepository
+}
+
+func NewBankAccountService(
+	br repository.IBankAccountRepository,
+) IBankAccountService {
+	return &bankAccountService{
+		bankAccountRepository: br,
+	}
+}
+
+func (s *bankAccountService) CreateBankAccount(
+	bankAccount *entity.BankAccount,
+) (*entity.BankAccount, error) {
+	bankAccount, rowsAffected, err := s.bankAccountRepository.CreateBankAccount(
+		bankAccount,
+	)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.FailedToCreateData{DataType: "bank account"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bankAccount, nil
+}
+
+func (s *bankAccountService) FindByUserID(
+	userID int,
+) ([]*entity.BankAccount, error) {
+	bankAccounts, _, err := s.bankAccountRepository.FindByUserID(userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bankAccounts, nil
+}
+
+func (s *bankAccountService) DeleteBankAccount(id int, userID int) error {
+	rowsAffected, err := s.bankAccountRepository.DeleteByIDAndUserID(
+		id,
+		userID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &custom_error.NoDataFound{DataType: "bank account"}
+	}
+
+	return nil
+}
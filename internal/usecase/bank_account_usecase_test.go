@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_bankAccountService_CreateBankAccount(t *testing.T) {
+	mockBankAccount := &entity.BankAccount{
+		UserID:        1,
+		BankCode:      "BCA",
+		AccountNumber: "1234567890",
+		AccountName:   "Name",
+	}
+
+	tests := []struct {
+		name        string
+		mock        func(*mocks.IBankAccountRepository)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Failed to create bank account",
+			mock: func(br *mocks.IBankAccountRepository) {
+				br.On("CreateBankAccount", mockBankAccount).
+					Return(nil, 0, fmt.Errorf("error"))
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToCreateData{DataType: "bank account"},
+		},
+		{
+			name: "Success",
+			mock: func(br *mocks.IBankAccountRepository) {
+				br.On("CreateBankAccount", mockBankAccount).
+					Return(mockBankAccount, 1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			br := mocks.NewIBankAccountRepository(t)
+			s := NewBankAccountService(br)
+
+			tt.mock(br)
+
+			got, err := s.CreateBankAccount(mockBankAccount)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, mockBankAccount, got)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_bankAccountService_DeleteBankAccount(t *testing.T) {
+	tests := []struct {
+		name        string
+		mock        func(*mocks.IBankAccountRepository)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Bank account not found",
+			mock: func(br *mocks.IBankAccountRepository) {
+				br.On("DeleteByIDAndUserID", 1, 1).Return(0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "bank account"},
+		},
+		{
+			name: "Success",
+			mock: func(br *mocks.IBankAccountRepository) {
+				br.On("DeleteByIDAndUserID", 1, 1).Return(1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			br := mocks.NewIBankAccountRepository(t)
+			s := NewBankAccountService(br)
+
+			tt.mock(br)
+
+			err := s.DeleteBankAccount(1, 1)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
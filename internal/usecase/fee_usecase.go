@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+)
+
+type IFeeService interface {
+	Quote(*entity.Transaction) (*entity.Transaction, error)
+}
+
+type feeService struct {
+	feeRuleRepository repository.IFeeRuleRepository
+}
+
+func NewFeeService(fr repository.IFeeRuleRepository) IFeeService {
+	return &feeService{
+		feeRuleRepository: fr,
+	}
+}
+
+// Quote sets the fee a transaction of its type, currency, source of funds
+// and amount would be charged, without moving any money.
+func (s *feeService) Quote(
+	transaction *entity.Transaction,
+) (*entity.Transaction, error) {
+	fee, err := calculateFee(s.feeRuleRepository, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction.Fee = fee
+
+	return transaction, nil
+}
+
+// calculateFee returns the fee of the rule matching the transaction, or no
+// fee when no rule matches.
+func calculateFee(
+	fr repository.IFeeRuleRepository,
+	transaction *entity.Transaction,
+) (int, error) {
+	rule, rowsAffected, err := fr.FindMatching(
+		transaction.Type,
+		transaction.Currency,
+		transaction.SourceID,
+		transaction.Amount,
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if rowsAffected == 0 {
+		return 0, nil
+	}
+
+	return rule.Fee(transaction.Amount), nil
+}
+
+// collectFee credits a fee to the house wallet of its currency. It must run
+// in the unit of work that charged the fee, so both commit or roll back
+// together.
+func collectFee(
+	r *repository.Repositories,
+	currency entity.Currency,
+	fee int,
+) error {
+	if fee <= 0 {
+		return nil
+	}
+
+	_, rowsAffected, err := r.Wallets.IncrementBalanceByValue(
+		currency.HouseWalletNumber(),
+		fee,
+	)
+
+	if rowsAffected == 0 {
+		return &custom_error.FailedToUpdateData{
+			DataType: "house wallet balance",
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/ledger"
+	"assignment-golang-backend/internal/repository"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNoFees returns no fee rule for any transaction, for tests that are
+// not about fees.
+func MockNoFees(t *testing.T) *mocks.IFeeRuleRepository {
+	fr := mocks.NewIFeeRuleRepository(t)
+	fr.On("FindMatching", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, 0, nil).
+		Maybe()
+
+	return fr
+}
+
+func TestNewFeeService(t *testing.T) {
+	NewFeeService(mocks.NewIFeeRuleRepository(t))
+}
+
+func Test_feeService_Quote(t *testing.T) {
+	sourceID := entity.SourceOfFundsID(1)
+
+	tests := []struct {
+		name        string
+		transaction *entity.Transaction
+		rule        *entity.FeeRule
+		rowsFound   int
+		findErr     error
+		wantFee     int
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Other errors from fee rule repository",
+			transaction: &entity.Transaction{
+				Type:   entity.Transfer,
+				Amount: 100000,
+			},
+			rowsFound:   1,
+			findErr:     fmt.Errorf("error"),
+			wantErr:     true,
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name: "Success | No matching rule is free",
+			transaction: &entity.Transaction{
+				Type:   entity.Transfer,
+				Amount: 100000,
+			},
+			wantFee: 0,
+		},
+		{
+			name: "Success | Flat fee",
+			transaction: &entity.Transaction{
+				Type:     entity.TopUp,
+				Amount:   100000,
+				SourceID: &sourceID,
+			},
+			rule:      &entity.FeeRule{FlatFee: 2500},
+			rowsFound: 1,
+			wantFee:   2500,
+		},
+		{
+			name: "Success | Percentage fee rounded down",
+			transaction: &entity.Transaction{
+				Type:   entity.Transfer,
+				Amount: 123456,
+			},
+			rule:      &entity.FeeRule{PercentageBasisPoints: 150},
+			rowsFound: 1,
+			wantFee:   1851,
+		},
+		{
+			name: "Success | Flat and percentage fee",
+			transaction: &entity.Transaction{
+				Type:   entity.Transfer,
+				Amount: 100000,
+			},
+			rule: &entity.FeeRule{
+				FlatFee:               1000,
+				PercentageBasisPoints: 50,
+			},
+			rowsFound: 1,
+			wantFee:   1500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fr := mocks.NewIFeeRuleRepository(t)
+			fr.On(
+				"FindMatching",
+				tt.transaction.Type,
+				tt.transaction.Currency,
+				tt.transaction.SourceID,
+				tt.transaction.Amount,
+			).Return(tt.rule, tt.rowsFound, tt.findErr)
+			s := NewFeeService(fr)
+
+			got, err := s.Quote(tt.transaction)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantFee, got.Fee)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_createTransfer_fees(t *testing.T) {
+	newTransfer := func() *entity.Transaction {
+		return &entity.Transaction{
+			Amount:   100000,
+			Type:     entity.Transfer,
+			Datetime: time.Now(),
+			From:     1,
+			To:       100002,
+		}
+	}
+	withFee := func(transfer *entity.Transaction) *entity.Transaction {
+		transfer.Currency = entity.IDR
+		transfer.Fee = 1000
+		return transfer
+	}
+	mockFeeRule := &entity.FeeRule{Type: entity.Transfer, FlatFee: 1000}
+
+	tests := []struct {
+		name string
+		mock func(
+			tr *mocks.ITransactionRepository,
+			wr *mocks.IWalletRepository,
+			lr *mocks.ILedgerRepository,
+		)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Balance covers the amount but not the fee",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				wr.On("FindByNumber", 1).
+					Return(&entity.Wallet{Number: 1, Currency: entity.IDR, Balance: 100000}, 1, nil)
+				wr.On("FindByNumber", 100002).
+					Return(&entity.Wallet{Number: 100002, Currency: entity.IDR}, 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.InsufficientBalance{},
+		},
+		{
+			name: "Error | Failed to collect the fee into the house wallet",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				wr.On("FindByNumber", 1).
+					Return(&entity.Wallet{Number: 1, Currency: entity.IDR, Balance: 101000}, 1, nil)
+				wr.On("FindByNumber", 100002).
+					Return(&entity.Wallet{Number: 100002, Currency: entity.IDR}, 1, nil)
+				wr.On("DecrementBalanceByValue", 1, 101000).
+					Return(&entity.Wallet{Number: 1}, 1, nil)
+				wr.On("IncrementBalanceByValue", 100002, 100000).
+					Return(&entity.Wallet{Number: 100002, Balance: 100000}, 1, nil)
+				wr.On("IncrementBalanceByValue", entity.HouseWalletNumber, 1000).
+					Return(nil, 0, nil)
+			},
+			wantErr: true,
+			expectedErr: &custom_error.FailedToUpdateData{
+				DataType: "house wallet balance",
+			},
+		},
+		{
+			name: "Success | Sender pays the fee on top and the house wallet collects it",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				wr.On("FindByNumber", 1).
+					Return(&entity.Wallet{Number: 1, Currency: entity.IDR, Balance: 101000}, 1, nil)
+				wr.On("FindByNumber", 100002).
+					Return(&entity.Wallet{Number: 100002, Currency: entity.IDR}, 1, nil)
+				wr.On("DecrementBalanceByValue", 1, 101000).
+					Return(&entity.Wallet{Number: 1}, 1, nil)
+				wr.On("IncrementBalanceByValue", 100002, 100000).
+					Return(&entity.Wallet{Number: 100002, Balance: 100000}, 1, nil)
+				wr.On("IncrementBalanceByValue", entity.HouseWalletNumber, 1000).
+					Return(&entity.Wallet{Number: entity.HouseWalletNumber}, 1, nil)
+				tr.On("CreateTransaction", mock.MatchedBy(func(transfer *entity.Transaction) bool {
+					return transfer.Amount == 100000 && transfer.Fee == 1000
+				})).
+					Return(func(transfer *entity.Transaction) *entity.Transaction {
+						return transfer
+					}, 1, nil)
+				lr.On("CreateJournalEntry", mock.MatchedBy(func(entry *entity.JournalEntry) bool {
+					return assert.ObjectsAreEqual(
+						ledger.NewTransferEntry(withFee(newTransfer())).Postings,
+						entry.Postings,
+					)
+				})).
+					Return(&entity.JournalEntry{}, 1, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := mocks.NewITransactionRepository(t)
+			wr := mocks.NewIWalletRepository(t)
+			lr := mocks.NewILedgerRepository(t)
+			fr := mocks.NewIFeeRuleRepository(t)
+			fr.On("FindMatching", entity.Transfer, entity.IDR, (*entity.SourceOfFundsID)(nil), 100000).
+				Return(mockFeeRule, 1, nil)
+
+			tt.mock(tr, wr, lr)
+
+			got, err := createTransfer(&repository.Repositories{
+				Transactions: tr,
+				Wallets:      wr,
+				Ledger:       lr,
+				Limits:       MockPermissiveLimits(t),
+				FeeRules:     fr,
+			}, mocks.NewIRateProvider(t), newTransfer())
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, 1000, got.Fee)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_createTransfer_ownMoveIsFree(t *testing.T) {
+	userID := 1
+	tr := mocks.NewITransactionRepository(t)
+	wr := mocks.NewIWalletRepository(t)
+	lr := mocks.NewILedgerRepository(t)
+	limits := mocks.NewITransactionLimitRepository(t)
+	wr.On("FindByNumber", 1).
+		Return(&entity.Wallet{Number: 1, UserID: &userID, Balance: 100000}, 1, nil)
+	wr.On("FindByNumber", 100002).
+		Return(&entity.Wallet{Number: 100002, UserID: &userID}, 1, nil)
+	wr.On("DecrementBalanceByValue", 1, 100000).
+		Return(&entity.Wallet{Number: 1}, 1, nil)
+	wr.On("IncrementBalanceByValue", 100002, 100000).
+		Return(&entity.Wallet{Number: 100002, Balance: 100000}, 1, nil)
+	limits.On("FindByWalletNumber", 100002).Return(&entity.TransactionLimit{
+		MaxBalance: 2000000,
+	}, 1, nil)
+	tr.On("CreateTransaction", mock.Anything).
+		Return(func(transfer *entity.Transaction) *entity.Transaction {
+			return transfer
+		}, 1, nil)
+	lr.On("CreateJournalEntry", mock.Anything).
+		Return(&entity.JournalEntry{}, 1, nil)
+
+	got, err := createTransfer(&repository.Repositories{
+		Transactions: tr,
+		Wallets:      wr,
+		Ledger:       lr,
+		Limits:       limits,
+		FeeRules:     mocks.NewIFeeRuleRepository(t),
+	}, mocks.NewIRateProvider(t), &entity.Transaction{
+		Amount:   100000,
+		Type:     entity.Transfer,
+		Datetime: time.Now(),
+		From:     1,
+		To:       100002,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got.Fee)
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+)
+
+type IIdempotencyService interface {
+	Reserve(int, string, string) (*entity.IdempotencyKey, error)
+	Complete(*entity.IdempotencyKey, int, []byte) error
+	Release(*entity.IdempotencyKey) error
+}
+
+type idempotencyService struct {
+	idempotencyKeyRepository repository.IIdempotencyKeyRepository
+}
+
+func NewIdempotencyService(
+	ir repository.IIdempotencyKeyRepository,
+) IIdempotencyService {
+	return &idempotencyService{
+		idempotencyKeyRepository: ir,
+	}
+}
+
+// Reserve claims the key for the user. A key without a stored response
+// means the caller should process the request; a key with a stored response
+// should be replayed as is.
+func (s *idempotencyService) Reserve(
+	userID int,
+	key, requestHash string,
+) (*entity.IdempotencyKey, error) {
+	idempotencyKey, rowsAffected, err := s.idempotencyKeyRepository.CreateKey(
+		&entity.IdempotencyKey{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash,
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected != 0 {
+		return idempotencyKey, nil
+	}
+
+	idempotencyKey, rowsAffected, err = s.idempotencyKeyRepository.FindByUserIDAndKey(
+		userID,
+		key,
+	)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "idempotency key"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if idempotencyKey.RequestHash != requestHash {
+		return nil, &custom_error.IdempotencyKeyReused{}
+	}
+
+	if idempotencyKey.ResponseCode == 0 {
+		return nil, &custom_error.IdempotentRequestInProgress{}
+	}
+
+	return idempotencyKey, nil
+}
+
+func (s *idempotencyService) Complete(
+	idempotencyKey *entity.IdempotencyKey,
+	responseCode int,
+	responseBody []byte,
+) error {
+	idempotencyKey.ResponseCode = responseCode
+	idempotencyKey.ResponseBody = responseBody
+
+	_, rowsAffected, err := s.idempotencyKeyRepository.UpdateResponse(
+		idempotencyKey,
+	)
+
+	if rowsAffected == 0 {
+		return &custom_error.FailedToUpdateData{DataType: "idempotency key"}
+	}
+
+	return err
+}
+
+func (s *idempotencyService) Release(
+	idempotencyKey *entity.IdempotencyKey,
+) error {
+	_, err := s.idempotencyKeyRepository.DeleteKey(idempotencyKey)
+	return err
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIdempotencyService(t *testing.T) {
+	NewIdempotencyService(mocks.NewIIdempotencyKeyRepository(t))
+}
+
+func Test_idempotencyService_Reserve(t *testing.T) {
+	mockNewKey := &entity.IdempotencyKey{
+		UserID:      1,
+		Key:         "key",
+		RequestHash: "hash",
+	}
+	mockStoredKey := &entity.IdempotencyKey{
+		UserID:       1,
+		Key:          "key",
+		RequestHash:  "hash",
+		ResponseCode: 200,
+		ResponseBody: []byte("{}"),
+	}
+	mockInProgressKey := &entity.IdempotencyKey{
+		UserID:      1,
+		Key:         "key",
+		RequestHash: "hash",
+	}
+	mockOtherError := fmt.Errorf("error")
+
+	tests := []struct {
+		name                     string
+		idempotencyKeyRepository *mocks.IIdempotencyKeyRepository
+		mock                     func(*mocks.IIdempotencyKeyRepository)
+		requestHash              string
+		want                     *entity.IdempotencyKey
+		wantErr                  bool
+		expectedErr              error
+	}{
+		{
+			name:                     "Error | Other error when creating key",
+			idempotencyKeyRepository: mocks.NewIIdempotencyKeyRepository(t),
+			mock: func(ir *mocks.IIdempotencyKeyRepository) {
+				ir.On("CreateKey", mockNewKey).Return(nil, 0, mockOtherError)
+			},
+			requestHash: "hash",
+			want:        nil,
+			wantErr:     true,
+			expectedErr: mockOtherError,
+		},
+		{
+			name:                     "Error | Key used with a different request",
+			idempotencyKeyRepository: mocks.NewIIdempotencyKeyRepository(t),
+			mock: func(ir *mocks.IIdempotencyKeyRepository) {
+				ir.On("CreateKey", &entity.IdempotencyKey{
+					UserID:      1,
+					Key:         "key",
+					RequestHash: "other hash",
+				}).Return(nil, 0, nil)
+				ir.On("FindByUserIDAndKey", 1, "key").
+					Return(mockStoredKey, 1, nil)
+			},
+			requestHash: "other hash",
+			want:        nil,
+			wantErr:     true,
+			expectedErr: &custom_error.IdempotencyKeyReused{},
+		},
+		{
+			name:                     "Error | Key is still in progress",
+			idempotencyKeyRepository: mocks.NewIIdempotencyKeyRepository(t),
+			mock: func(ir *mocks.IIdempotencyKeyRepository) {
+				ir.On("CreateKey", mockNewKey).Return(nil, 0, nil)
+				ir.On("FindByUserIDAndKey", 1, "key").
+					Return(mockInProgressKey, 1, nil)
+			},
+			requestHash: "hash",
+			want:        nil,
+			wantErr:     true,
+			expectedErr: &custom_error.IdempotentRequestInProgress{},
+		},
+		{
+			name:                     "Error | Key disappeared after conflict",
+			idempotencyKeyRepository: mocks.NewIIdempotencyKeyRepository(t),
+			mock: func(ir *mocks.IIdempotencyKeyRepository) {
+				ir.On("CreateKey", mockNewKey).Return(nil, 0, nil)
+				ir.On("FindByUserIDAndKey", 1, "key").Return(nil, 0, nil)
+			},
+			requestHash: "hash",
+			want:        nil,
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "idempotency key"},
+		},
+		{
+			name:                     "Success | New key reserved",
+			idempotencyKeyRepository: mocks.NewIIdempotencyKeyRepository(t),
+			mock: func(ir *mocks.IIdempotencyKeyRepository) {
+				ir.On("CreateKey", mockNewKey).Return(mockNewKey, 1, nil)
+			},
+			requestHash: "hash",
+			want:        mockNewKey,
+			wantErr:     false,
+			expectedErr: nil,
+		},
+		{
+			name:                     "Success | Stored response replayed",
+			idempotencyKeyRepository: mocks.NewIIdempotencyKeyRepository(t),
+			mock: func(ir *mocks.IIdempotencyKeyRepository) {
+				ir.On("CreateKey", mockNewKey).Return(nil, 0, nil)
+				ir.On("FindByUserIDAndKey", 1, "key").
+					Return(mockStoredKey, 1, nil)
+			},
+			requestHash: "hash",
+			want:        mockStoredKey,
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &idempotencyService{
+				idempotencyKeyRepository: tt.idempotencyKeyRepository,
+			}
+
+			tt.mock(tt.idempotencyKeyRepository)
+
+			got, err := s.Reserve(1, "key", tt.requestHash)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_idempotencyService_Complete(t *testing.T) {
+	tests := []struct {
+		name                     string
+		idempotencyKeyRepository *mocks.IIdempotencyKeyRepository
+		mock                     func(*mocks.IIdempotencyKeyRepository)
+		wantErr                  bool
+		expectedErr              error
+	}{
+		{
+			name:                     "Error | Failed to update key",
+			idempotencyKeyRepository: mocks.NewIIdempotencyKeyRepository(t),
+			mock: func(ir *mocks.IIdempotencyKeyRepository) {
+				ir.On("UpdateResponse", &entity.IdempotencyKey{
+					ResponseCode: 200,
+					ResponseBody: []byte("{}"),
+				}).Return(nil, 0, nil)
+			},
+			wantErr: true,
+			expectedErr: &custom_error.FailedToUpdateData{
+				DataType: "idempotency key",
+			},
+		},
+		{
+			name:                     "Success",
+			idempotencyKeyRepository: mocks.NewIIdempotencyKeyRepository(t),
+			mock: func(ir *mocks.IIdempotencyKeyRepository) {
+				ir.On("UpdateResponse", &entity.IdempotencyKey{
+					ResponseCode: 200,
+					ResponseBody: []byte("{}"),
+				}).Return(&entity.IdempotencyKey{}, 1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &idempotencyService{
+				idempotencyKeyRepository: tt.idempotencyKeyRepository,
+			}
+
+			tt.mock(tt.idempotencyKeyRepository)
+
+			err := s.Complete(&entity.IdempotencyKey{}, 200, []byte("{}"))
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func Test_idempotencyService_Release(t *testing.T) {
+	ir := mocks.NewIIdempotencyKeyRepository(t)
+	ir.On("DeleteKey", &entity.IdempotencyKey{}).Return(1, nil)
+
+	s := &idempotencyService{
+		idempotencyKeyRepository: ir,
+	}
+
+	assert.NoError(t, s.Release(&entity.IdempotencyKey{}))
+}
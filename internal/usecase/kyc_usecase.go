@@ -0,0 +1,336 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/repository"
+	"assignment-golang-backend/internal/storage"
+)
+
+const (
+	MAX_KYC_DOCUMENT_SIZE = 5 << 20
+)
+
+// kycDocumentExtensions are the content types accepted for KYC documents
+// and the extension each is stored with.
+var kycDocumentExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+type IKycService interface {
+	Submit(*entity.KycSubmission, io.Reader, io.Reader) (*entity.KycSubmission, error)
+	FindLatest(int) (*entity.KycSubmission, error)
+	FindPending() ([]*entity.KycSubmission, error)
+	Approve(int, int) (*entity.KycSubmission, error)
+	Reject(int, int, string) (*entity.KycSubmission, error)
+	OpenDocument(int, entity.KycDocument) (io.ReadCloser, string, error)
+}
+
+type kycService struct {
+	userRepository          repository.IUserRepository
+	kycSubmissionRepository repository.IKycSubmissionRepository
+	unitOfWork              repository.IUnitOfWork
+	fileStorage             storage.IFileStorage
+}
+
+func NewKycService(
+	ur repository.IUserRepository,
+	kr repository.IKycSubmissionRepository,
+	uow repository.IUnitOfWork,
+	fs storage.IFileStorage,
+) IKycService {
+	return &kycService{
+		userRepository:          ur,
+		kycSubmissionRepository: kr,
+		unitOfWork:              uow,
+		fileStorage:             fs,
+	}
+}
+
+// Submit stores the documents of a KYC submission and puts the user's KYC
+// status to PENDING until an admin reviews it. Only UNVERIFIED users and
+// users whose last submission was REJECTED can submit.
+func (s *kycService) Submit(
+	submission *entity.KycSubmission,
+	identityDocument io.Reader,
+	selfie io.Reader,
+) (*entity.KycSubmission, error) {
+	user, rowsAffected, err := s.userRepository.FindByID(submission.UserID)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "user"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user.KycStatus == entity.KycPending || user.KycStatus == entity.KycVerified {
+		return nil, &custom_error.KycNotSubmittable{Status: string(user.KycStatus)}
+	}
+
+	identityDocumentPath, err := s.saveDocument(
+		submission.UserID,
+		identityDocument,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	selfiePath, err := s.saveDocument(submission.UserID, selfie)
+	if err != nil {
+		s.fileStorage.Delete(identityDocumentPath)
+		return nil, err
+	}
+
+	submission.IdentityDocumentPath = identityDocumentPath
+	submission.SelfiePath = selfiePath
+
+	submission.Status = entity.KycPending
+	err = s.unitOfWork.WithinTransaction(func(r *repository.Repositories) error {
+		rowsAffected, err := r.Users.UpdateKycStatus(
+			submission.UserID,
+			entity.KycPending,
+			entity.KycUnverified,
+			entity.KycRejected,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return &custom_error.KycNotSubmittable{
+				Status: string(entity.KycPending),
+			}
+		}
+
+		submission, rowsAffected, err = r.KycSubmissions.CreateKycSubmission(
+			submission,
+		)
+
+		if rowsAffected == 0 {
+			return &custom_error.FailedToCreateData{DataType: "KYC submission"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		s.fileStorage.Delete(identityDocumentPath)
+		s.fileStorage.Delete(selfiePath)
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// saveDocument checks the size and the content type of a document before
+// storing it under a random name, and returns the name.
+func (s *kycService) saveDocument(userID int, document io.Reader) (string, error) {
+	content, err := io.ReadAll(io.LimitReader(document, MAX_KYC_DOCUMENT_SIZE+1))
+	if err != nil {
+		return "", err
+	}
+
+	if len(content) > MAX_KYC_DOCUMENT_SIZE {
+		return "", &custom_error.InvalidKycDocument{}
+	}
+
+	extension, ok := kycDocumentExtensions[http.DetectContentType(content)]
+	if !ok {
+		return "", &custom_error.InvalidKycDocument{}
+	}
+
+	token, err := helper.GenerateRandomToken()
+	if err != nil {
+		return "", err
+	}
+
+	name := path.Join("kyc", fmt.Sprint(userID), token+extension)
+	err = s.fileStorage.Save(name, bytes.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+func (s *kycService) FindLatest(userID int) (*entity.KycSubmission, error) {
+	submission, rowsAffected, err := s.kycSubmissionRepository.FindLatestByUserID(
+		userID,
+	)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "KYC submission"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+func (s *kycService) FindPending() ([]*entity.KycSubmission, error) {
+	submissions, _, err := s.kycSubmissionRepository.FindByStatus(
+		entity.KycPending,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return submissions, nil
+}
+
+// Approve verifies the user of a pending submission, which moves the user
+// to the VERIFIED tier and its transaction limits.
+func (s *kycService) Approve(
+	id int,
+	reviewerID int,
+) (*entity.KycSubmission, error) {
+	return s.review(id, reviewerID, entity.KycVerified, "")
+}
+
+// Reject turns down a pending submission. The user stays on their tier and
+// can submit again.
+func (s *kycService) Reject(
+	id int,
+	reviewerID int,
+	reason string,
+) (*entity.KycSubmission, error) {
+	return s.review(id, reviewerID, entity.KycRejected, reason)
+}
+
+func (s *kycService) review(
+	id int,
+	reviewerID int,
+	status entity.KycStatus,
+	reason string,
+) (*entity.KycSubmission, error) {
+	var submission *entity.KycSubmission
+	err := s.unitOfWork.WithinTransaction(func(r *repository.Repositories) error {
+		var rowsAffected int
+		var err error
+
+		submission, rowsAffected, err = r.KycSubmissions.FindByID(id)
+
+		if rowsAffected == 0 {
+			return &custom_error.NoDataFound{DataType: "KYC submission"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if submission.UserID == reviewerID {
+			return &custom_error.CannotReviewOwnKyc{}
+		}
+
+		if submission.Status != entity.KycPending {
+			return &custom_error.KycSubmissionNotPending{
+				Status: string(submission.Status),
+			}
+		}
+
+		reviewedAt := time.Now()
+		submission.Status = status
+		submission.RejectionReason = reason
+		submission.ReviewedBy = &reviewerID
+		submission.ReviewedAt = &reviewedAt
+
+		rowsAffected, err = r.KycSubmissions.UpdateReview(submission)
+
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return &custom_error.FailedToUpdateData{DataType: "KYC submission"}
+		}
+
+		rowsAffected, err = r.Users.UpdateKycStatus(
+			submission.UserID,
+			status,
+			entity.KycPending,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return &custom_error.FailedToUpdateData{DataType: "user"}
+		}
+
+		if status != entity.KycVerified {
+			return nil
+		}
+
+		rowsAffected, err = r.Users.UpdateTier(
+			submission.UserID,
+			entity.VerifiedTier,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return &custom_error.FailedToUpdateData{DataType: "user"}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// OpenDocument opens a document of a submission for a reviewer and returns
+// its content type. The caller closes it.
+func (s *kycService) OpenDocument(
+	id int,
+	document entity.KycDocument,
+) (io.ReadCloser, string, error) {
+	submission, rowsAffected, err := s.kycSubmissionRepository.FindByID(id)
+
+	if rowsAffected == 0 {
+		return nil, "", &custom_error.NoDataFound{DataType: "KYC submission"}
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	name, ok := submission.DocumentPath(document)
+	if !ok {
+		return nil, "", &custom_error.NoDataFound{DataType: "KYC document"}
+	}
+
+	file, err := s.fileStorage.Open(name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return file, mime.TypeByExtension(path.Ext(name)), nil
+}
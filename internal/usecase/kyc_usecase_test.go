@@ -0,0 +1,384 @@
+package usecase
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const mockPng = "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+type kycDependencies struct {
+	userRepository          *mocks.IUserRepository
+	kycSubmissionRepository *mocks.IKycSubmissionRepository
+	fileStorage             *mocks.IFileStorage
+}
+
+func newKycDependencies(t *testing.T) *kycDependencies {
+	return &kycDependencies{
+		userRepository:          mocks.NewIUserRepository(t),
+		kycSubmissionRepository: mocks.NewIKycSubmissionRepository(t),
+		fileStorage:             mocks.NewIFileStorage(t),
+	}
+}
+
+func (d *kycDependencies) service(t *testing.T, withUnitOfWork bool) IKycService {
+	uow := mocks.NewIUnitOfWork(t)
+	if withUnitOfWork {
+		uow.On("WithinTransaction", mock.Anything).
+			Return(func(fn func(*repository.Repositories) error) error {
+				return fn(&repository.Repositories{
+					Users:          d.userRepository,
+					KycSubmissions: d.kycSubmissionRepository,
+				})
+			})
+	}
+
+	return NewKycService(
+		d.userRepository,
+		d.kycSubmissionRepository,
+		uow,
+		d.fileStorage,
+	)
+}
+
+func Test_kycService_Submit(t *testing.T) {
+	newSubmission := func() *entity.KycSubmission {
+		return &entity.KycSubmission{
+			UserID:         1,
+			FullName:       "Full Name",
+			IdentityNumber: "3171234567890001",
+		}
+	}
+	isDocumentName := mock.MatchedBy(func(name string) bool {
+		return strings.HasPrefix(name, "kyc/1/") && strings.HasSuffix(name, ".png")
+	})
+	created := func(submission *entity.KycSubmission) *entity.KycSubmission {
+		return submission
+	}
+
+	tests := []struct {
+		name             string
+		identityDocument string
+		mock             func(*kycDependencies)
+		noUnitOfWork     bool
+		wantErr          bool
+		expectedErr      error
+	}{
+		{
+			name:             "Error | User not found",
+			identityDocument: mockPng,
+			mock: func(d *kycDependencies) {
+				d.userRepository.On("FindByID", 1).Return(nil, 0, nil)
+			},
+			noUnitOfWork: true,
+			wantErr:      true,
+			expectedErr:  &custom_error.NoDataFound{DataType: "user"},
+		},
+		{
+			name:             "Error | KYC already verified",
+			identityDocument: mockPng,
+			mock: func(d *kycDependencies) {
+				d.userRepository.On("FindByID", 1).
+					Return(&entity.User{KycStatus: entity.KycVerified}, 1, nil)
+			},
+			noUnitOfWork: true,
+			wantErr:      true,
+			expectedErr:  &custom_error.KycNotSubmittable{Status: "VERIFIED"},
+		},
+		{
+			name:             "Error | Document is not an image or a PDF",
+			identityDocument: "plain text",
+			mock: func(d *kycDependencies) {
+				d.userRepository.On("FindByID", 1).
+					Return(&entity.User{KycStatus: entity.KycUnverified}, 1, nil)
+			},
+			noUnitOfWork: true,
+			wantErr:      true,
+			expectedErr:  &custom_error.InvalidKycDocument{},
+		},
+		{
+			name:             "Error | Document is too large",
+			identityDocument: mockPng + strings.Repeat("0", MAX_KYC_DOCUMENT_SIZE),
+			mock: func(d *kycDependencies) {
+				d.userRepository.On("FindByID", 1).
+					Return(&entity.User{KycStatus: entity.KycUnverified}, 1, nil)
+			},
+			noUnitOfWork: true,
+			wantErr:      true,
+			expectedErr:  &custom_error.InvalidKycDocument{},
+		},
+		{
+			name:             "Error | Failed to save the selfie",
+			identityDocument: mockPng,
+			mock: func(d *kycDependencies) {
+				d.userRepository.On("FindByID", 1).
+					Return(&entity.User{KycStatus: entity.KycUnverified}, 1, nil)
+				d.fileStorage.On("Save", isDocumentName, mock.Anything).
+					Return(nil).Once()
+				d.fileStorage.On("Save", isDocumentName, mock.Anything).
+					Return(fmt.Errorf("error")).Once()
+				d.fileStorage.On("Delete", isDocumentName).Return(nil).Once()
+			},
+			noUnitOfWork: true,
+			wantErr:      true,
+			expectedErr:  fmt.Errorf("error"),
+		},
+		{
+			name:             "Error | Submitted concurrently",
+			identityDocument: mockPng,
+			mock: func(d *kycDependencies) {
+				d.userRepository.On("FindByID", 1).
+					Return(&entity.User{KycStatus: entity.KycRejected}, 1, nil)
+				d.fileStorage.On("Save", isDocumentName, mock.Anything).Return(nil)
+				d.userRepository.On(
+					"UpdateKycStatus",
+					1,
+					entity.KycPending,
+					entity.KycUnverified,
+					entity.KycRejected,
+				).Return(0, nil)
+				d.fileStorage.On("Delete", isDocumentName).Return(nil).Twice()
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.KycNotSubmittable{Status: "PENDING"},
+		},
+		{
+			name:             "Success",
+			identityDocument: mockPng,
+			mock: func(d *kycDependencies) {
+				d.userRepository.On("FindByID", 1).
+					Return(&entity.User{KycStatus: entity.KycUnverified}, 1, nil)
+				d.fileStorage.On("Save", isDocumentName, mock.Anything).Return(nil)
+				d.userRepository.On(
+					"UpdateKycStatus",
+					1,
+					entity.KycPending,
+					entity.KycUnverified,
+					entity.KycRejected,
+				).Return(1, nil)
+				d.kycSubmissionRepository.On(
+					"CreateKycSubmission",
+					mock.MatchedBy(func(submission *entity.KycSubmission) bool {
+						return submission.Status == entity.KycPending &&
+							submission.IdentityDocumentPath != "" &&
+							submission.SelfiePath != "" &&
+							submission.IdentityDocumentPath != submission.SelfiePath
+					}),
+				).Return(created, 1, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newKycDependencies(t)
+			s := d.service(t, !tt.noUnitOfWork)
+
+			tt.mock(d)
+
+			got, err := s.Submit(
+				newSubmission(),
+				strings.NewReader(tt.identityDocument),
+				strings.NewReader(mockPng),
+			)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.KycPending, got.Status)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_kycService_review(t *testing.T) {
+	newPending := func() *entity.KycSubmission {
+		return &entity.KycSubmission{
+			Base:   entity.Base{ID: 1},
+			UserID: 2,
+			Status: entity.KycPending,
+		}
+	}
+	isReviewed := func(status entity.KycStatus) interface{} {
+		return mock.MatchedBy(func(submission *entity.KycSubmission) bool {
+			return submission.Status == status &&
+				*submission.ReviewedBy == 1 &&
+				submission.ReviewedAt != nil
+		})
+	}
+
+	tests := []struct {
+		name        string
+		approve     bool
+		reviewerID  int
+		mock        func(*kycDependencies)
+		wantStatus  entity.KycStatus
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:       "Error | Submission not found",
+			approve:    true,
+			reviewerID: 1,
+			mock: func(d *kycDependencies) {
+				d.kycSubmissionRepository.On("FindByID", 1).Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "KYC submission"},
+		},
+		{
+			name:       "Error | Reviewer's own submission",
+			approve:    true,
+			reviewerID: 2,
+			mock: func(d *kycDependencies) {
+				d.kycSubmissionRepository.On("FindByID", 1).
+					Return(newPending(), 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.CannotReviewOwnKyc{},
+		},
+		{
+			name:       "Error | Submission already reviewed",
+			approve:    false,
+			reviewerID: 1,
+			mock: func(d *kycDependencies) {
+				submission := newPending()
+				submission.Status = entity.KycVerified
+				d.kycSubmissionRepository.On("FindByID", 1).
+					Return(submission, 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.KycSubmissionNotPending{Status: "VERIFIED"},
+		},
+		{
+			name:       "Error | Reviewed concurrently",
+			approve:    true,
+			reviewerID: 1,
+			mock: func(d *kycDependencies) {
+				d.kycSubmissionRepository.On("FindByID", 1).
+					Return(newPending(), 1, nil)
+				d.kycSubmissionRepository.On("UpdateReview", isReviewed(entity.KycVerified)).
+					Return(0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToUpdateData{DataType: "KYC submission"},
+		},
+		{
+			name:       "Error | Other errors from user repository",
+			approve:    true,
+			reviewerID: 1,
+			mock: func(d *kycDependencies) {
+				d.kycSubmissionRepository.On("FindByID", 1).
+					Return(newPending(), 1, nil)
+				d.kycSubmissionRepository.On("UpdateReview", isReviewed(entity.KycVerified)).
+					Return(1, nil)
+				d.userRepository.On("UpdateKycStatus", 2, entity.KycVerified, entity.KycPending).
+					Return(1, nil)
+				d.userRepository.On("UpdateTier", 2, entity.VerifiedTier).
+					Return(0, fmt.Errorf("error"))
+			},
+			wantErr:     true,
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name:       "Success | Approve moves the user to the verified tier",
+			approve:    true,
+			reviewerID: 1,
+			mock: func(d *kycDependencies) {
+				d.kycSubmissionRepository.On("FindByID", 1).
+					Return(newPending(), 1, nil)
+				d.kycSubmissionRepository.On("UpdateReview", isReviewed(entity.KycVerified)).
+					Return(1, nil)
+				d.userRepository.On("UpdateKycStatus", 2, entity.KycVerified, entity.KycPending).
+					Return(1, nil)
+				d.userRepository.On("UpdateTier", 2, entity.VerifiedTier).
+					Return(1, nil)
+			},
+			wantStatus: entity.KycVerified,
+		},
+		{
+			name:       "Success | Reject keeps the tier",
+			approve:    false,
+			reviewerID: 1,
+			mock: func(d *kycDependencies) {
+				d.kycSubmissionRepository.On("FindByID", 1).
+					Return(newPending(), 1, nil)
+				d.kycSubmissionRepository.On("UpdateReview", isReviewed(entity.KycRejected)).
+					Return(1, nil)
+				d.userRepository.On("UpdateKycStatus", 2, entity.KycRejected, entity.KycPending).
+					Return(1, nil)
+			},
+			wantStatus: entity.KycRejected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newKycDependencies(t)
+			s := d.service(t, true)
+
+			tt.mock(d)
+
+			var got *entity.KycSubmission
+			var err error
+			if tt.approve {
+				got, err = s.Approve(1, tt.reviewerID)
+			} else {
+				got, err = s.Reject(1, tt.reviewerID, "Blurry document")
+			}
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, got.Status)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_kycService_OpenDocument(t *testing.T) {
+	mockSubmission := &entity.KycSubmission{
+		Base:                 entity.Base{ID: 1},
+		IdentityDocumentPath: "kyc/2/document.pdf",
+		SelfiePath:           "kyc/2/selfie.png",
+	}
+
+	t.Run("Error | Unknown document", func(t *testing.T) {
+		d := newKycDependencies(t)
+		d.kycSubmissionRepository.On("FindByID", 1).Return(mockSubmission, 1, nil)
+
+		got, contentType, err := d.service(t, false).OpenDocument(1, "passport")
+
+		assert.EqualError(t, err, custom_error.NoDataFound{DataType: "KYC document"}.Error())
+		assert.Nil(t, got)
+		assert.Empty(t, contentType)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		d := newKycDependencies(t)
+		d.kycSubmissionRepository.On("FindByID", 1).Return(mockSubmission, 1, nil)
+		d.fileStorage.On("Open", "kyc/2/document.pdf").
+			Return(io.NopCloser(strings.NewReader("document")), nil)
+
+		got, contentType, err := d.service(t, false).OpenDocument(
+			1,
+			entity.KycIdentityDocument,
+		)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Equal(t, "application/pdf", contentType)
+	})
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/ledger"
+	"assignment-golang-backend/internal/repository"
+)
+
+// postJournalEntry writes a balanced journal entry through the given
+// repositories, so it commits or rolls back with the money movement it
+// records.
+func postJournalEntry(
+	r *repository.Repositories,
+	journalEntry *entity.JournalEntry,
+) error {
+	if !ledger.IsBalanced(journalEntry) {
+		return &custom_error.UnbalancedJournalEntry{}
+	}
+
+	_, rowsAffected, err := r.Ledger.CreateJournalEntry(journalEntry)
+
+	if rowsAffected == 0 {
+		return &custom_error.FailedToCreateData{DataType: "journal entry"}
+	}
+
+	return err
+}
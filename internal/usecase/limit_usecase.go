@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/repository"
+)
+
+// findTransactionLimit returns the limits of the tier of the wallet's user.
+func findTransactionLimit(
+	lr repository.ITransactionLimitRepository,
+	walletNumber int,
+) (*entity.TransactionLimit, error) {
+	limit, rowsAffected, err := lr.FindByWalletNumber(walletNumber)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "transaction limit"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return limit, nil
+}
+
+func checkTransferAmount(limit *entity.TransactionLimit, amount int) error {
+	if !helper.IsBetweenRange(
+		amount,
+		limit.MinTransferAmount,
+		limit.MaxTransferAmount,
+	) {
+		return &custom_error.AmountNotInRange{
+			Minimum: limit.MinTransferAmount,
+			Maximum: limit.MaxTransferAmount,
+		}
+	}
+
+	return nil
+}
+
+// checkWalletTransferAmount checks amount against the transfer limits of
+// the wallet sending it.
+func checkWalletTransferAmount(
+	lr repository.ITransactionLimitRepository,
+	walletNumber int,
+	amount int,
+) error {
+	limit, err := findTransactionLimit(lr, walletNumber)
+	if err != nil {
+		return err
+	}
+
+	return checkTransferAmount(limit, amount)
+}
+
+func checkWithdrawalAmount(limit *entity.TransactionLimit, amount int) error {
+	if !helper.IsBetweenRange(
+		amount,
+		limit.MinWithdrawalAmount,
+		limit.MaxWithdrawalAmount,
+	) {
+		return &custom_error.AmountNotInRange{
+			Minimum: limit.MinWithdrawalAmount,
+			Maximum: limit.MaxWithdrawalAmount,
+		}
+	}
+
+	return nil
+}
+
+// checkOutgoingLimits fails when sending amount from the wallet at now
+// goes over its daily or monthly limit. It must run in the unit of work
+// that debited the wallet, after the debit. The limits are shared by all
+// the wallets of the user, so it locks the user's row before summing, and
+// concurrent payments from any of their wallets are summed one after the
+// other.
+func checkOutgoingLimits(
+	lr repository.ITransactionLimitRepository,
+	limit *entity.TransactionLimit,
+	walletNumber int,
+	amount int,
+	now time.Time,
+) error {
+	rowsAffected, err := lr.LockOwnerOfWallet(walletNumber)
+
+	if rowsAffected == 0 {
+		return &custom_error.NoDataFound{DataType: "user"}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	startOfDay := time.Date(
+		now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location(),
+	)
+	sentToday, err := lr.SumOutgoingSince(walletNumber, startOfDay)
+	if err != nil {
+		return err
+	}
+
+	if sentToday+amount > limit.DailyOutgoingLimit {
+		return &custom_error.DailyLimitExceeded{
+			Remaining: remainingLimit(limit.DailyOutgoingLimit, sentToday),
+		}
+	}
+
+	startOfMonth := time.Date(
+		now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location(),
+	)
+	sentThisMonth, err := lr.SumOutgoingSince(walletNumber, startOfMonth)
+	if err != nil {
+		return err
+	}
+
+	if sentThisMonth+amount > limit.MonthlyOutgoingLimit {
+		return &custom_error.MonthlyLimitExceeded{
+			Remaining: remainingLimit(limit.MonthlyOutgoingLimit, sentThisMonth),
+		}
+	}
+
+	return nil
+}
+
+// checkDestinationBalance fails when a wallet that just received money
+// holds more than its limit. The sender is not told the receiver's
+// headroom.
+func checkDestinationBalance(
+	lr repository.ITransactionLimitRepository,
+	wallet *entity.Wallet,
+) error {
+	limit, err := findTransactionLimit(lr, wallet.Number)
+	if err != nil {
+		return err
+	}
+
+	if wallet.Balance > limit.MaxBalance {
+		return &custom_error.DestinationBalanceLimitExceeded{}
+	}
+
+	return nil
+}
+
+func remainingLimit(limit int, used int) int {
+	if used >= limit {
+		return 0
+	}
+
+	return limit - used
+}
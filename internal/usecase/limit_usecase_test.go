@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var mockTransactionLimit = &entity.TransactionLimit{
+	Tier:                 entity.BasicTier,
+	MinTransferAmount:    1000,
+	MaxTransferAmount:    10000000,
+	MinWithdrawalAmount:  10000,
+	MaxWithdrawalAmount:  10000000,
+	DailyOutgoingLimit:   10000000,
+	MonthlyOutgoingLimit: 20000000,
+	MaxBalance:           20000000,
+}
+
+// MockPermissiveLimits returns the limits of mockTransactionLimit for every
+// wallet with nothing sent yet, for tests that are not about limits.
+func MockPermissiveLimits(t *testing.T) *mocks.ITransactionLimitRepository {
+	lr := mocks.NewITransactionLimitRepository(t)
+	lr.On("FindByWalletNumber", mock.Anything).
+		Return(mockTransactionLimit, 1, nil).
+		Maybe()
+	lr.On("LockOwnerOfWallet", mock.Anything).
+		Return(1, nil).
+		Maybe()
+	lr.On("SumOutgoingSince", mock.Anything, mock.Anything).
+		Return(0, nil).
+		Maybe()
+
+	return lr
+}
+
+func Test_checkOutgoingLimits(t *testing.T) {
+	now := time.Date(2023, time.March, 15, 10, 0, 0, 0, time.UTC)
+	startOfDay := time.Date(2023, time.March, 15, 0, 0, 0, 0, time.UTC)
+	startOfMonth := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		amount        int
+		sentToday     int
+		sentThisMonth int
+		lockedRows    int
+		lockErr       error
+		sumErr        error
+		wantErr       bool
+		expectedErr   error
+	}{
+		{
+			name:        "Error | User of the wallet not found",
+			amount:      1000,
+			lockedRows:  0,
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "user"},
+		},
+		{
+			name:        "Error | Other errors when locking the user",
+			amount:      1000,
+			lockedRows:  1,
+			lockErr:     fmt.Errorf("error"),
+			wantErr:     true,
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name:        "Error | Other errors from transaction limit repository",
+			amount:      1000,
+			lockedRows:  1,
+			sumErr:      fmt.Errorf("error"),
+			wantErr:     true,
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name:          "Error | Daily limit exceeded",
+			amount:        3000000,
+			sentToday:     8000000,
+			sentThisMonth: 8000000,
+			lockedRows:    1,
+			wantErr:       true,
+			expectedErr:   &custom_error.DailyLimitExceeded{Remaining: 2000000},
+		},
+		{
+			name:          "Error | Daily limit used up",
+			amount:        1000,
+			sentToday:     10000000,
+			sentThisMonth: 10000000,
+			lockedRows:    1,
+			wantErr:       true,
+			expectedErr:   &custom_error.DailyLimitExceeded{Remaining: 0},
+		},
+		{
+			name:          "Error | Monthly limit exceeded",
+			amount:        2000000,
+			sentToday:     0,
+			sentThisMonth: 19000000,
+			lockedRows:    1,
+			wantErr:       true,
+			expectedErr:   &custom_error.MonthlyLimitExceeded{Remaining: 1000000},
+		},
+		{
+			name:          "Success | Exactly at the limits",
+			amount:        2000000,
+			sentToday:     8000000,
+			sentThisMonth: 18000000,
+			lockedRows:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := mocks.NewITransactionLimitRepository(t)
+			lr.On("LockOwnerOfWallet", 1).Return(tt.lockedRows, tt.lockErr)
+			lr.On("SumOutgoingSince", 1, startOfDay).
+				Return(tt.sentToday, tt.sumErr).
+				Maybe()
+			lr.On("SumOutgoingSince", 1, startOfMonth).
+				Return(tt.sentThisMonth, nil).
+				Maybe()
+
+			err := checkOutgoingLimits(lr, mockTransactionLimit, 1, tt.amount, now)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func Test_createTransfer_limits(t *testing.T) {
+	newTransfer := func(transactionType entity.TransactionType, amount int) *entity.Transaction {
+		return &entity.Transaction{
+			Amount:   amount,
+			Type:     transactionType,
+			Datetime: time.Now(),
+			From:     1,
+			To:       100002,
+		}
+	}
+
+	userID := 1
+	otherUserID := 2
+
+	tests := []struct {
+		name     string
+		transfer *entity.Transaction
+		mock     func(
+			wr *mocks.IWalletRepository,
+			lr *mocks.ITransactionLimitRepository,
+		)
+		expectedErr error
+	}{
+		{
+			name:     "Error | Transaction limit not found",
+			transfer: newTransfer(entity.Transfer, 1000),
+			mock: func(
+				wr *mocks.IWalletRepository,
+				lr *mocks.ITransactionLimitRepository,
+			) {
+				wr.On("FindByNumber", 1).Return(&entity.Wallet{Number: 1, Balance: 1000}, 1, nil)
+				wr.On("FindByNumber", 100002).Return(&entity.Wallet{Number: 100002}, 1, nil)
+				lr.On("FindByWalletNumber", 1).Return(nil, 0, nil)
+			},
+			expectedErr: &custom_error.NoDataFound{DataType: "transaction limit"},
+		},
+		{
+			name:     "Error | Amount above the maximum of the tier",
+			transfer: newTransfer(entity.Transfer, 10000001),
+			mock: func(
+				wr *mocks.IWalletRepository,
+				lr *mocks.ITransactionLimitRepository,
+			) {
+				wr.On("FindByNumber", 1).Return(&entity.Wallet{Number: 1, Balance: 1000}, 1, nil)
+				wr.On("FindByNumber", 100002).Return(&entity.Wallet{Number: 100002}, 1, nil)
+				lr.On("FindByWalletNumber", 1).Return(mockTransactionLimit, 1, nil)
+			},
+			expectedErr: &custom_error.AmountNotInRange{
+				Minimum: mockTransactionLimit.MinTransferAmount,
+				Maximum: mockTransactionLimit.MaxTransferAmount,
+			},
+		},
+		{
+			name:     "Error | Daily limit exceeded after the debit",
+			transfer: newTransfer(entity.Transfer, 1000),
+			mock: func(
+				wr *mocks.IWalletRepository,
+				lr *mocks.ITransactionLimitRepository,
+			) {
+				lr.On("FindByWalletNumber", 1).Return(mockTransactionLimit, 1, nil)
+				wr.On("FindByNumber", 1).Return(&entity.Wallet{Number: 1, Balance: 1000}, 1, nil)
+				wr.On("FindByNumber", 100002).Return(&entity.Wallet{Number: 100002}, 1, nil)
+				wr.On("DecrementBalanceByValue", 1, 1000).
+					Return(&entity.Wallet{Number: 1}, 1, nil)
+				lr.On("LockOwnerOfWallet", 1).Return(1, nil)
+				lr.On("SumOutgoingSince", 1, mock.Anything).
+					Return(mockTransactionLimit.DailyOutgoingLimit, nil)
+			},
+			expectedErr: &custom_error.DailyLimitExceeded{Remaining: 0},
+		},
+		{
+			name:     "Error | Destination wallet over its balance limit",
+			transfer: newTransfer(entity.Transfer, 1000),
+			mock: func(
+				wr *mocks.IWalletRepository,
+				lr *mocks.ITransactionLimitRepository,
+			) {
+				lr.On("FindByWalletNumber", mock.Anything).Return(mockTransactionLimit, 1, nil)
+				wr.On("FindByNumber", 1).Return(&entity.Wallet{Number: 1, Balance: 1000}, 1, nil)
+				wr.On("FindByNumber", 100002).Return(&entity.Wallet{Number: 100002}, 1, nil)
+				wr.On("DecrementBalanceByValue", 1, 1000).
+					Return(&entity.Wallet{Number: 1}, 1, nil)
+				lr.On("LockOwnerOfWallet", 1).Return(1, nil)
+				lr.On("SumOutgoingSince", 1, mock.Anything).Return(0, nil)
+				wr.On("IncrementBalanceByValue", 100002, 1000).
+					Return(&entity.Wallet{
+						Number:  100002,
+						Balance: mockTransactionLimit.MaxBalance + 1,
+					}, 1, nil)
+			},
+			expectedErr: &custom_error.DestinationBalanceLimitExceeded{},
+		},
+		{
+			name:     "Error | Transfers to another user are limited",
+			transfer: newTransfer(entity.Transfer, 10000001),
+			mock: func(
+				wr *mocks.IWalletRepository,
+				lr *mocks.ITransactionLimitRepository,
+			) {
+				wr.On("FindByNumber", 1).
+					Return(&entity.Wallet{Number: 1, UserID: &userID}, 1, nil)
+				wr.On("FindByNumber", 100002).
+					Return(&entity.Wallet{Number: 100002, UserID: &otherUserID}, 1, nil)
+				lr.On("FindByWalletNumber", 1).Return(mockTransactionLimit, 1, nil)
+			},
+			expectedErr: &custom_error.AmountNotInRange{
+				Minimum: mockTransactionLimit.MinTransferAmount,
+				Maximum: mockTransactionLimit.MaxTransferAmount,
+			},
+		},
+		{
+			name:     "Error | Moves between own wallets are not held to the transfer limits",
+			transfer: newTransfer(entity.Transfer, 10000001),
+			mock: func(
+				wr *mocks.IWalletRepository,
+				lr *mocks.ITransactionLimitRepository,
+			) {
+				wr.On("FindByNumber", 1).
+					Return(&entity.Wallet{Number: 1, UserID: &userID}, 1, nil)
+				wr.On("FindByNumber", 100002).
+					Return(&entity.Wallet{Number: 100002, UserID: &userID}, 1, nil)
+			},
+			expectedErr: &custom_error.InsufficientBalance{},
+		},
+		{
+			name:     "Error | Moves between own wallets are held to the balance limit of the receiver",
+			transfer: newTransfer(entity.Transfer, 1000),
+			mock: func(
+				wr *mocks.IWalletRepository,
+				lr *mocks.ITransactionLimitRepository,
+			) {
+				wr.On("FindByNumber", 1).
+					Return(&entity.Wallet{Number: 1, UserID: &userID, Balance: 1000}, 1, nil)
+				wr.On("FindByNumber", 100002).
+					Return(&entity.Wallet{Number: 100002, UserID: &userID}, 1, nil)
+				wr.On("DecrementBalanceByValue", 1, 1000).
+					Return(&entity.Wallet{Number: 1}, 1, nil)
+				wr.On("IncrementBalanceByValue", 100002, 1000).
+					Return(&entity.Wallet{
+						Number:  100002,
+						Balance: mockTransactionLimit.MaxBalance + 1,
+					}, 1, nil)
+				lr.On("FindByWalletNumber", 100002).Return(mockTransactionLimit, 1, nil)
+			},
+			expectedErr: &custom_error.DestinationBalanceLimitExceeded{},
+		},
+		{
+			name:     "Error | Moves between own wallets of a negative amount",
+			transfer: newTransfer(entity.Transfer, -1000),
+			mock: func(
+				wr *mocks.IWalletRepository,
+				lr *mocks.ITransactionLimitRepository,
+			) {
+			},
+			expectedErr: &custom_error.AmountNotInRange{
+				Minimum: 1,
+				Maximum: math.MaxInt,
+			},
+		},
+		{
+			name: "Error | Transfers to a house wallet",
+			transfer: &entity.Transaction{
+				Amount:   1000,
+				Type:     entity.Transfer,
+				Datetime: time.Now(),
+				From:     1,
+				To:       entity.HouseWalletNumber,
+			},
+			mock: func(
+				wr *mocks.IWalletRepository,
+				lr *mocks.ITransactionLimitRepository,
+			) {
+			},
+			expectedErr: &custom_error.CannotTransferToHouseWallet{},
+		},
+		{
+			name:     "Error | Refunds are not held to the limits",
+			transfer: newTransfer(entity.Refund, 10000001),
+			mock: func(
+				wr *mocks.IWalletRepository,
+				lr *mocks.ITransactionLimitRepository,
+			) {
+				wr.On("FindByNumber", 1).Return(nil, 0, nil)
+			},
+			expectedErr: &custom_error.NoDataFound{DataType: "source wallet"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wr := mocks.NewIWalletRepository(t)
+			lr := mocks.NewITransactionLimitRepository(t)
+
+			tt.mock(wr, lr)
+
+			got, err := createTransfer(&repository.Repositories{
+				Wallets:  wr,
+				Limits:   lr,
+				FeeRules: MockNoFees(t),
+			}, mocks.NewIRateProvider(t), tt.transfer)
+
+			assert.Nil(t, got)
+			assert.EqualError(t, err, tt.expectedErr.Error())
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"strconv"
+	"time"
+
+	"assignment-golang-backend/internal/config"
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/fx"
+	"assignment-golang-backend/internal/repository"
+)
+
+const (
+	DEFAULT_PAYMENT_REQUEST_EXP_HOUR = 72
+)
+
+type IPaymentRequestService interface {
+	CreatePaymentRequest(*entity.PaymentRequest) (*entity.PaymentRequest, error)
+	FindIncoming(int) ([]*entity.PaymentRequest, error)
+	FindOutgoing(int) ([]*entity.PaymentRequest, error)
+	Approve(int, int) (*entity.PaymentRequest, *entity.Transaction, error)
+	Decline(int, int) (*entity.PaymentRequest, error)
+}
+
+type paymentRequestService struct {
+	paymentRequestRepository   repository.IPaymentRequestRepository
+	walletRepository           repository.IWalletRepository
+	transactionLimitRepository repository.ITransactionLimitRepository
+	unitOfWork                 repository.IUnitOfWork
+	rates                      fx.IRateProvider
+}
+
+func NewPaymentRequestService(
+	pr repository.IPaymentRequestRepository,
+	wr repository.IWalletRepository,
+	lr repository.ITransactionLimitRepository,
+	uow repository.IUnitOfWork,
+	rates fx.IRateProvider,
+) IPaymentRequestService {
+	return &paymentRequestService{
+		paymentRequestRepository:   pr,
+		walletRepository:           wr,
+		transactionLimitRepository: lr,
+		unitOfWork:                 uow,
+		rates:                      rates,
+	}
+}
+
+// CreatePaymentRequest asks the payer for an amount within the payer's
+// transfer limits. The daily and monthly limits are only checked when the
+// payer approves.
+func (s *paymentRequestService) CreatePaymentRequest(
+	paymentRequest *entity.PaymentRequest,
+) (*entity.PaymentRequest, error) {
+	_, rowsAffected, err := s.walletRepository.FindByNumber(
+		paymentRequest.PayerNumber,
+	)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "payer wallet"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkWalletTransferAmount(
+		s.transactionLimitRepository,
+		paymentRequest.PayerNumber,
+		paymentRequest.Amount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentRequest.Status = entity.PaymentRequestPending
+	paymentRequest.ExpiresAt = time.Now().Add(paymentRequestDuration())
+
+	paymentRequest, rowsAffected, err = s.paymentRequestRepository.CreatePaymentRequest(
+		paymentRequest,
+	)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.FailedToCreateData{DataType: "payment request"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return paymentRequest, nil
+}
+
+// FindIncoming returns the requests the wallet can still approve or decline.
+func (s *paymentRequestService) FindIncoming(
+	payerNumber int,
+) ([]*entity.PaymentRequest, error) {
+	paymentRequests, _, err := s.paymentRequestRepository.FindPendingByPayerNumber(
+		payerNumber,
+		time.Now(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return paymentRequests, nil
+}
+
+func (s *paymentRequestService) FindOutgoing(
+	requesterNumber int,
+) ([]*entity.PaymentRequest, error) {
+	paymentRequests, _, err := s.paymentRequestRepository.FindByRequesterNumber(
+		requesterNumber,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return paymentRequests, nil
+}
+
+// Approve pays a pending request from the payer's wallet. The request is
+// claimed and the transfer made in one unit of work, through the same
+// transfer code as transactionService.CreateTransaction.
+func (s *paymentRequestService) Approve(
+	id int,
+	payerNumber int,
+) (*entity.PaymentRequest, *entity.Transaction, error) {
+	var paymentRequest *entity.PaymentRequest
+	var transfer *entity.Transaction
+
+	err := s.unitOfWork.WithinTransaction(func(r *repository.Repositories) error {
+		var err error
+		paymentRequest, err = claimPaymentRequest(
+			r,
+			id,
+			payerNumber,
+			entity.PaymentRequestApproved,
+		)
+		if err != nil {
+			return err
+		}
+
+		transfer, err = createTransfer(r, s.rates, &entity.Transaction{
+			Amount:      paymentRequest.Amount,
+			Description: paymentRequest.Description,
+			Type:        entity.Transfer,
+			Datetime:    time.Now(),
+			From:        paymentRequest.PayerNumber,
+			To:          paymentRequest.RequesterNumber,
+		})
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := r.PaymentRequests.UpdateTransactionID(
+			paymentRequest.ID,
+			transfer.ID,
+		)
+
+		if rowsAffected == 0 {
+			return &custom_error.FailedToUpdateData{DataType: "payment request"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		paymentRequest.TransactionID = &transfer.ID
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return paymentRequest, transfer, nil
+}
+
+func (s *paymentRequestService) Decline(
+	id int,
+	payerNumber int,
+) (*entity.PaymentRequest, error) {
+	var paymentRequest *entity.PaymentRequest
+
+	err := s.unitOfWork.WithinTransaction(func(r *repository.Repositories) error {
+		var err error
+		paymentRequest, err = claimPaymentRequest(
+			r,
+			id,
+			payerNumber,
+			entity.PaymentRequestDeclined,
+		)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return paymentRequest, nil
+}
+
+// claimPaymentRequest moves a pending request to status. When the request
+// cannot be claimed it reports why: not found, not addressed to the payer,
+// already handled or expired.
+func claimPaymentRequest(
+	r *repository.Repositories,
+	id int,
+	payerNumber int,
+	status entity.PaymentRequestStatus,
+) (*entity.PaymentRequest, error) {
+	now := time.Now()
+	paymentRequest, rowsAffected, err := r.PaymentRequests.UpdatePendingStatus(
+		id,
+		payerNumber,
+		status,
+		now,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected != 0 {
+		return paymentRequest, nil
+	}
+
+	paymentRequest, rowsAffected, err = r.PaymentRequests.FindByID(id)
+
+	if rowsAffected == 0 || paymentRequest.PayerNumber != payerNumber {
+		return nil, &custom_error.NoDataFound{DataType: "payment request"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, &custom_error.PaymentRequestNotPending{
+		Status: string(paymentRequest.CurrentStatus(now)),
+	}
+}
+
+func paymentRequestDuration() time.Duration {
+	expHour, err := strconv.Atoi(config.GetEnv("PAYMENT_REQUEST_EXP_HOUR"))
+	if err != nil || expHour <= 0 {
+		expHour = DEFAULT_PAYMENT_REQUEST_EXP_HOUR
+	}
+
+	return time.Duration(expHour) * time.Hour
+}
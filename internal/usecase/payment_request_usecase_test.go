@@ -0,0 +1,336 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type paymentRequestRepositories struct {
+	paymentRequestRepository *mocks.IPaymentRequestRepository
+	transactionRepository    *mocks.ITransactionRepository
+	walletRepository         *mocks.IWalletRepository
+	ledgerRepository         *mocks.ILedgerRepository
+}
+
+func newPaymentRequestRepositories(t *testing.T) *paymentRequestRepositories {
+	return &paymentRequestRepositories{
+		paymentRequestRepository: mocks.NewIPaymentRequestRepository(t),
+		transactionRepository:    mocks.NewITransactionRepository(t),
+		walletRepository:         mocks.NewIWalletRepository(t),
+		ledgerRepository:         mocks.NewILedgerRepository(t),
+	}
+}
+
+func (r *paymentRequestRepositories) unitOfWork(t *testing.T) *mocks.IUnitOfWork {
+	uow := mocks.NewIUnitOfWork(t)
+	uow.On("WithinTransaction", mock.Anything).
+		Return(func(fn func(*repository.Repositories) error) error {
+			return fn(&repository.Repositories{
+				PaymentRequests: r.paymentRequestRepository,
+				Transactions:    r.transactionRepository,
+				Wallets:         r.walletRepository,
+				Ledger:          r.ledgerRepository,
+				Limits:          MockPermissiveLimits(t),
+				FeeRules:        MockNoFees(t),
+			})
+		})
+
+	return uow
+}
+
+func Test_paymentRequestService_CreatePaymentRequest(t *testing.T) {
+	mockPaymentRequest := &entity.PaymentRequest{
+		RequesterNumber: 1,
+		PayerNumber:     2,
+		Amount:          1000,
+	}
+
+	tests := []struct {
+		name        string
+		mock        func(*paymentRequestRepositories)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Payer wallet not found",
+			mock: func(r *paymentRequestRepositories) {
+				r.walletRepository.On("FindByNumber", 2).Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "payer wallet"},
+		},
+		{
+			name: "Error | Failed to create payment request",
+			mock: func(r *paymentRequestRepositories) {
+				r.walletRepository.On("FindByNumber", 2).
+					Return(&entity.Wallet{Number: 2}, 1, nil)
+				r.paymentRequestRepository.On("CreatePaymentRequest", mock.Anything).
+					Return(nil, 0, fmt.Errorf("error"))
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToCreateData{DataType: "payment request"},
+		},
+		{
+			name: "Success",
+			mock: func(r *paymentRequestRepositories) {
+				r.walletRepository.On("FindByNumber", 2).
+					Return(&entity.Wallet{Number: 2}, 1, nil)
+				r.paymentRequestRepository.On("CreatePaymentRequest", mock.MatchedBy(
+					func(paymentRequest *entity.PaymentRequest) bool {
+						return paymentRequest.Status == entity.PaymentRequestPending &&
+							paymentRequest.ExpiresAt.After(time.Now())
+					},
+				)).Return(mockPaymentRequest, 1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPaymentRequestRepositories(t)
+			s := NewPaymentRequestService(
+				r.paymentRequestRepository,
+				r.walletRepository,
+				MockPermissiveLimits(t),
+				mocks.NewIUnitOfWork(t),
+				mocks.NewIRateProvider(t),
+			)
+
+			tt.mock(r)
+
+			got, err := s.CreatePaymentRequest(&entity.PaymentRequest{
+				RequesterNumber: 1,
+				PayerNumber:     2,
+				Amount:          1000,
+			})
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, mockPaymentRequest, got)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_paymentRequestService_Approve(t *testing.T) {
+	mockPaymentRequest := &entity.PaymentRequest{
+		Base:            entity.Base{ID: 1},
+		RequesterNumber: 100001,
+		PayerNumber:     2,
+		Amount:          1000,
+		Description:     "dinner",
+		Status:          entity.PaymentRequestApproved,
+	}
+	isTransfer := mock.MatchedBy(func(transfer *entity.Transaction) bool {
+		return transfer.From == 2 &&
+			transfer.To == 100001 &&
+			transfer.Amount == 1000 &&
+			transfer.Type == entity.Transfer
+	})
+
+	tests := []struct {
+		name        string
+		mock        func(*paymentRequestRepositories)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Payment request not found",
+			mock: func(r *paymentRequestRepositories) {
+				r.paymentRequestRepository.On(
+					"UpdatePendingStatus", 1, 2, entity.PaymentRequestApproved, mock.Anything,
+				).Return(nil, 0, nil)
+				r.paymentRequestRepository.On("FindByID", 1).Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "payment request"},
+		},
+		{
+			name: "Error | Payment request addressed to another wallet",
+			mock: func(r *paymentRequestRepositories) {
+				r.paymentRequestRepository.On(
+					"UpdatePendingStatus", 1, 2, entity.PaymentRequestApproved, mock.Anything,
+				).Return(nil, 0, nil)
+				r.paymentRequestRepository.On("FindByID", 1).
+					Return(&entity.PaymentRequest{PayerNumber: 3}, 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "payment request"},
+		},
+		{
+			name: "Error | Payment request already declined",
+			mock: func(r *paymentRequestRepositories) {
+				r.paymentRequestRepository.On(
+					"UpdatePendingStatus", 1, 2, entity.PaymentRequestApproved, mock.Anything,
+				).Return(nil, 0, nil)
+				r.paymentRequestRepository.On("FindByID", 1).
+					Return(&entity.PaymentRequest{
+						PayerNumber: 2,
+						Status:      entity.PaymentRequestDeclined,
+					}, 1, nil)
+			},
+			wantErr: true,
+			expectedErr: &custom_error.PaymentRequestNotPending{
+				Status: string(entity.PaymentRequestDeclined),
+			},
+		},
+		{
+			name: "Error | Payment request expired",
+			mock: func(r *paymentRequestRepositories) {
+				r.paymentRequestRepository.On(
+					"UpdatePendingStatus", 1, 2, entity.PaymentRequestApproved, mock.Anything,
+				).Return(nil, 0, nil)
+				r.paymentRequestRepository.On("FindByID", 1).
+					Return(&entity.PaymentRequest{
+						PayerNumber: 2,
+						Status:      entity.PaymentRequestPending,
+						ExpiresAt:   time.Now().Add(-time.Minute),
+					}, 1, nil)
+			},
+			wantErr: true,
+			expectedErr: &custom_error.PaymentRequestNotPending{
+				Status: string(entity.PaymentRequestExpired),
+			},
+		},
+		{
+			name: "Error | Insufficient balance",
+			mock: func(r *paymentRequestRepositories) {
+				r.paymentRequestRepository.On(
+					"UpdatePendingStatus", 1, 2, entity.PaymentRequestApproved, mock.Anything,
+				).Return(mockPaymentRequest, 1, nil)
+				r.walletRepository.On("FindByNumber", 2).
+					Return(&entity.Wallet{Number: 2, Balance: 999}, 1, nil)
+				r.walletRepository.On("FindByNumber", 100001).
+					Return(&entity.Wallet{Number: 100001}, 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.InsufficientBalance{},
+		},
+		{
+			name: "Success",
+			mock: func(r *paymentRequestRepositories) {
+				r.paymentRequestRepository.On(
+					"UpdatePendingStatus", 1, 2, entity.PaymentRequestApproved, mock.Anything,
+				).Return(mockPaymentRequest, 1, nil)
+				r.walletRepository.On("FindByNumber", 2).
+					Return(&entity.Wallet{Number: 2, Balance: 1000}, 1, nil)
+				r.walletRepository.On("FindByNumber", 100001).
+					Return(&entity.Wallet{Number: 100001}, 1, nil)
+				r.walletRepository.On("DecrementBalanceByValue", 2, 1000).
+					Return(&entity.Wallet{Number: 2}, 1, nil)
+				r.walletRepository.On("IncrementBalanceByValue", 100001, 1000).
+					Return(&entity.Wallet{Number: 100001, Balance: 1000}, 1, nil)
+				r.transactionRepository.On("CreateTransaction", isTransfer).
+					Return(func(transfer *entity.Transaction) *entity.Transaction {
+						transfer.ID = 10
+						return transfer
+					}, 1, nil)
+				r.ledgerRepository.On("CreateJournalEntry", mock.Anything).
+					Return(&entity.JournalEntry{}, 1, nil)
+				r.paymentRequestRepository.On("UpdateTransactionID", 1, 10).
+					Return(1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPaymentRequestRepositories(t)
+			s := NewPaymentRequestService(
+				r.paymentRequestRepository,
+				r.walletRepository,
+				MockPermissiveLimits(t),
+				r.unitOfWork(t),
+				mocks.NewIRateProvider(t),
+			)
+
+			tt.mock(r)
+
+			paymentRequest, transfer, err := s.Approve(1, 2)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, 10, transfer.ID)
+				assert.Equal(t, 10, *paymentRequest.TransactionID)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, paymentRequest)
+				assert.Nil(t, transfer)
+			}
+		})
+	}
+}
+
+func Test_paymentRequestService_Decline(t *testing.T) {
+	r := newPaymentRequestRepositories(t)
+	s := NewPaymentRequestService(
+		r.paymentRequestRepository,
+		r.walletRepository,
+		MockPermissiveLimits(t),
+		r.unitOfWork(t),
+		mocks.NewIRateProvider(t),
+	)
+	mockPaymentRequest := &entity.PaymentRequest{
+		Base:   entity.Base{ID: 1},
+		Status: entity.PaymentRequestDeclined,
+	}
+	r.paymentRequestRepository.On(
+		"UpdatePendingStatus", 1, 2, entity.PaymentRequestDeclined, mock.Anything,
+	).Return(mockPaymentRequest, 1, nil)
+
+	got, err := s.Decline(1, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mockPaymentRequest, got)
+}
+
+func Test_paymentRequestService_FindIncoming(t *testing.T) {
+	r := newPaymentRequestRepositories(t)
+	s := NewPaymentRequestService(
+		r.paymentRequestRepository,
+		r.walletRepository,
+		MockPermissiveLimits(t),
+		mocks.NewIUnitOfWork(t),
+		mocks.NewIRateProvider(t),
+	)
+	r.paymentRequestRepository.On("FindPendingByPayerNumber", 2, mock.Anything).
+		Return(nil, 0, fmt.Errorf("error"))
+
+	got, err := s.FindIncoming(2)
+
+	assert.EqualError(t, err, "error")
+	assert.Nil(t, got)
+}
+
+func Test_paymentRequestService_FindOutgoing(t *testing.T) {
+	r := newPaymentRequestRepositories(t)
+	s := NewPaymentRequestService(
+		r.paymentRequestRepository,
+		r.walletRepository,
+		MockPermissiveLimits(t),
+		mocks.NewIUnitOfWork(t),
+		mocks.NewIRateProvider(t),
+	)
+	mockPaymentRequests := []*entity.PaymentRequest{{RequesterNumber: 1}}
+	r.paymentRequestRepository.On("FindByRequesterNumber", 1).
+		Return(mockPaymentRequests, 1, nil)
+
+	got, err := s.FindOutgoing(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mockPaymentRequests, got)
+}
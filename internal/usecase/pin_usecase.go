@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/repository"
+)
+
+const (
+	MAX_PIN_ATTEMPTS         = 3
+	PIN_LOCK_DURATION_MINUTE = 15
+)
+
+type IPinService interface {
+	SetPin(int, string) error
+	ChangePin(int, string, string) error
+	ResetPin(int, string, string) error
+	VerifyPin(int, string) error
+}
+
+type pinService struct {
+	userRepository repository.IUserRepository
+	unitOfWork     repository.IUnitOfWork
+}
+
+func NewPinService(
+	ur repository.IUserRepository,
+	uow repository.IUnitOfWork,
+) IPinService {
+	return &pinService{
+		userRepository: ur,
+		unitOfWork:     uow,
+	}
+}
+
+// SetPin sets the first transaction PIN of a user. Use ChangePin or ResetPin
+// once a PIN is set.
+func (s *pinService) SetPin(userID int, pin string) error {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Pin != "" {
+		return &custom_error.PinAlreadySet{}
+	}
+
+	return s.updatePin(userID, pin)
+}
+
+// ChangePin replaces the PIN after checking the old one, counting a wrong
+// old PIN as a failed attempt like VerifyPin does.
+func (s *pinService) ChangePin(userID int, oldPin, newPin string) error {
+	err := s.VerifyPin(userID, oldPin)
+	if err != nil {
+		return err
+	}
+
+	return s.updatePin(userID, newPin)
+}
+
+// ResetPin replaces a forgotten or locked PIN after checking the account
+// password.
+func (s *pinService) ResetPin(userID int, password, newPin string) error {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !helper.ComparePasswords(user.Password, []byte(password)) {
+		return &custom_error.WrongPassword{}
+	}
+
+	return s.updatePin(userID, newPin)
+}
+
+// VerifyPin checks the PIN of a user. After MAX_PIN_ATTEMPTS wrong PINs in a
+// row the PIN is locked for PIN_LOCK_DURATION_MINUTE, even for the right PIN,
+// and every wrong PIN after that locks it again until the right one is
+// given. The user is locked while the PIN is checked and the attempt
+// counted, so concurrent attempts cannot all pass a lock check that one of
+// them is about to set.
+func (s *pinService) VerifyPin(userID int, pin string) error {
+	var pinErr error
+	err := s.unitOfWork.WithinTransaction(func(r *repository.Repositories) error {
+		user, rowsAffected, err := r.Users.FindByIDForUpdate(userID)
+
+		if rowsAffected == 0 {
+			return &custom_error.NoDataFound{DataType: "user"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if user.Pin == "" {
+			return &custom_error.PinNotSet{}
+		}
+
+		if user.PinLockedUntil != nil && time.Now().Before(*user.PinLockedUntil) {
+			return &custom_error.PinLocked{Until: *user.PinLockedUntil}
+		}
+
+		if !helper.ComparePasswords(user.Pin, []byte(pin)) {
+			// The failed attempt is committed along with the wrong PIN
+			// error it is reported as.
+			pinErr = recordFailedAttempt(r.Users, userID)
+			switch pinErr.(type) {
+			case *custom_error.WrongPin, *custom_error.PinLocked:
+				return nil
+			default:
+				return pinErr
+			}
+		}
+
+		if user.PinFailedAttempts > 0 {
+			_, err = r.Users.ResetPinFailedAttempts(userID)
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return pinErr
+}
+
+func recordFailedAttempt(ur repository.IUserRepository, userID int) error {
+	user, rowsAffected, err := ur.IncrementPinFailedAttempts(
+		userID,
+		MAX_PIN_ATTEMPTS,
+		time.Now().Add(PIN_LOCK_DURATION_MINUTE*time.Minute),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &custom_error.FailedToUpdateData{DataType: "user"}
+	}
+
+	if user.PinLockedUntil != nil && time.Now().Before(*user.PinLockedUntil) {
+		return &custom_error.PinLocked{Until: *user.PinLockedUntil}
+	}
+
+	return &custom_error.WrongPin{
+		RemainingAttempts: MAX_PIN_ATTEMPTS - user.PinFailedAttempts,
+	}
+}
+
+func (s *pinService) findUser(userID int) (*entity.User, error) {
+	user, rowsAffected, err := s.userRepository.FindByID(userID)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "user"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *pinService) updatePin(userID int, pin string) error {
+	hashedPin, err := helper.HashAndSalt(pin)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := s.userRepository.UpdatePin(userID, hashedPin)
+
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &custom_error.FailedToUpdateData{DataType: "user"}
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/repository"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockUserUnitOfWork runs units of work one at a time with ur, the way the
+// row lock of the user serializes them.
+func mockUserUnitOfWork(
+	t *testing.T,
+	ur *mocks.IUserRepository,
+) *mocks.IUnitOfWork {
+	var mu sync.Mutex
+	uow := mocks.NewIUnitOfWork(t)
+	uow.On("WithinTransaction", mock.Anything).
+		Return(func(fn func(*repository.Repositories) error) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return fn(&repository.Repositories{Users: ur})
+		}).
+		Maybe()
+
+	return uow
+}
+
+func Test_pinService_VerifyPin(t *testing.T) {
+	hashedPin, err := helper.HashAndSalt("123456")
+	require.NoError(t, err)
+	lockedUntil := time.Now().Add(time.Minute)
+	expiredLock := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name        string
+		pin         string
+		mock        func(*mocks.IUserRepository)
+		expectedErr error
+	}{
+		{
+			name: "Error | User not found",
+			pin:  "123456",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByIDForUpdate", 1).Return(nil, 0, nil)
+			},
+			expectedErr: &custom_error.NoDataFound{DataType: "user"},
+		},
+		{
+			name: "Error | PIN not set",
+			pin:  "123456",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByIDForUpdate", 1).Return(&entity.User{}, 1, nil)
+			},
+			expectedErr: &custom_error.PinNotSet{},
+		},
+		{
+			name: "Error | PIN locked even for the right PIN",
+			pin:  "123456",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByIDForUpdate", 1).Return(&entity.User{
+					Pin:            hashedPin,
+					PinLockedUntil: &lockedUntil,
+				}, 1, nil)
+			},
+			expectedErr: &custom_error.PinLocked{Until: lockedUntil},
+		},
+		{
+			name: "Error | Wrong PIN counts a failed attempt",
+			pin:  "000000",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByIDForUpdate", 1).Return(&entity.User{Pin: hashedPin}, 1, nil)
+				ur.On("IncrementPinFailedAttempts", 1, MAX_PIN_ATTEMPTS, mock.Anything).
+					Return(&entity.User{PinFailedAttempts: 1}, 1, nil)
+			},
+			expectedErr: &custom_error.WrongPin{
+				RemainingAttempts: MAX_PIN_ATTEMPTS - 1,
+			},
+		},
+		{
+			name: "Error | Last wrong PIN locks the PIN",
+			pin:  "000000",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByIDForUpdate", 1).Return(&entity.User{
+					Pin:               hashedPin,
+					PinFailedAttempts: MAX_PIN_ATTEMPTS - 1,
+				}, 1, nil)
+				ur.On("IncrementPinFailedAttempts", 1, MAX_PIN_ATTEMPTS, mock.Anything).
+					Return(&entity.User{PinLockedUntil: &lockedUntil}, 1, nil)
+			},
+			expectedErr: &custom_error.PinLocked{Until: lockedUntil},
+		},
+		{
+			name: "Error | Failed to count attempt",
+			pin:  "000000",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByIDForUpdate", 1).Return(&entity.User{Pin: hashedPin}, 1, nil)
+				ur.On("IncrementPinFailedAttempts", 1, MAX_PIN_ATTEMPTS, mock.Anything).
+					Return(nil, 0, nil)
+			},
+			expectedErr: &custom_error.FailedToUpdateData{DataType: "user"},
+		},
+		{
+			name: "Success | Expired lock and previous failures are reset",
+			pin:  "123456",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByIDForUpdate", 1).Return(&entity.User{
+					Pin:               hashedPin,
+					PinFailedAttempts: 1,
+					PinLockedUntil:    &expiredLock,
+				}, 1, nil)
+				ur.On("ResetPinFailedAttempts", 1).Return(1, nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Success",
+			pin:  "123456",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByIDForUpdate", 1).Return(&entity.User{Pin: hashedPin}, 1, nil)
+			},
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepository := mocks.NewIUserRepository(t)
+			s := NewPinService(userRepository, mockUserUnitOfWork(t, userRepository))
+
+			tt.mock(userRepository)
+
+			err := s.VerifyPin(1, tt.pin)
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func Test_pinService_VerifyPin_concurrentAttempts(t *testing.T) {
+	hashedPin, err := helper.HashAndSalt("123456")
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	user := &entity.User{Pin: hashedPin, PinFailedAttempts: MAX_PIN_ATTEMPTS - 2}
+	userRepository := mocks.NewIUserRepository(t)
+	userRepository.On("FindByIDForUpdate", 1).
+		Return(func(int) *entity.User {
+			mu.Lock()
+			defer mu.Unlock()
+
+			locked := *user
+			return &locked
+		}, 1, nil)
+	userRepository.On("IncrementPinFailedAttempts", 1, MAX_PIN_ATTEMPTS, mock.Anything).
+		Return(func(_ int, maxAttempts int, lockedUntil time.Time) *entity.User {
+			mu.Lock()
+			defer mu.Unlock()
+
+			user.PinFailedAttempts++
+			if user.PinFailedAttempts >= maxAttempts {
+				user.PinLockedUntil = &lockedUntil
+			}
+
+			updated := *user
+			return &updated
+		}, 1, nil).
+		Twice()
+
+	s := NewPinService(userRepository, mockUserUnitOfWork(t, userRepository))
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.VerifyPin(1, "000000")
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, MAX_PIN_ATTEMPTS, user.PinFailedAttempts)
+	assert.ElementsMatch(
+		t,
+		[]string{
+			custom_error.WrongPin{RemainingAttempts: 1}.Error(),
+			custom_error.PinLocked{Until: *user.PinLockedUntil}.Error(),
+		},
+		[]string{errs[0].Error(), errs[1].Error()},
+	)
+	assert.ErrorAs(t, s.VerifyPin(1, "123456"), new(*custom_error.PinLocked))
+}
+
+func Test_pinService_SetPin(t *testing.T) {
+	tests := []struct {
+		name        string
+		mock        func(*mocks.IUserRepository)
+		expectedErr error
+	}{
+		{
+			name: "Error | PIN already set",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByID", 1).Return(&entity.User{Pin: "hashed"}, 1, nil)
+			},
+			expectedErr: &custom_error.PinAlreadySet{},
+		},
+		{
+			name: "Error | Failed to update PIN",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByID", 1).Return(&entity.User{}, 1, nil)
+				ur.On("UpdatePin", 1, mock.Anything).Return(0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name: "Success",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByID", 1).Return(&entity.User{}, 1, nil)
+				ur.On("UpdatePin", 1, mock.MatchedBy(func(pin string) bool {
+					return helper.ComparePasswords(pin, []byte("123456"))
+				})).Return(1, nil)
+			},
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepository := mocks.NewIUserRepository(t)
+			s := NewPinService(userRepository, mocks.NewIUnitOfWork(t))
+
+			tt.mock(userRepository)
+
+			err := s.SetPin(1, "123456")
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func Test_pinService_ChangePin(t *testing.T) {
+	hashedPin, err := helper.HashAndSalt("123456")
+	require.NoError(t, err)
+
+	userRepository := mocks.NewIUserRepository(t)
+	s := NewPinService(userRepository, mockUserUnitOfWork(t, userRepository))
+	userRepository.On("FindByIDForUpdate", 1).
+		Return(&entity.User{Pin: hashedPin}, 1, nil)
+	userRepository.On("UpdatePin", 1, mock.MatchedBy(func(pin string) bool {
+		return helper.ComparePasswords(pin, []byte("654321"))
+	})).Return(1, nil)
+
+	err = s.ChangePin(1, "123456", "654321")
+
+	assert.NoError(t, err)
+}
+
+func Test_pinService_ResetPin(t *testing.T) {
+	hashedPassword, err := helper.HashAndSalt("password")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		password    string
+		mock        func(*mocks.IUserRepository)
+		expectedErr error
+	}{
+		{
+			name:     "Error | Wrong password",
+			password: "wrong",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByID", 1).Return(&entity.User{Password: hashedPassword}, 1, nil)
+			},
+			expectedErr: &custom_error.WrongPassword{},
+		},
+		{
+			name:     "Success",
+			password: "password",
+			mock: func(ur *mocks.IUserRepository) {
+				ur.On("FindByID", 1).Return(&entity.User{Password: hashedPassword}, 1, nil)
+				ur.On("UpdatePin", 1, mock.Anything).Return(1, nil)
+			},
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepository := mocks.NewIUserRepository(t)
+			s := NewPinService(userRepository, mocks.NewIUnitOfWork(t))
+
+			tt.mock(userRepository)
+
+			err := s.ResetPin(1, tt.password, "654321")
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
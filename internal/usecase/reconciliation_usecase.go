@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/ledger"
+	"assignment-golang-backend/internal/repository"
+)
+
+type IReconciliationService interface {
+	ReconcileWallets(bool) ([]*entity.BalanceReconciliation, error)
+}
+
+type reconciliationService struct {
+	walletRepository repository.IWalletRepository
+	unitOfWork       repository.IUnitOfWork
+}
+
+func NewReconciliationService(
+	wr repository.IWalletRepository,
+	uow repository.IUnitOfWork,
+) IReconciliationService {
+	return &reconciliationService{
+		walletRepository: wr,
+		unitOfWork:       uow,
+	}
+}
+
+// ReconcileWallets returns every wallet whose balance differs from the
+// balance recomputed from its transactions. When repair is true the wallet
+// balance is overwritten with the recomputed one and the correction is
+// posted to the ledger.
+func (s *reconciliationService) ReconcileWallets(
+	repair bool,
+) ([]*entity.BalanceReconciliation, error) {
+	reconciliations, _, err := s.walletRepository.FindAllWithTransactionBalance()
+	if err != nil {
+		return nil, err
+	}
+
+	mismatches := []*entity.BalanceReconciliation{}
+	for _, reconciliation := range reconciliations {
+		reconciliation.Difference = reconciliation.TransactionBalance -
+			reconciliation.WalletBalance
+		if reconciliation.Difference == 0 {
+			continue
+		}
+
+		if repair {
+			err = s.repairWallet(reconciliation)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		mismatches = append(mismatches, reconciliation)
+	}
+
+	return mismatches, nil
+}
+
+func (s *reconciliationService) repairWallet(
+	reconciliation *entity.BalanceReconciliation,
+) error {
+	return s.unitOfWork.WithinTransaction(func(r *repository.Repositories) error {
+		_, rowsAffected, err := r.Wallets.UpdateBalance(
+			reconciliation.WalletNumber,
+			reconciliation.WalletBalance,
+			reconciliation.TransactionBalance,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		// The wallet moved since it was read, leave it for the next run.
+		if rowsAffected == 0 {
+			return nil
+		}
+
+		err = postJournalEntry(r, ledger.NewAdjustmentEntry(
+			reconciliation.WalletNumber,
+			reconciliation.Difference,
+			time.Now(),
+		))
+		if err != nil {
+			return err
+		}
+
+		reconciliation.Repaired = true
+
+		return nil
+	})
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewReconciliationService(t *testing.T) {
+	NewReconciliationService(
+		mocks.NewIWalletRepository(t),
+		mocks.NewIUnitOfWork(t),
+	)
+}
+
+func Test_reconciliationService_ReconcileWallets(t *testing.T) {
+	mockOtherError := fmt.Errorf("error")
+	mockReconciliations := func() []*entity.BalanceReconciliation {
+		return []*entity.BalanceReconciliation{
+			{WalletNumber: 1, WalletBalance: 100, TransactionBalance: 100},
+			{WalletNumber: 2, WalletBalance: 100, TransactionBalance: 70},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		repair      bool
+		mock        func(*mocks.IWalletRepository, *mocks.ILedgerRepository)
+		want        []*entity.BalanceReconciliation
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:   "Error | Other error from wallet repository",
+			repair: false,
+			mock: func(wr *mocks.IWalletRepository, lr *mocks.ILedgerRepository) {
+				wr.On("FindAllWithTransactionBalance").
+					Return(nil, 0, mockOtherError)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: mockOtherError,
+		},
+		{
+			name:   "Success | Report mismatches only",
+			repair: false,
+			mock: func(wr *mocks.IWalletRepository, lr *mocks.ILedgerRepository) {
+				wr.On("FindAllWithTransactionBalance").
+					Return(mockReconciliations(), 2, nil)
+			},
+			want: []*entity.BalanceReconciliation{
+				{
+					WalletNumber:       2,
+					WalletBalance:      100,
+					TransactionBalance: 70,
+					Difference:         -30,
+				},
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+		{
+			name:   "Error | Failed to post adjustment when repairing",
+			repair: true,
+			mock: func(wr *mocks.IWalletRepository, lr *mocks.ILedgerRepository) {
+				wr.On("FindAllWithTransactionBalance").
+					Return(mockReconciliations(), 2, nil)
+				wr.On("UpdateBalance", 2, 100, 70).
+					Return(&entity.Wallet{}, 1, nil)
+				lr.On("CreateJournalEntry", mock.Anything).
+					Return(nil, 1, mockOtherError)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: mockOtherError,
+		},
+		{
+			name:   "Success | Wallet changed concurrently is not repaired",
+			repair: true,
+			mock: func(wr *mocks.IWalletRepository, lr *mocks.ILedgerRepository) {
+				wr.On("FindAllWithTransactionBalance").
+					Return(mockReconciliations(), 2, nil)
+				wr.On("UpdateBalance", 2, 100, 70).
+					Return(&entity.Wallet{}, 0, nil)
+			},
+			want: []*entity.BalanceReconciliation{
+				{
+					WalletNumber:       2,
+					WalletBalance:      100,
+					TransactionBalance: 70,
+					Difference:         -30,
+					Repaired:           false,
+				},
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+		{
+			name:   "Success | Repair mismatches",
+			repair: true,
+			mock: func(wr *mocks.IWalletRepository, lr *mocks.ILedgerRepository) {
+				wr.On("FindAllWithTransactionBalance").
+					Return(mockReconciliations(), 2, nil)
+				wr.On("UpdateBalance", 2, 100, 70).
+					Return(&entity.Wallet{}, 1, nil)
+				lr.On("CreateJournalEntry", mock.MatchedBy(
+					func(journalEntry *entity.JournalEntry) bool {
+						return journalEntry.Postings[1].AccountNumber == 2 &&
+							journalEntry.Postings[1].Direction == entity.Debit &&
+							journalEntry.Postings[1].Amount == 30
+					},
+				)).Return(&entity.JournalEntry{}, 1, nil)
+			},
+			want: []*entity.BalanceReconciliation{
+				{
+					WalletNumber:       2,
+					WalletBalance:      100,
+					TransactionBalance: 70,
+					Difference:         -30,
+					Repaired:           true,
+				},
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wr := mocks.NewIWalletRepository(t)
+			lr := mocks.NewILedgerRepository(t)
+			uow := mocks.NewIUnitOfWork(t)
+			uow.On("WithinTransaction", mock.Anything).
+				Return(func(fn func(*repository.Repositories) error) error {
+					return fn(&repository.Repositories{
+						Wallets: wr,
+						Ledger:  lr,
+					})
+				}).
+				Maybe()
+
+			s := &reconciliationService{
+				walletRepository: wr,
+				unitOfWork:       uow,
+			}
+
+			tt.mock(wr, lr)
+
+			got, err := s.ReconcileWallets(tt.repair)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
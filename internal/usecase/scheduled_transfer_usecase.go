@@ -0,0 +1,308 @@
+package usecase
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+)
+
+const (
+	SCHEDULED_TRANSFER_BATCH_SIZE = 100
+)
+
+type IScheduledTransferService interface {
+	CreateScheduledTransfer(
+		*entity.ScheduledTransfer,
+	) (*entity.ScheduledTransfer, error)
+	FindByUserID(int) ([]*entity.ScheduledTransfer, error)
+	FindByID(int, int) (*entity.ScheduledTransfer, error)
+	UpdateScheduledTransfer(
+		*entity.ScheduledTransfer,
+	) (*entity.ScheduledTransfer, error)
+	CancelScheduledTransfer(int, int) error
+	RunDueScheduledTransfers(time.Time) (int, error)
+}
+
+type scheduledTransferService struct {
+	scheduledTransferRepository repository.IScheduledTransferRepository
+	walletRepository            repository.IWalletRepository
+	transactionLimitRepository  repository.ITransactionLimitRepository
+	transactionService          ITransactionService
+}
+
+func NewScheduledTransferService(
+	sr repository.IScheduledTransferRepository,
+	wr repository.IWalletRepository,
+	lr repository.ITransactionLimitRepository,
+	ts ITransactionService,
+) IScheduledTransferService {
+	return &scheduledTransferService{
+		scheduledTransferRepository: sr,
+		walletRepository:            wr,
+		transactionLimitRepository:  lr,
+		transactionService:          ts,
+	}
+}
+
+func (s *scheduledTransferService) CreateScheduledTransfer(
+	scheduledTransfer *entity.ScheduledTransfer,
+) (*entity.ScheduledTransfer, error) {
+	err := checkWalletTransferAmount(
+		s.transactionLimitRepository,
+		scheduledTransfer.From,
+		scheduledTransfer.Amount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkDestinationWallet(scheduledTransfer.To)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledTransfer.Status = entity.ScheduledTransferActive
+	scheduledTransfer.Occurrence = 0
+	scheduledTransfer.NextRunAt = &scheduledTransfer.StartAt
+
+	scheduledTransfer, rowsAffected, err := s.scheduledTransferRepository.CreateScheduledTransfer(
+		scheduledTransfer,
+	)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.FailedToCreateData{
+			DataType: "scheduled transfer",
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledTransfer, nil
+}
+
+func (s *scheduledTransferService) FindByUserID(
+	userID int,
+) ([]*entity.ScheduledTransfer, error) {
+	scheduledTransfers, _, err := s.scheduledTransferRepository.FindByUserID(
+		userID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledTransfers, nil
+}
+
+// FindByID returns a schedule of the user together with its run history.
+func (s *scheduledTransferService) FindByID(
+	id int,
+	userID int,
+) (*entity.ScheduledTransfer, error) {
+	scheduledTransfer, rowsAffected, err := s.scheduledTransferRepository.FindByIDAndUserID(
+		id,
+		userID,
+	)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "scheduled transfer"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledTransfer, nil
+}
+
+// UpdateScheduledTransfer replaces an active schedule. The recurrence starts
+// over from the new StartAt.
+func (s *scheduledTransferService) UpdateScheduledTransfer(
+	scheduledTransfer *entity.ScheduledTransfer,
+) (*entity.ScheduledTransfer, error) {
+	err := checkWalletTransferAmount(
+		s.transactionLimitRepository,
+		scheduledTransfer.From,
+		scheduledTransfer.Amount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkDestinationWallet(scheduledTransfer.To)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledTransfer.Occurrence = 0
+	scheduledTransfer.NextRunAt = &scheduledTransfer.StartAt
+
+	rowsAffected, err := s.scheduledTransferRepository.UpdateActiveScheduledTransfer(
+		scheduledTransfer,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, s.inactiveScheduleError(
+			scheduledTransfer.ID,
+			scheduledTransfer.UserID,
+		)
+	}
+
+	return s.FindByID(scheduledTransfer.ID, scheduledTransfer.UserID)
+}
+
+// CancelScheduledTransfer stops an active schedule. Its run history is kept.
+func (s *scheduledTransferService) CancelScheduledTransfer(
+	id int,
+	userID int,
+) error {
+	rowsAffected, err := s.scheduledTransferRepository.CancelScheduledTransfer(
+		id,
+		userID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return s.inactiveScheduleError(id, userID)
+	}
+
+	return nil
+}
+
+// RunDueScheduledTransfers runs every schedule due at now and returns how
+// many runs were attempted. Each due schedule runs once even when several
+// occurrences were missed, and then moves to its first occurrence after now.
+func (s *scheduledTransferService) RunDueScheduledTransfers(
+	now time.Time,
+) (int, error) {
+	scheduledTransfers, _, err := s.scheduledTransferRepository.FindDue(
+		now,
+		SCHEDULED_TRANSFER_BATCH_SIZE,
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	runCount := 0
+	for _, scheduledTransfer := range scheduledTransfers {
+		ran, err := s.runScheduledTransfer(scheduledTransfer, now)
+		if err != nil {
+			return runCount, err
+		}
+
+		if ran {
+			runCount++
+		}
+	}
+
+	return runCount, nil
+}
+
+// runScheduledTransfer claims the due occurrence before moving any money,
+// so a crash between the claim and the transfer skips the run instead of
+// paying it twice. A failed transfer is recorded with its reason and does
+// not stop a recurring schedule.
+func (s *scheduledTransferService) runScheduledTransfer(
+	scheduledTransfer *entity.ScheduledTransfer,
+	now time.Time,
+) (bool, error) {
+	scheduledAt := *scheduledTransfer.NextRunAt
+
+	var nextRunAt *time.Time
+	occurrence, runAt, ok := scheduledTransfer.NextOccurrenceAfter(now)
+	if ok {
+		nextRunAt = &runAt
+	} else {
+		occurrence = scheduledTransfer.Occurrence
+	}
+
+	rowsAffected, err := s.scheduledTransferRepository.ClaimRun(
+		scheduledTransfer,
+		nextRunAt,
+		occurrence,
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	run := &entity.ScheduledTransferRun{
+		ScheduledTransferID: scheduledTransfer.ID,
+		ScheduledAt:         scheduledAt,
+		Status:              entity.ScheduledRunSucceeded,
+	}
+
+	transfer, err := s.transactionService.CreateTransaction(&entity.Transaction{
+		Amount:      scheduledTransfer.Amount,
+		Description: scheduledTransfer.Description,
+		Type:        entity.Transfer,
+		Datetime:    now,
+		From:        scheduledTransfer.From,
+		To:          scheduledTransfer.To,
+	})
+
+	if err != nil {
+		run.Status = entity.ScheduledRunFailed
+		run.FailureReason = err.Error()
+	} else {
+		run.TransactionID = &transfer.ID
+	}
+
+	_, rowsAffected, err = s.scheduledTransferRepository.CreateRun(run)
+
+	if rowsAffected == 0 {
+		return true, &custom_error.FailedToCreateData{
+			DataType: "scheduled transfer run",
+		}
+	}
+
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+func (s *scheduledTransferService) checkDestinationWallet(number int) error {
+	if number <= entity.HouseWalletNumber {
+		return &custom_error.CannotTransferToHouseWallet{}
+	}
+
+	_, rowsAffected, err := s.walletRepository.FindByNumber(number)
+
+	if rowsAffected == 0 {
+		return &custom_error.NoDataFound{DataType: "destination wallet"}
+	}
+
+	return err
+}
+
+func (s *scheduledTransferService) inactiveScheduleError(
+	id int,
+	userID int,
+) error {
+	scheduledTransfer, err := s.FindByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	return &custom_error.ScheduledTransferNotActive{
+		Status: string(scheduledTransfer.Status),
+	}
+}
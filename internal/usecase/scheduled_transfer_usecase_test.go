@@ -0,0 +1,332 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type scheduledTransferDependencies struct {
+	scheduledTransferRepository *mocks.IScheduledTransferRepository
+	walletRepository            *mocks.IWalletRepository
+	transactionLimitRepository  *mocks.ITransactionLimitRepository
+	transactionService          *mocks.ITransactionService
+}
+
+func newScheduledTransferDependencies(t *testing.T) *scheduledTransferDependencies {
+	return &scheduledTransferDependencies{
+		scheduledTransferRepository: mocks.NewIScheduledTransferRepository(t),
+		walletRepository:            mocks.NewIWalletRepository(t),
+		transactionLimitRepository:  MockPermissiveLimits(t),
+		transactionService:          mocks.NewITransactionService(t),
+	}
+}
+
+func (d *scheduledTransferDependencies) service() IScheduledTransferService {
+	return NewScheduledTransferService(
+		d.scheduledTransferRepository,
+		d.walletRepository,
+		d.transactionLimitRepository,
+		d.transactionService,
+	)
+}
+
+func Test_scheduledTransferService_CreateScheduledTransfer(t *testing.T) {
+	startAt := time.Date(2030, time.January, 31, 9, 0, 0, 0, time.UTC)
+	mockScheduledTransfer := &entity.ScheduledTransfer{
+		Base:       entity.Base{ID: 1},
+		UserID:     1,
+		From:       100001,
+		To:         100002,
+		Amount:     1000,
+		Recurrence: entity.RecurrenceMonthly,
+		StartAt:    startAt,
+		NextRunAt:  &startAt,
+		Status:     entity.ScheduledTransferActive,
+	}
+
+	tests := []struct {
+		name        string
+		mock        func(*scheduledTransferDependencies)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Destination wallet not found",
+			mock: func(d *scheduledTransferDependencies) {
+				d.walletRepository.On("FindByNumber", 100002).Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "destination wallet"},
+		},
+		{
+			name: "Error | Failed to create scheduled transfer",
+			mock: func(d *scheduledTransferDependencies) {
+				d.walletRepository.On("FindByNumber", 100002).
+					Return(&entity.Wallet{Number: 100002}, 1, nil)
+				d.scheduledTransferRepository.On("CreateScheduledTransfer", mock.Anything).
+					Return(nil, 0, fmt.Errorf("error"))
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToCreateData{DataType: "scheduled transfer"},
+		},
+		{
+			name: "Success",
+			mock: func(d *scheduledTransferDependencies) {
+				d.walletRepository.On("FindByNumber", 100002).
+					Return(&entity.Wallet{Number: 100002}, 1, nil)
+				d.scheduledTransferRepository.On("CreateScheduledTransfer", mock.MatchedBy(
+					func(scheduledTransfer *entity.ScheduledTransfer) bool {
+						return scheduledTransfer.Status == entity.ScheduledTransferActive &&
+							scheduledTransfer.NextRunAt.Equal(startAt)
+					},
+				)).Return(mockScheduledTransfer, 1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newScheduledTransferDependencies(t)
+			s := d.service()
+
+			tt.mock(d)
+
+			got, err := s.CreateScheduledTransfer(&entity.ScheduledTransfer{
+				UserID:     1,
+				From:       100001,
+				To:         100002,
+				Amount:     1000,
+				Recurrence: entity.RecurrenceMonthly,
+				StartAt:    startAt,
+			})
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, mockScheduledTransfer, got)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_scheduledTransferService_CancelScheduledTransfer(t *testing.T) {
+	tests := []struct {
+		name        string
+		mock        func(*scheduledTransferDependencies)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Scheduled transfer not found",
+			mock: func(d *scheduledTransferDependencies) {
+				d.scheduledTransferRepository.On("CancelScheduledTransfer", 1, 1).
+					Return(0, nil)
+				d.scheduledTransferRepository.On("FindByIDAndUserID", 1, 1).
+					Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "scheduled transfer"},
+		},
+		{
+			name: "Error | Scheduled transfer already completed",
+			mock: func(d *scheduledTransferDependencies) {
+				d.scheduledTransferRepository.On("CancelScheduledTransfer", 1, 1).
+					Return(0, nil)
+				d.scheduledTransferRepository.On("FindByIDAndUserID", 1, 1).
+					Return(&entity.ScheduledTransfer{
+						Status: entity.ScheduledTransferCompleted,
+					}, 1, nil)
+			},
+			wantErr: true,
+			expectedErr: &custom_error.ScheduledTransferNotActive{
+				Status: string(entity.ScheduledTransferCompleted),
+			},
+		},
+		{
+			name: "Success",
+			mock: func(d *scheduledTransferDependencies) {
+				d.scheduledTransferRepository.On("CancelScheduledTransfer", 1, 1).
+					Return(1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newScheduledTransferDependencies(t)
+			s := d.service()
+
+			tt.mock(d)
+
+			err := s.CancelScheduledTransfer(1, 1)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func Test_scheduledTransferService_RunDueScheduledTransfers(t *testing.T) {
+	now := time.Date(2030, time.April, 1, 9, 30, 0, 0, time.UTC)
+	startAt := time.Date(2030, time.January, 31, 9, 0, 0, 0, time.UTC)
+	dueAt := time.Date(2030, time.March, 31, 9, 0, 0, 0, time.UTC)
+	newScheduledTransfer := func(recurrence entity.Recurrence) *entity.ScheduledTransfer {
+		return &entity.ScheduledTransfer{
+			Base:       entity.Base{ID: 1},
+			From:       1,
+			To:         2,
+			Amount:     1000,
+			Recurrence: recurrence,
+			StartAt:    startAt,
+			NextRunAt:  &dueAt,
+			Occurrence: 2,
+			Status:     entity.ScheduledTransferActive,
+		}
+	}
+	isTransfer := mock.MatchedBy(func(transfer *entity.Transaction) bool {
+		return transfer.From == 1 &&
+			transfer.To == 2 &&
+			transfer.Amount == 1000 &&
+			transfer.Type == entity.Transfer
+	})
+	isMonthlyNextRun := mock.MatchedBy(func(nextRunAt *time.Time) bool {
+		return nextRunAt != nil &&
+			nextRunAt.Equal(time.Date(2030, time.April, 30, 9, 0, 0, 0, time.UTC))
+	})
+
+	tests := []struct {
+		name         string
+		mock         func(*scheduledTransferDependencies)
+		wantRunCount int
+		wantErr      bool
+		expectedErr  error
+	}{
+		{
+			name: "Error | Failed to find due scheduled transfers",
+			mock: func(d *scheduledTransferDependencies) {
+				d.scheduledTransferRepository.On(
+					"FindDue",
+					now,
+					SCHEDULED_TRANSFER_BATCH_SIZE,
+				).Return(nil, 0, fmt.Errorf("error"))
+			},
+			wantRunCount: 0,
+			wantErr:      true,
+			expectedErr:  fmt.Errorf("error"),
+		},
+		{
+			name: "Success | Run claimed by another worker is skipped",
+			mock: func(d *scheduledTransferDependencies) {
+				d.scheduledTransferRepository.On(
+					"FindDue",
+					now,
+					SCHEDULED_TRANSFER_BATCH_SIZE,
+				).Return([]*entity.ScheduledTransfer{
+					newScheduledTransfer(entity.RecurrenceMonthly),
+				}, 1, nil)
+				d.scheduledTransferRepository.On(
+					"ClaimRun",
+					mock.Anything,
+					isMonthlyNextRun,
+					3,
+				).Return(0, nil)
+			},
+			wantRunCount: 0,
+			wantErr:      false,
+			expectedErr:  nil,
+		},
+		{
+			name: "Success | Failed transfer is recorded with its reason",
+			mock: func(d *scheduledTransferDependencies) {
+				d.scheduledTransferRepository.On(
+					"FindDue",
+					now,
+					SCHEDULED_TRANSFER_BATCH_SIZE,
+				).Return([]*entity.ScheduledTransfer{
+					newScheduledTransfer(entity.RecurrenceMonthly),
+				}, 1, nil)
+				d.scheduledTransferRepository.On(
+					"ClaimRun",
+					mock.Anything,
+					isMonthlyNextRun,
+					3,
+				).Return(1, nil)
+				d.transactionService.On("CreateTransaction", isTransfer).
+					Return(nil, &custom_error.InsufficientBalance{})
+				d.scheduledTransferRepository.On("CreateRun", mock.MatchedBy(
+					func(run *entity.ScheduledTransferRun) bool {
+						return run.Status == entity.ScheduledRunFailed &&
+							run.FailureReason == custom_error.InsufficientBalance{}.Error() &&
+							run.ScheduledAt.Equal(dueAt) &&
+							run.TransactionID == nil
+					},
+				)).Return(&entity.ScheduledTransferRun{}, 1, nil)
+			},
+			wantRunCount: 1,
+			wantErr:      false,
+			expectedErr:  nil,
+		},
+		{
+			name: "Success | One-time transfer is completed",
+			mock: func(d *scheduledTransferDependencies) {
+				d.scheduledTransferRepository.On(
+					"FindDue",
+					now,
+					SCHEDULED_TRANSFER_BATCH_SIZE,
+				).Return([]*entity.ScheduledTransfer{
+					newScheduledTransfer(entity.RecurrenceOnce),
+				}, 1, nil)
+				d.scheduledTransferRepository.On(
+					"ClaimRun",
+					mock.Anything,
+					(*time.Time)(nil),
+					2,
+				).Return(1, nil)
+				d.transactionService.On("CreateTransaction", isTransfer).
+					Return(&entity.Transaction{Base: entity.Base{ID: 10}}, nil)
+				d.scheduledTransferRepository.On("CreateRun", mock.MatchedBy(
+					func(run *entity.ScheduledTransferRun) bool {
+						return run.Status == entity.ScheduledRunSucceeded &&
+							run.TransactionID != nil &&
+							*run.TransactionID == 10
+					},
+				)).Return(&entity.ScheduledTransferRun{}, 1, nil)
+			},
+			wantRunCount: 1,
+			wantErr:      false,
+			expectedErr:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newScheduledTransferDependencies(t)
+			s := d.service()
+
+			tt.mock(d)
+
+			got, err := s.RunDueScheduledTransfers(now)
+
+			assert.Equal(t, tt.wantRunCount, got)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
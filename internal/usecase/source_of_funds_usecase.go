@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+)
+
+type ISourceOfFundsService interface {
+	FindEnabled() ([]*entity.SourceOfFunds, error)
+}
+
+type sourceOfFundsService struct {
+	sourceOfFundsRepository repository.ISourceOfFundsRepository
+}
+
+func NewSourceOfFundsService(
+	sr repository.ISourceOfFundsRepository,
+) ISourceOfFundsService {
+	return &sourceOfFundsService{
+		sourceOfFundsRepository: sr,
+	}
+}
+
+func (s *sourceOfFundsService) FindEnabled() ([]*entity.SourceOfFunds, error) {
+	sources, _, err := s.sourceOfFundsRepository.FindEnabled()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+)
+
+const (
+	STATEMENT_BATCH_SIZE = 500
+)
+
+type IStatementService interface {
+	Open(int, time.Time, time.Time) (*entity.Statement, error)
+	EachLine(*entity.Statement, func(*entity.StatementLine) error) error
+}
+
+type statementService struct {
+	walletRepository repository.IWalletRepository
+	ledgerRepository repository.ILedgerRepository
+}
+
+func NewStatementService(
+	wr repository.IWalletRepository,
+	lr repository.ILedgerRepository,
+) IStatementService {
+	return &statementService{
+		walletRepository: wr,
+		ledgerRepository: lr,
+	}
+}
+
+// Open starts the statement of the wallet for the period from from until
+// to, with the balance the ledger held for the wallet when it started.
+func (s *statementService) Open(
+	walletNumber int,
+	from time.Time,
+	to time.Time,
+) (*entity.Statement, error) {
+	wallet, rowsAffected, err := s.walletRepository.FindByNumber(walletNumber)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "wallet"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	openingBalance, err := s.ledgerRepository.SumBalanceByAccountBefore(
+		entity.WalletAccount,
+		walletNumber,
+		from,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Statement{
+		Wallet:         wallet,
+		From:           from,
+		To:             to,
+		OpeningBalance: openingBalance,
+	}, nil
+}
+
+// EachLine calls fn with every line of the statement, oldest first. The
+// lines come from the ledger, like the opening balance, so the opening
+// balance plus every line is what the ledger holds for the wallet at the
+// end of the period. They are read in batches, so a long period is never
+// held in memory at once. It stops at the first error fn returns.
+func (s *statementService) EachLine(
+	statement *entity.Statement,
+	fn func(*entity.StatementLine) error,
+) error {
+	var after *entity.StatementLine
+	for {
+		lines, _, err := s.ledgerRepository.FindStatementLinesAfter(
+			statement.Wallet.Number,
+			statement.From,
+			statement.To,
+			after,
+			STATEMENT_BATCH_SIZE,
+		)
+		if err != nil {
+			return err
+		}
+
+		hasMore := len(lines) > STATEMENT_BATCH_SIZE
+		if hasMore {
+			lines = lines[:STATEMENT_BATCH_SIZE]
+		}
+
+		for _, line := range lines {
+			err = fn(line)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !hasMore {
+			return nil
+		}
+
+		after = lines[len(lines)-1]
+	}
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewStatementService(t *testing.T) {
+	NewStatementService(
+		mocks.NewIWalletRepository(t),
+		mocks.NewILedgerRepository(t),
+	)
+}
+
+func Test_statementService_Open(t *testing.T) {
+	from := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2030, time.February, 1, 0, 0, 0, 0, time.UTC)
+	mockWallet := &entity.Wallet{Number: 100001, Balance: 75000}
+
+	tests := []struct {
+		name        string
+		mock        func(*mocks.IWalletRepository, *mocks.ILedgerRepository)
+		want        *entity.Statement
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Wallet not found",
+			mock: func(wr *mocks.IWalletRepository, lr *mocks.ILedgerRepository) {
+				wr.On("FindByNumber", 100001).Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "wallet"},
+		},
+		{
+			name: "Error | Other error from ledger repository",
+			mock: func(wr *mocks.IWalletRepository, lr *mocks.ILedgerRepository) {
+				wr.On("FindByNumber", 100001).Return(mockWallet, 1, nil)
+				lr.On("SumBalanceByAccountBefore", entity.WalletAccount, 100001, from).
+					Return(0, fmt.Errorf("error"))
+			},
+			wantErr:     true,
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name: "Success",
+			mock: func(wr *mocks.IWalletRepository, lr *mocks.ILedgerRepository) {
+				wr.On("FindByNumber", 100001).Return(mockWallet, 1, nil)
+				lr.On("SumBalanceByAccountBefore", entity.WalletAccount, 100001, from).
+					Return(50000, nil)
+			},
+			want: &entity.Statement{
+				Wallet:         mockWallet,
+				From:           from,
+				To:             to,
+				OpeningBalance: 50000,
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wr := mocks.NewIWalletRepository(t)
+			lr := mocks.NewILedgerRepository(t)
+			s := NewStatementService(wr, lr)
+
+			tt.mock(wr, lr)
+
+			got, err := s.Open(100001, from, to)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_statementService_EachLine(t *testing.T) {
+	statement := &entity.Statement{
+		Wallet: &entity.Wallet{Number: 100001},
+		From:   time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2030, time.February, 1, 0, 0, 0, 0, time.UTC),
+	}
+	newLines := func(firstID, count int) []*entity.StatementLine {
+		lines := []*entity.StatementLine{}
+		for id := firstID; id < firstID+count; id++ {
+			lines = append(lines, &entity.StatementLine{
+				JournalEntryID: id,
+				Type:           entity.Transfer,
+				Datetime:       statement.From.Add(time.Duration(id) * time.Minute),
+				Amount:         -1000,
+			})
+		}
+
+		return lines
+	}
+	isAfter := func(id int) interface{} {
+		return mock.MatchedBy(func(after *entity.StatementLine) bool {
+			if id == 0 {
+				return after == nil
+			}
+
+			return after != nil && after.JournalEntryID == id
+		})
+	}
+	findLines := func(lr *mocks.ILedgerRepository, afterID int) *mock.Call {
+		return lr.On(
+			"FindStatementLinesAfter",
+			100001,
+			statement.From,
+			statement.To,
+			isAfter(afterID),
+			STATEMENT_BATCH_SIZE,
+		)
+	}
+
+	tests := []struct {
+		name        string
+		mock        func(*mocks.ILedgerRepository)
+		fnErr       error
+		wantIDs     []int
+		expectedErr error
+	}{
+		{
+			name: "Error | Other error from repository",
+			mock: func(lr *mocks.ILedgerRepository) {
+				findLines(lr, 0).Return(nil, 0, fmt.Errorf("error"))
+			},
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name: "Error | Error from fn stops the statement",
+			mock: func(lr *mocks.ILedgerRepository) {
+				findLines(lr, 0).Return(newLines(1, 2), 2, nil)
+			},
+			fnErr:       fmt.Errorf("write error"),
+			wantIDs:     []int{1},
+			expectedErr: fmt.Errorf("write error"),
+		},
+		{
+			name: "Success | No line",
+			mock: func(lr *mocks.ILedgerRepository) {
+				findLines(lr, 0).Return([]*entity.StatementLine{}, 0, nil)
+			},
+			wantIDs: nil,
+		},
+		{
+			name: "Success | Every batch",
+			mock: func(lr *mocks.ILedgerRepository) {
+				firstBatch := newLines(1, STATEMENT_BATCH_SIZE+1)
+				findLines(lr, 0).Return(firstBatch, len(firstBatch), nil)
+
+				lastBatch := newLines(STATEMENT_BATCH_SIZE+1, 1)
+				findLines(lr, STATEMENT_BATCH_SIZE).Return(lastBatch, len(lastBatch), nil)
+			},
+			wantIDs: func() []int {
+				ids := []int{}
+				for id := 1; id <= STATEMENT_BATCH_SIZE+1; id++ {
+					ids = append(ids, id)
+				}
+
+				return ids
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := mocks.NewILedgerRepository(t)
+			s := NewStatementService(mocks.NewIWalletRepository(t), lr)
+
+			tt.mock(lr)
+
+			var gotIDs []int
+			err := s.EachLine(statement, func(line *entity.StatementLine) error {
+				gotIDs = append(gotIDs, line.JournalEntryID)
+				return tt.fnErr
+			})
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"fmt"
+	"net/http"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/helper"
+	"assignment-golang-backend/internal/ledger"
+	"assignment-golang-backend/internal/repository"
+	"assignment-golang-backend/internal/topup"
+)
+
+type ITopupService interface {
+	CreateTopup(*entity.Transaction) (*entity.Transaction, error)
+	HandleWebhook(string, []byte, http.Header) (*entity.Transaction, error)
+}
+
+type topupService struct {
+	transactionRepository      repository.ITransactionRepository
+	sourceOfFundsRepository    repository.ISourceOfFundsRepository
+	walletRepository           repository.IWalletRepository
+	transactionLimitRepository repository.ITransactionLimitRepository
+	feeRuleRepository          repository.IFeeRuleRepository
+	unitOfWork                 repository.IUnitOfWork
+	providers                  *topup.Registry
+}
+
+func NewTopupService(
+	tr repository.ITransactionRepository,
+	sr repository.ISourceOfFundsRepository,
+	wr repository.IWalletRepository,
+	lr repository.ITransactionLimitRepository,
+	fr repository.IFeeRuleRepository,
+	uow repository.IUnitOfWork,
+	providers *topup.Registry,
+) ITopupService {
+	return &topupService{
+		transactionRepository:      tr,
+		sourceOfFundsRepository:    sr,
+		walletRepository:           wr,
+		transactionLimitRepository: lr,
+		feeRuleRepository:          fr,
+		unitOfWork:                 uow,
+		providers:                  providers,
+	}
+}
+
+// CreateTopup records a PENDING top up and asks the provider of its source
+// of funds to collect it with its fee on top. The wallet is
+// credited later, when the provider confirms the payment. A top up the
+// provider refused is returned FAILED. A top up to a wallet that cannot
+// receive money, or that would take the wallet over its balance limit, is
+// refused up front.
+func (s *topupService) CreateTopup(
+	topupRecord *entity.Transaction,
+) (*entity.Transaction, error) {
+	source, rowsAffected, err := s.sourceOfFundsRepository.FindByID(
+		*topupRecord.SourceID,
+	)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.SourceOfFundsUnavailable{}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !source.Enabled {
+		return nil, &custom_error.SourceOfFundsUnavailable{}
+	}
+
+	if !helper.IsBetweenRange(
+		topupRecord.Amount,
+		source.MinTopupAmount,
+		source.MaxTopupAmount,
+	) {
+		return nil, &custom_error.AmountNotInRange{
+			Minimum: source.MinTopupAmount,
+			Maximum: source.MaxTopupAmount,
+		}
+	}
+
+	err = s.checkReceivingWallet(topupRecord.To, topupRecord.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, ok := s.providers.Get(source.Provider)
+	if !ok {
+		return nil, &custom_error.NoDataFound{DataType: "top up provider"}
+	}
+
+	topupRecord.Type = entity.TopUp
+	topupRecord.Currency = entity.BaseCurrency
+	topupRecord.Status = entity.TransactionPending
+	topupRecord.Description = fmt.Sprintf("Top Up from %s", source.Name)
+	topupRecord.Fee, err = calculateFee(s.feeRuleRepository, topupRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	topupRecord, rowsAffected, err = s.transactionRepository.CreateTransaction(
+		topupRecord,
+	)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.FailedToCreateData{DataType: "transaction"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	reference, err := provider.CreatePayment(topupRecord)
+	if err != nil {
+		topupRecord.Status = entity.TransactionFailed
+		topupRecord.FailureReason = err.Error()
+	} else {
+		topupRecord.ProviderReference = reference
+	}
+
+	_, rowsAffected, err = s.transactionRepository.UpdateStatus(
+		topupRecord,
+		entity.TransactionPending,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.FailedToUpdateData{DataType: "top up"}
+	}
+
+	topupRecord.SourceOfFunds = source
+
+	return topupRecord, nil
+}
+
+// checkReceivingWallet fails when the status of the wallet keeps it from
+// receiving money, or when it cannot hold amount more within the balance
+// limit of its user's tier.
+func (s *topupService) checkReceivingWallet(walletNumber int, amount int) error {
+	wallet, rowsAffected, err := s.walletRepository.FindByNumber(walletNumber)
+
+	if rowsAffected == 0 {
+		return &custom_error.NoDataFound{DataType: "wallet"}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !wallet.Status.CanReceive() {
+		return &custom_error.WalletCannotReceive{Status: string(wallet.Status)}
+	}
+
+	limit, err := findTransactionLimit(s.transactionLimitRepository, walletNumber)
+	if err != nil {
+		return err
+	}
+
+	if wallet.Balance+amount > limit.MaxBalance {
+		return &custom_error.BalanceLimitExceeded{
+			Remaining: remainingLimit(limit.MaxBalance, wallet.Balance),
+		}
+	}
+
+	return nil
+}
+
+// HandleWebhook settles the top up a signed webhook call of the named
+// provider reports on. Providers retry webhook calls, so a call repeating
+// the outcome of a settled top up is accepted without crediting it again.
+// A paid top up to a wallet that can no longer receive money, or that it
+// would take over its balance limit, is FAILED instead of credited.
+func (s *topupService) HandleWebhook(
+	providerName string,
+	body []byte,
+	header http.Header,
+) (*entity.Transaction, error) {
+	provider, ok := s.providers.Get(providerName)
+	if !ok {
+		return nil, &custom_error.NoDataFound{DataType: "top up provider"}
+	}
+
+	event, err := provider.ParseWebhook(body, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var topupRecord *entity.Transaction
+	err = s.unitOfWork.WithinTransaction(func(r *repository.Repositories) error {
+		var rowsAffected int
+		var err error
+
+		topupRecord, rowsAffected, err = r.Transactions.FindByProviderReference(
+			entity.TopUp,
+			event.Reference,
+		)
+
+		if rowsAffected == 0 {
+			return &custom_error.NoDataFound{DataType: "top up"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		status := entity.TransactionFailed
+		if event.Paid {
+			status = entity.TransactionCompleted
+		}
+
+		if topupRecord.Status == status {
+			return nil
+		}
+
+		if topupRecord.Status != entity.TransactionPending {
+			return &custom_error.TransactionNotPending{
+				Status: string(topupRecord.Status),
+			}
+		}
+
+		topupRecord.Status = status
+		topupRecord.FailureReason = event.FailureReason
+
+		// A paid top up is credited before its status is written, so the
+		// balance after it is written along with the status.
+		var wallet *entity.Wallet
+		if event.Paid {
+			wallet, rowsAffected, err = r.Wallets.IncrementBalanceByValue(
+				topupRecord.To,
+				topupRecord.Amount,
+			)
+
+			if rowsAffected == 0 {
+				return &custom_error.FailedToUpdateData{
+					DataType: "wallet balance",
+				}
+			}
+
+			if err != nil {
+				return err
+			}
+
+			// The status and balance limit are checked on the credited row,
+			// so a wallet that stopped receiving money since the top up was
+			// created, or that other top ups filled up, is not credited. The
+			// credit is rolled back and the top up failed.
+			if !wallet.Status.CanReceive() {
+				return &custom_error.WalletCannotReceive{
+					Status: string(wallet.Status),
+				}
+			}
+
+			err = checkDestinationBalance(r.Limits, wallet)
+			if err != nil {
+				return err
+			}
+
+			topupRecord.ToBalanceAfter = &wallet.Balance
+		}
+
+		_, rowsAffected, err = r.Transactions.UpdateStatus(
+			topupRecord,
+			entity.TransactionPending,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return &custom_error.FailedToUpdateData{DataType: "top up"}
+		}
+
+		if !event.Paid {
+			return nil
+		}
+
+		err = collectFee(r, entity.BaseCurrency, topupRecord.Fee)
+		if err != nil {
+			return err
+		}
+
+		err = postJournalEntry(r, ledger.NewTopupEntry(topupRecord))
+		if err != nil {
+			return err
+		}
+
+		topupRecord.ToWallet = *wallet
+		topupRecord.FromWallet = *wallet
+
+		return nil
+	})
+
+	switch err.(type) {
+	case *custom_error.WalletCannotReceive,
+		*custom_error.DestinationBalanceLimitExceeded:
+		return s.failTopup(topupRecord, err.Error())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return topupRecord, nil
+}
+
+// failTopup marks a paid top up the wallet could not be credited with as
+// FAILED, so the provider returns the payment.
+func (s *topupService) failTopup(
+	topupRecord *entity.Transaction,
+	reason string,
+) (*entity.Transaction, error) {
+	topupRecord.Status = entity.TransactionFailed
+	topupRecord.FailureReason = reason
+	topupRecord.ToBalanceAfter = nil
+
+	_, rowsAffected, err := s.transactionRepository.UpdateStatus(
+		topupRecord,
+		entity.TransactionPending,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.FailedToUpdateData{DataType: "top up"}
+	}
+
+	return topupRecord, nil
+}
@@ -0,0 +1,674 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/ledger"
+	"assignment-golang-backend/internal/topup"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_topupService_CreateTopup(t *testing.T) {
+	mockSourceID := entity.SourceOfFundsID(1)
+	mockSource := &entity.SourceOfFunds{
+		Base:           entity.Base{ID: 1},
+		Name:           "Bank Transfer",
+		Provider:       "mock",
+		Enabled:        true,
+		MinTopupAmount: 50000,
+		MaxTopupAmount: 10000000,
+	}
+	mockFeeRule := &entity.FeeRule{
+		Type:     entity.TopUp,
+		SourceID: &mockSourceID,
+		FlatFee:  2500,
+	}
+	newSource := func(change func(*entity.SourceOfFunds)) *entity.SourceOfFunds {
+		source := *mockSource
+		change(&source)
+		return &source
+	}
+	newTopup := func() *entity.Transaction {
+		return &entity.Transaction{
+			Base:     entity.Base{ID: 1},
+			Amount:   50000,
+			SourceID: &mockSourceID,
+			From:     100001,
+			To:       100001,
+		}
+	}
+	created := func(topup *entity.Transaction) *entity.Transaction {
+		return topup
+	}
+	isStatus := func(status entity.TransactionStatus) interface{} {
+		return mock.MatchedBy(func(topup *entity.Transaction) bool {
+			return topup.Status == status
+		})
+	}
+	isPending := mock.MatchedBy(func(topup *entity.Transaction) bool {
+		return topup.Status == entity.TransactionPending &&
+			topup.Fee == mockFeeRule.FlatFee &&
+			topup.Description == "Top Up from Bank Transfer"
+	})
+
+	tests := []struct {
+		name string
+		mock func(
+			tr *mocks.ITransactionRepository,
+			sr *mocks.ISourceOfFundsRepository,
+			tp *mocks.ITopupProvider,
+		)
+		balance       int
+		walletStatus  entity.WalletStatus
+		wantStatus    entity.TransactionStatus
+		wantReference string
+		wantErr       bool
+		expectedErr   error
+	}{
+		{
+			name: "Error | Source of funds not found",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				sr *mocks.ISourceOfFundsRepository,
+				tp *mocks.ITopupProvider,
+			) {
+				sr.On("FindByID", mockSourceID).Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.SourceOfFundsUnavailable{},
+		},
+		{
+			name: "Error | Source of funds disabled",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				sr *mocks.ISourceOfFundsRepository,
+				tp *mocks.ITopupProvider,
+			) {
+				sr.On("FindByID", mockSourceID).
+					Return(newSource(func(source *entity.SourceOfFunds) {
+						source.Enabled = false
+					}), 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.SourceOfFundsUnavailable{},
+		},
+		{
+			name: "Error | Amount not between Min and Max amount of the source of funds",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				sr *mocks.ISourceOfFundsRepository,
+				tp *mocks.ITopupProvider,
+			) {
+				sr.On("FindByID", mockSourceID).
+					Return(newSource(func(source *entity.SourceOfFunds) {
+						source.MinTopupAmount = 100000
+					}), 1, nil)
+			},
+			wantErr: true,
+			expectedErr: &custom_error.AmountNotInRange{
+				Minimum: 100000,
+				Maximum: mockSource.MaxTopupAmount,
+			},
+		},
+		{
+			name: "Error | Wallet is suspended",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				sr *mocks.ISourceOfFundsRepository,
+				tp *mocks.ITopupProvider,
+			) {
+				sr.On("FindByID", mockSourceID).Return(mockSource, 1, nil)
+			},
+			walletStatus: entity.WalletSuspended,
+			wantErr:      true,
+			expectedErr: &custom_error.WalletCannotReceive{
+				Status: string(entity.WalletSuspended),
+			},
+		},
+		{
+			name: "Error | Wallet balance limit exceeded",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				sr *mocks.ISourceOfFundsRepository,
+				tp *mocks.ITopupProvider,
+			) {
+				sr.On("FindByID", mockSourceID).Return(mockSource, 1, nil)
+			},
+			balance: mockTransactionLimit.MaxBalance - 10000,
+			wantErr: true,
+			expectedErr: &custom_error.BalanceLimitExceeded{
+				Remaining: 10000,
+			},
+		},
+		{
+			name: "Error | No provider for the source of funds",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				sr *mocks.ISourceOfFundsRepository,
+				tp *mocks.ITopupProvider,
+			) {
+				sr.On("FindByID", mockSourceID).
+					Return(newSource(func(source *entity.SourceOfFunds) {
+						source.Provider = "unknown"
+					}), 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "top up provider"},
+		},
+		{
+			name: "Error | Failed to create transaction data from transaction repository",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				sr *mocks.ISourceOfFundsRepository,
+				tp *mocks.ITopupProvider,
+			) {
+				sr.On("FindByID", mockSourceID).Return(mockSource, 1, nil)
+				tr.On("CreateTransaction", isPending).Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToCreateData{DataType: "transaction"},
+		},
+		{
+			name: "Error | Other errors from transaction repository",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				sr *mocks.ISourceOfFundsRepository,
+				tp *mocks.ITopupProvider,
+			) {
+				sr.On("FindByID", mockSourceID).Return(mockSource, 1, nil)
+				tr.On("CreateTransaction", isPending).
+					Return(nil, 1, fmt.Errorf("error"))
+			},
+			wantErr:     true,
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name: "Error | Failed to save the provider reference",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				sr *mocks.ISourceOfFundsRepository,
+				tp *mocks.ITopupProvider,
+			) {
+				sr.On("FindByID", mockSourceID).Return(mockSource, 1, nil)
+				tr.On("CreateTransaction", isPending).Return(created, 1, nil)
+				tp.On("CreatePayment", mock.Anything).Return("VA-1", nil)
+				tr.On("UpdateStatus", mock.Anything, entity.TransactionPending).
+					Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToUpdateData{DataType: "top up"},
+		},
+		{
+			name: "Success | Provider refused the payment",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				sr *mocks.ISourceOfFundsRepository,
+				tp *mocks.ITopupProvider,
+			) {
+				sr.On("FindByID", mockSourceID).Return(mockSource, 1, nil)
+				tr.On("CreateTransaction", isPending).Return(created, 1, nil)
+				tp.On("CreatePayment", mock.Anything).
+					Return("", fmt.Errorf("provider is down"))
+				tr.On("UpdateStatus", isStatus(entity.TransactionFailed), entity.TransactionPending).
+					Return(&entity.Transaction{}, 1, nil)
+			},
+			wantStatus: entity.TransactionFailed,
+		},
+		{
+			name: "Success",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				sr *mocks.ISourceOfFundsRepository,
+				tp *mocks.ITopupProvider,
+			) {
+				sr.On("FindByID", mockSourceID).Return(mockSource, 1, nil)
+				tr.On("CreateTransaction", isPending).Return(created, 1, nil)
+				tp.On("CreatePayment", mock.Anything).Return("VA-1", nil)
+				tr.On("UpdateStatus", isStatus(entity.TransactionPending), entity.TransactionPending).
+					Return(&entity.Transaction{}, 1, nil)
+			},
+			wantStatus:    entity.TransactionPending,
+			wantReference: "VA-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := mocks.NewITransactionRepository(t)
+			sr := mocks.NewISourceOfFundsRepository(t)
+			tp := mocks.NewITopupProvider(t)
+			tp.On("Name").Return("mock")
+			wr := mocks.NewIWalletRepository(t)
+			wr.On("FindByNumber", 100001).
+				Return(&entity.Wallet{
+					Number:  100001,
+					Balance: tt.balance,
+					Status:  tt.walletStatus,
+				}, 1, nil).
+				Maybe()
+			fr := mocks.NewIFeeRuleRepository(t)
+			fr.On("FindMatching", entity.TopUp, entity.BaseCurrency, &mockSourceID, 50000).
+				Return(mockFeeRule, 1, nil).
+				Maybe()
+			s := NewTopupService(
+				tr,
+				sr,
+				wr,
+				MockPermissiveLimits(t),
+				fr,
+				mocks.NewIUnitOfWork(t),
+				topup.NewRegistry(tp),
+			)
+
+			tt.mock(tr, sr, tp)
+
+			got, err := s.CreateTopup(newTopup())
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, got.Status)
+				assert.Equal(t, tt.wantReference, got.ProviderReference)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_topupService_HandleWebhook(t *testing.T) {
+	secret := "secret"
+	provider := topup.NewFakeProvider(secret)
+	mockSourceID := entity.SourceOfFundsID(1)
+	mockWallet := &entity.Wallet{Number: 100001, Balance: 50000}
+	newTopup := func(status entity.TransactionStatus) *entity.Transaction {
+		return &entity.Transaction{
+			Base:              entity.Base{ID: 1},
+			Amount:            50000,
+			Type:              entity.TopUp,
+			Status:            status,
+			SourceID:          &mockSourceID,
+			From:              100001,
+			To:                100001,
+			ProviderReference: "FAKE-VA-1",
+		}
+	}
+	newTopupWithFee := func(status entity.TransactionStatus) *entity.Transaction {
+		topupRecord := newTopup(status)
+		topupRecord.Fee = 2500
+		return topupRecord
+	}
+	paidBody, _ := json.Marshal(topup.FakeWebhookBody{
+		Reference: "FAKE-VA-1",
+		Status:    topup.FAKE_PAYMENT_PAID,
+	})
+	failedBody, _ := json.Marshal(topup.FakeWebhookBody{
+		Reference:     "FAKE-VA-1",
+		Status:        topup.FAKE_PAYMENT_FAILED,
+		FailureReason: "expired",
+	})
+	signed := func(body []byte) http.Header {
+		header := http.Header{}
+		header.Set(topup.FAKE_SIGNATURE_HEADER, provider.Sign(body))
+		return header
+	}
+	isStatus := func(status entity.TransactionStatus) interface{} {
+		return mock.MatchedBy(func(topup *entity.Transaction) bool {
+			return topup.Status == status
+		})
+	}
+
+	tests := []struct {
+		name string
+		mock func(
+			tr *mocks.ITransactionRepository,
+			wr *mocks.IWalletRepository,
+			lr *mocks.ILedgerRepository,
+		)
+		provider     string
+		body         []byte
+		header       http.Header
+		noUnitOfWork bool
+		wantStatus   entity.TransactionStatus
+		wantErr      bool
+		expectedErr  error
+	}{
+		{
+			name: "Error | Unknown provider",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+			},
+			provider:     "unknown",
+			body:         paidBody,
+			header:       signed(paidBody),
+			noUnitOfWork: true,
+			wantErr:      true,
+			expectedErr:  &custom_error.NoDataFound{DataType: "top up provider"},
+		},
+		{
+			name: "Error | Invalid signature",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+			},
+			provider:     topup.FAKE_PROVIDER_NAME,
+			body:         paidBody,
+			header:       signed(failedBody),
+			noUnitOfWork: true,
+			wantErr:      true,
+			expectedErr:  &custom_error.InvalidWebhookSignature{},
+		},
+		{
+			name: "Error | Top up not found",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(nil, 0, nil)
+			},
+			provider:    topup.FAKE_PROVIDER_NAME,
+			body:        paidBody,
+			header:      signed(paidBody),
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "top up"},
+		},
+		{
+			name: "Error | Top up already failed",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopup(entity.TransactionFailed), 1, nil)
+			},
+			provider:    topup.FAKE_PROVIDER_NAME,
+			body:        paidBody,
+			header:      signed(paidBody),
+			wantErr:     true,
+			expectedErr: &custom_error.TransactionNotPending{Status: "FAILED"},
+		},
+		{
+			name: "Error | Top up settled by another call",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopup(entity.TransactionPending), 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 50000).
+					Return(mockWallet, 1, nil)
+				tr.On("UpdateStatus", isStatus(entity.TransactionCompleted), entity.TransactionPending).
+					Return(nil, 0, nil)
+			},
+			provider:    topup.FAKE_PROVIDER_NAME,
+			body:        paidBody,
+			header:      signed(paidBody),
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToUpdateData{DataType: "top up"},
+		},
+		{
+			name: "Error | Failed to increment wallet balance from wallet repository",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopup(entity.TransactionPending), 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 50000).
+					Return(nil, 0, nil)
+			},
+			provider:    topup.FAKE_PROVIDER_NAME,
+			body:        paidBody,
+			header:      signed(paidBody),
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToUpdateData{DataType: "wallet balance"},
+		},
+		{
+			name: "Error | Failed to create journal entry from ledger repository",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopup(entity.TransactionPending), 1, nil)
+				tr.On("UpdateStatus", isStatus(entity.TransactionCompleted), entity.TransactionPending).
+					Return(&entity.Transaction{}, 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 50000).
+					Return(mockWallet, 1, nil)
+				lr.On("CreateJournalEntry", ledger.NewTopupEntry(newTopup(entity.TransactionCompleted))).
+					Return(nil, 0, nil)
+			},
+			provider:    topup.FAKE_PROVIDER_NAME,
+			body:        paidBody,
+			header:      signed(paidBody),
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToCreateData{DataType: "journal entry"},
+		},
+		{
+			name: "Error | Failed to fail the top up of a wallet that cannot receive",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopup(entity.TransactionPending), 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 50000).
+					Return(&entity.Wallet{Number: 100001, Status: entity.WalletClosed}, 1, nil)
+				tr.On("UpdateStatus", isStatus(entity.TransactionFailed), entity.TransactionPending).
+					Return(nil, 0, nil)
+			},
+			provider:    topup.FAKE_PROVIDER_NAME,
+			body:        paidBody,
+			header:      signed(paidBody),
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToUpdateData{DataType: "top up"},
+		},
+		{
+			name: "Success | Paid top up of a wallet that cannot receive is failed",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopup(entity.TransactionPending), 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 50000).
+					Return(&entity.Wallet{Number: 100001, Status: entity.WalletSuspended}, 1, nil)
+				tr.On(
+					"UpdateStatus",
+					mock.MatchedBy(func(topup *entity.Transaction) bool {
+						return topup.Status == entity.TransactionFailed &&
+							topup.FailureReason == custom_error.WalletCannotReceive{
+								Status: string(entity.WalletSuspended),
+							}.Error() &&
+							topup.ToBalanceAfter == nil
+					}),
+					entity.TransactionPending,
+				).Return(&entity.Transaction{}, 1, nil)
+			},
+			provider:   topup.FAKE_PROVIDER_NAME,
+			body:       paidBody,
+			header:     signed(paidBody),
+			wantStatus: entity.TransactionFailed,
+		},
+		{
+			name: "Success | Paid top up over the balance limit of the wallet is failed",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopup(entity.TransactionPending), 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 50000).
+					Return(&entity.Wallet{
+						Number:  100001,
+						Balance: mockTransactionLimit.MaxBalance + 1,
+					}, 1, nil)
+				tr.On(
+					"UpdateStatus",
+					mock.MatchedBy(func(topup *entity.Transaction) bool {
+						return topup.Status == entity.TransactionFailed &&
+							topup.FailureReason == custom_error.DestinationBalanceLimitExceeded{}.Error()
+					}),
+					entity.TransactionPending,
+				).Return(&entity.Transaction{}, 1, nil)
+			},
+			provider:   topup.FAKE_PROVIDER_NAME,
+			body:       paidBody,
+			header:     signed(paidBody),
+			wantStatus: entity.TransactionFailed,
+		},
+		{
+			name: "Success | Replayed webhook of a completed top up",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopup(entity.TransactionCompleted), 1, nil)
+			},
+			provider:   topup.FAKE_PROVIDER_NAME,
+			body:       paidBody,
+			header:     signed(paidBody),
+			wantStatus: entity.TransactionCompleted,
+		},
+		{
+			name: "Success | Failed payment",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopup(entity.TransactionPending), 1, nil)
+				tr.On("UpdateStatus", isStatus(entity.TransactionFailed), entity.TransactionPending).
+					Return(&entity.Transaction{}, 1, nil)
+			},
+			provider:   topup.FAKE_PROVIDER_NAME,
+			body:       failedBody,
+			header:     signed(failedBody),
+			wantStatus: entity.TransactionFailed,
+		},
+		{
+			name: "Success",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopup(entity.TransactionPending), 1, nil)
+				tr.On("UpdateStatus", isStatus(entity.TransactionCompleted), entity.TransactionPending).
+					Return(&entity.Transaction{}, 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 50000).
+					Return(mockWallet, 1, nil)
+				lr.On("CreateJournalEntry", ledger.NewTopupEntry(newTopup(entity.TransactionCompleted))).
+					Return(&entity.JournalEntry{}, 1, nil)
+			},
+			provider:   topup.FAKE_PROVIDER_NAME,
+			body:       paidBody,
+			header:     signed(paidBody),
+			wantStatus: entity.TransactionCompleted,
+		},
+		{
+			name: "Error | Failed to collect the fee into the house wallet",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopupWithFee(entity.TransactionPending), 1, nil)
+				tr.On("UpdateStatus", isStatus(entity.TransactionCompleted), entity.TransactionPending).
+					Return(&entity.Transaction{}, 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 50000).
+					Return(mockWallet, 1, nil)
+				wr.On("IncrementBalanceByValue", entity.HouseWalletNumber, 2500).
+					Return(nil, 0, nil)
+			},
+			provider: topup.FAKE_PROVIDER_NAME,
+			body:     paidBody,
+			header:   signed(paidBody),
+			wantErr:  true,
+			expectedErr: &custom_error.FailedToUpdateData{
+				DataType: "house wallet balance",
+			},
+		},
+		{
+			name: "Success | Fee collected into the house wallet",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByProviderReference", entity.TopUp, "FAKE-VA-1").
+					Return(newTopupWithFee(entity.TransactionPending), 1, nil)
+				tr.On("UpdateStatus", isStatus(entity.TransactionCompleted), entity.TransactionPending).
+					Return(&entity.Transaction{}, 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 50000).
+					Return(mockWallet, 1, nil)
+				wr.On("IncrementBalanceByValue", entity.HouseWalletNumber, 2500).
+					Return(&entity.Wallet{Number: entity.HouseWalletNumber}, 1, nil)
+				lr.On("CreateJournalEntry", ledger.NewTopupEntry(newTopupWithFee(entity.TransactionCompleted))).
+					Return(&entity.JournalEntry{}, 1, nil)
+			},
+			provider:   topup.FAKE_PROVIDER_NAME,
+			body:       paidBody,
+			header:     signed(paidBody),
+			wantStatus: entity.TransactionCompleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := mocks.NewITransactionRepository(t)
+			wr := mocks.NewIWalletRepository(t)
+			lr := mocks.NewILedgerRepository(t)
+			uow := mocks.NewIUnitOfWork(t)
+			if !tt.noUnitOfWork {
+				uow = MockUnitOfWork(t, tr, wr, lr)
+			}
+			s := NewTopupService(
+				tr,
+				mocks.NewISourceOfFundsRepository(t),
+				wr,
+				mocks.NewITransactionLimitRepository(t),
+				mocks.NewIFeeRuleRepository(t),
+				uow,
+				topup.NewRegistry(provider),
+			)
+
+			tt.mock(tr, wr, lr)
+
+			got, err := s.HandleWebhook(tt.provider, tt.body, tt.header)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, got.Status)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
@@ -5,37 +5,99 @@ import (
 
 	"assignment-golang-backend/internal/custom_error"
 	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/fx"
+	"assignment-golang-backend/internal/ledger"
 	"assignment-golang-backend/internal/repository"
 )
 
 type ITransactionService interface {
-	CreateTopup(*entity.Transaction) (*entity.Transaction, error)
 	CreateTransaction(*entity.Transaction) (*entity.Transaction, error)
+	CreateRefund(*entity.Transaction) (*entity.Transaction, error)
 	FindByWalletNumber(
 		int,
+		*entity.TransactionFilter,
 		*entity.Pagination,
 	) ([]*entity.Transaction, *entity.Pagination, error)
+	FindByWalletNumberAfterCursor(
+		int,
+		*entity.TransactionFilter,
+		*entity.CursorPagination,
+	) ([]*entity.Transaction, *entity.CursorPagination, error)
+	FindDetailByID(int, int) (*entity.TransactionDetail, error)
 }
 
 type transactionService struct {
 	transactionRepository repository.ITransactionRepository
 	walletRepository      repository.IWalletRepository
+	userRepository        repository.IUserRepository
+	unitOfWork            repository.IUnitOfWork
+	rates                 fx.IRateProvider
 }
 
 func NewTransactionService(
 	tr repository.ITransactionRepository,
 	wr repository.IWalletRepository,
+	ur repository.IUserRepository,
+	uow repository.IUnitOfWork,
+	rates fx.IRateProvider,
 ) ITransactionService {
 	return &transactionService{
 		transactionRepository: tr,
 		walletRepository:      wr,
+		userRepository:        ur,
+		unitOfWork:            uow,
+		rates:                 rates,
 	}
 }
 
 func (s *transactionService) CreateTransaction(
 	transferRecord *entity.Transaction,
 ) (*entity.Transaction, error) {
-	fromWallet, rowsAffected, err := s.walletRepository.FindByNumber(
+	err := s.unitOfWork.WithinTransaction(func(r *repository.Repositories) error {
+		var err error
+		transferRecord, err = createTransfer(r, s.rates, transferRecord)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return transferRecord, nil
+}
+
+// createTransfer moves the amount between two wallets and records the
+// transaction and its journal entry. It must run inside a unit of work so
+// callers can make the transfer part of a larger change. The amount is in
+// the currency of the sender, and is converted at the rate of rates when
+// the receiver's wallet holds another currency. The sender pays the fee of
+// the transfer on top of the amount, and the fee is collected into the
+// house wallet of the sender's currency. Transfers are held to the
+// transaction limits of both wallets; refunds only return money and are
+// not. Moving money between the wallets of one user is free and only held
+// to the balance limit of the receiving wallet. Neither wallet may have a
+// status that keeps it from sending or receiving.
+func createTransfer(
+	r *repository.Repositories,
+	rates fx.IRateProvider,
+	transferRecord *entity.Transaction,
+) (*entity.Transaction, error) {
+	// Moves between own wallets and refunds are not held to the transfer
+	// limits, so the amount is checked to be positive for every transfer.
+	if transferRecord.Amount <= 0 {
+		return nil, &custom_error.AmountNotInRange{
+			Minimum: 1,
+			Maximum: math.MaxInt,
+		}
+	}
+
+	// House wallets only collect fees and have no tier to limit transfers
+	// to them by.
+	if transferRecord.To <= entity.HouseWalletNumber {
+		return nil, &custom_error.CannotTransferToHouseWallet{}
+	}
+
+	fromWallet, rowsAffected, err := r.Wallets.FindByNumber(
 		transferRecord.From,
 	)
 
@@ -47,11 +109,13 @@ func (s *transactionService) CreateTransaction(
 		return nil, err
 	}
 
-	if fromWallet.Balance < transferRecord.Amount {
-		return nil, &custom_error.InsufficientBalance{}
+	if !fromWallet.Status.CanSend() {
+		return nil, &custom_error.WalletCannotSend{
+			Status: string(fromWallet.Status),
+		}
 	}
 
-	_, rowsAffected, err = s.walletRepository.FindByNumber(
+	toWallet, rowsAffected, err := r.Wallets.FindByNumber(
 		transferRecord.To,
 	)
 
@@ -63,24 +127,74 @@ func (s *transactionService) CreateTransaction(
 		return nil, err
 	}
 
-	fromWallet, rowsAffected, err = s.walletRepository.DecrementBalanceByValue(
+	isOwnMove := fromWallet.IsSameOwner(toWallet)
+	isLimited := transferRecord.Type == entity.Transfer && !isOwnMove
+
+	var limit *entity.TransactionLimit
+	if isLimited {
+		limit, err = findTransactionLimit(r.Limits, transferRecord.From)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkTransferAmount(limit, transferRecord.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transferRecord.Currency = fromWallet.Currency
+	fee := 0
+	if !isOwnMove {
+		fee, err = calculateFee(r.FeeRules, transferRecord)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transferRecord.Fee = fee
+	grossAmount := transferRecord.Amount + fee
+
+	if fromWallet.Balance < grossAmount {
+		return nil, &custom_error.InsufficientBalance{}
+	}
+
+	if toWallet.Currency != fromWallet.Currency {
+		err = convertTransfer(rates, transferRecord, toWallet.Currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fromWallet, rowsAffected, err = r.Wallets.DecrementBalanceByValue(
 		transferRecord.From,
-		transferRecord.Amount,
+		grossAmount,
 	)
 
 	if rowsAffected == 0 {
-		return nil, &custom_error.FailedToUpdateData{
-			DataType: "source wallet balance",
-		}
+		return nil, &custom_error.InsufficientBalance{}
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	toWallet, rowsAffected, err := s.walletRepository.IncrementBalanceByValue(
+	if isLimited {
+		err = checkOutgoingLimits(
+			r.Limits,
+			limit,
+			transferRecord.From,
+			transferRecord.Amount,
+			transferRecord.Datetime,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	toWallet, rowsAffected, err = r.Wallets.IncrementBalanceByValue(
 		transferRecord.To,
-		transferRecord.Amount,
+		transferRecord.ReceivedAmount(),
 	)
 
 	if rowsAffected == 0 {
@@ -93,7 +207,27 @@ func (s *transactionService) CreateTransaction(
 		return nil, err
 	}
 
-	transferRecord, rowsAffected, err = s.transactionRepository.CreateTransaction(
+	// The status is checked on the updated row, so a wallet closed while
+	// the transfer ran does not receive it.
+	if !toWallet.Status.CanReceive() {
+		return nil, &custom_error.DestinationWalletCannotReceive{}
+	}
+
+	if transferRecord.Type == entity.Transfer {
+		err = checkDestinationBalance(r.Limits, toWallet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = collectFee(r, transferRecord.Currency, fee)
+	if err != nil {
+		return nil, err
+	}
+
+	transferRecord.FromBalanceAfter = &fromWallet.Balance
+	transferRecord.ToBalanceAfter = &toWallet.Balance
+	transferRecord, rowsAffected, err = r.Transactions.CreateTransaction(
 		transferRecord,
 	)
 
@@ -105,52 +239,110 @@ func (s *transactionService) CreateTransaction(
 		return nil, err
 	}
 
+	err = postJournalEntry(r, ledger.NewTransferEntry(transferRecord))
+	if err != nil {
+		return nil, err
+	}
+
 	transferRecord.FromWallet = *fromWallet
 	transferRecord.ToWallet = *toWallet
 
 	return transferRecord, nil
 }
 
-func (s *transactionService) CreateTopup(
-	topup *entity.Transaction,
-) (*entity.Transaction, error) {
-	topup, rowsAffected, err := s.transactionRepository.CreateTransaction(topup)
-
-	if rowsAffected == 0 {
-		return nil, &custom_error.FailedToCreateData{DataType: "transaction"}
+// convertTransfer records what the amount of a transfer is worth in the
+// currency of the receiver at the current rate.
+func convertTransfer(
+	rates fx.IRateProvider,
+	transferRecord *entity.Transaction,
+	currency entity.Currency,
+) error {
+	rate, err := rates.Rate(transferRecord.Currency, currency)
+	if err != nil {
+		return err
 	}
 
+	convertedAmount, err := fx.Convert(
+		transferRecord.Amount,
+		rate,
+		transferRecord.Currency,
+		currency,
+	)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	wallet, rowsAffected, err := s.walletRepository.IncrementBalanceByValue(
-		topup.To,
-		topup.Amount,
-	)
+	if convertedAmount <= 0 {
+		return &custom_error.ConvertedAmountTooSmall{}
+	}
 
-	if rowsAffected == 0 {
-		return nil, &custom_error.FailedToUpdateData{
-			DataType: "wallet balance",
+	transferRecord.ConvertedAmount = &convertedAmount
+	transferRecord.ConvertedCurrency = currency
+	transferRecord.ExchangeRate = rate
+
+	return nil
+}
+
+// CreateRefund sends money of a transfer back to its sender. refund.From is
+// the wallet that received the transfer and refund.ReferenceID the transfer.
+// Refunds are in the currency of the wallet that received the transfer and
+// may be partial, but together never exceed what it received.
+func (s *transactionService) CreateRefund(
+	refund *entity.Transaction,
+) (*entity.Transaction, error) {
+	err := s.unitOfWork.WithinTransaction(func(r *repository.Repositories) error {
+		original, rowsAffected, err := r.Transactions.FindByID(
+			*refund.ReferenceID,
+		)
+
+		if rowsAffected == 0 || original.To != refund.From {
+			return &custom_error.NoDataFound{DataType: "transaction"}
+		}
+
+		if err != nil {
+			return err
 		}
-	}
+
+		if original.Type != entity.Transfer {
+			return &custom_error.TransactionNotRefundable{}
+		}
+
+		_, rowsAffected, err = r.Transactions.AddRefundedAmount(
+			original.ID,
+			refund.Amount,
+		)
+
+		if rowsAffected == 0 {
+			return &custom_error.RefundExceedsAmount{
+				Refundable: original.RefundableAmount(),
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		refund.Type = entity.Refund
+		refund.To = original.From
+		refund, err = createTransfer(r, s.rates, refund)
+		return err
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	topup.ToWallet = *wallet
-	topup.FromWallet = *wallet
-
-	return topup, nil
+	return refund, nil
 }
 
 func (s *transactionService) FindByWalletNumber(
 	walletNumber int,
+	filter *entity.TransactionFilter,
 	pagination *entity.Pagination,
 ) ([]*entity.Transaction, *entity.Pagination, error) {
 	transactions, rowsAffected, err := s.transactionRepository.FindByWalletNumberWithQuery(
 		walletNumber,
+		filter,
 		pagination,
 	)
 
@@ -164,7 +356,7 @@ func (s *transactionService) FindByWalletNumber(
 
 	totalRows := s.transactionRepository.CountTransactionByWalletNumber(
 		walletNumber,
-		pagination.Search,
+		filter,
 	)
 
 	totalPages := int(math.Ceil(float64(totalRows) / float64(pagination.Limit)))
@@ -174,3 +366,100 @@ func (s *transactionService) FindByWalletNumber(
 
 	return transactions, pagination, nil
 }
+
+// FindByWalletNumberAfterCursor returns a page of the wallet's transactions
+// that follow pagination.After, and sets the cursor of the next page. It
+// does not count the transactions.
+func (s *transactionService) FindByWalletNumberAfterCursor(
+	walletNumber int,
+	filter *entity.TransactionFilter,
+	pagination *entity.CursorPagination,
+) ([]*entity.Transaction, *entity.CursorPagination, error) {
+	transactions, rowsAffected, err := s.transactionRepository.FindByWalletNumberAfterCursor(
+		walletNumber,
+		filter,
+		pagination,
+	)
+
+	if rowsAffected == 0 {
+		return nil, nil, &custom_error.NoDataFound{DataType: "transaction"}
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pagination.NextCursor = ""
+	if len(transactions) > pagination.Limit {
+		transactions = transactions[:pagination.Limit]
+		last := transactions[len(transactions)-1]
+		pagination.NextCursor = (&entity.TransactionCursor{
+			Datetime: last.Datetime,
+			ID:       last.ID,
+		}).Encode()
+	}
+
+	return transactions, pagination, nil
+}
+
+// FindDetailByID returns the transaction with the names of the users who
+// sent and received it. Only the users whose wallets sent or received it
+// can see it, to anyone else it is not found.
+func (s *transactionService) FindDetailByID(
+	id int,
+	userID int,
+) (*entity.TransactionDetail, error) {
+	transaction, rowsAffected, err := s.transactionRepository.FindByIDWithWallets(id)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "transaction"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !transaction.FromWallet.IsOwnedBy(userID) &&
+		!transaction.ToWallet.IsOwnedBy(userID) {
+		return nil, &custom_error.NoDataFound{DataType: "transaction"}
+	}
+
+	detail := &entity.TransactionDetail{Transaction: transaction}
+	if transaction.Type != entity.Transfer && transaction.Type != entity.Refund {
+		return detail, nil
+	}
+
+	detail.SenderName, err = s.findOwnerName(&transaction.FromWallet)
+	if err != nil {
+		return nil, err
+	}
+
+	detail.RecipientName, err = s.findOwnerName(&transaction.ToWallet)
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// findOwnerName returns the name of the user who owns the wallet, or an
+// empty name when no user owns it anymore.
+func (s *transactionService) findOwnerName(
+	wallet *entity.Wallet,
+) (string, error) {
+	if wallet.UserID == nil {
+		return "", nil
+	}
+
+	user, rowsAffected, err := s.userRepository.FindByID(*wallet.UserID)
+
+	if rowsAffected == 0 {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return user.Name, nil
+}
@@ -3,189 +3,90 @@ package usecase
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"assignment-golang-backend/internal/custom_error"
 	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/ledger"
+	"assignment-golang-backend/internal/repository"
 	"assignment-golang-backend/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+func MockUnitOfWork(
+	t *testing.T,
+	tr *mocks.ITransactionRepository,
+	wr *mocks.IWalletRepository,
+	lr *mocks.ILedgerRepository,
+) *mocks.IUnitOfWork {
+	uow := mocks.NewIUnitOfWork(t)
+	uow.On("WithinTransaction", mock.Anything).
+		Return(func(fn func(*repository.Repositories) error) error {
+			return fn(&repository.Repositories{
+				Transactions: tr,
+				Wallets:      wr,
+				Ledger:       lr,
+				Limits:       MockPermissiveLimits(t),
+				FeeRules:     MockNoFees(t),
+			})
+		})
+
+	return uow
+}
+
 func TestNewTransactionService(t *testing.T) {
 	NewTransactionService(
 		mocks.NewITransactionRepository(t),
 		mocks.NewIWalletRepository(t),
+		mocks.NewIUserRepository(t),
+		mocks.NewIUnitOfWork(t),
+		mocks.NewIRateProvider(t),
 	)
 }
 
-func Test_transactionService_CreateTopup(t *testing.T) {
-	mockTopup := &entity.Transaction{
-		To:     1,
-		Amount: 1,
-	}
-	mockWallet := &entity.Wallet{}
-
-	type repositories struct {
-		transactionRepository *mocks.ITransactionRepository
-		walletRepository      *mocks.IWalletRepository
-	}
-	tests := []struct {
-		name         string
-		repositories repositories
-		mock         func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository)
-		topup        *entity.Transaction
-		want         *entity.Transaction
-		wantErr      bool
-		expectedErr  error
-	}{
-		{
-			name: "Error | Failed to create transaction data from transaction repository",
-			repositories: repositories{
-				transactionRepository: mocks.NewITransactionRepository(t),
-				walletRepository:      mocks.NewIWalletRepository(t),
-			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
-				tr.On("CreateTransaction", &entity.Transaction{}).
-					Return(nil, 0, nil)
-			},
-			topup:   &entity.Transaction{},
-			want:    nil,
-			wantErr: true,
-			expectedErr: &custom_error.FailedToCreateData{
-				DataType: "transaction",
-			},
-		},
-		{
-			name: "Error | Other errors from transaction repository",
-			repositories: repositories{
-				transactionRepository: mocks.NewITransactionRepository(t),
-				walletRepository:      mocks.NewIWalletRepository(t),
-			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
-				tr.On("CreateTransaction", &entity.Transaction{}).
-					Return(nil, 1, fmt.Errorf("error"))
-			},
-			topup:       &entity.Transaction{},
-			want:        nil,
-			wantErr:     true,
-			expectedErr: fmt.Errorf("error"),
-		},
-		{
-			name: "Error | Failed to create increment wallet balance from wallet repository",
-			repositories: repositories{
-				transactionRepository: mocks.NewITransactionRepository(t),
-				walletRepository:      mocks.NewIWalletRepository(t),
-			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
-				tr.On("CreateTransaction", mockTopup).
-					Return(mockTopup, 1, nil)
-				wr.On("IncrementBalanceByValue", mockTopup.To, mockTopup.Amount).
-					Return(nil, 0, nil)
-			},
-			topup:   mockTopup,
-			want:    nil,
-			wantErr: true,
-			expectedErr: &custom_error.FailedToUpdateData{
-				DataType: "wallet balance",
-			},
-		},
-		{
-			name: "Error | Other errors from wallet repository",
-			repositories: repositories{
-				transactionRepository: mocks.NewITransactionRepository(t),
-				walletRepository:      mocks.NewIWalletRepository(t),
-			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
-				tr.On("CreateTransaction", mockTopup).
-					Return(mockTopup, 1, nil)
-				wr.On("IncrementBalanceByValue", mockTopup.To, mockTopup.Amount).
-					Return(nil, 1, fmt.Errorf("error"))
-			},
-			topup:       mockTopup,
-			want:        nil,
-			wantErr:     true,
-			expectedErr: fmt.Errorf("error"),
-		},
-		{
-			name: "Success",
-			repositories: repositories{
-				transactionRepository: mocks.NewITransactionRepository(t),
-				walletRepository:      mocks.NewIWalletRepository(t),
-			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
-				tr.On("CreateTransaction", mockTopup).
-					Return(mockTopup, 1, nil)
-				wr.On("IncrementBalanceByValue", mockTopup.To, mockTopup.Amount).
-					Return(mockWallet, 1, nil)
-			},
-			topup: mockTopup,
-			want: &entity.Transaction{
-				To:         mockTopup.To,
-				Amount:     mockTopup.Amount,
-				ToWallet:   *mockWallet,
-				FromWallet: *mockWallet,
-			},
-			wantErr:     false,
-			expectedErr: nil,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &transactionService{
-				transactionRepository: tt.repositories.transactionRepository,
-				walletRepository:      tt.repositories.walletRepository,
-			}
-
-			tt.mock(
-				tt.repositories.transactionRepository,
-				tt.repositories.walletRepository,
-			)
-
-			got, err := s.CreateTopup(tt.topup)
-
-			if !tt.wantErr {
-				assert.NoError(t, err)
-			} else {
-				assert.Error(t, err)
-				assert.EqualError(t, err, tt.expectedErr.Error())
-			}
-
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func Test_transactionService_CreateTransaction(t *testing.T) {
 	mockTransfer := &entity.Transaction{
 		Amount:      1000,
 		Description: "",
 		Type:        entity.Transfer,
-		From:        1,
-		To:          2,
+		From:        100001,
+		To:          100002,
 	}
-	mockFromWallet := &entity.Wallet{Number: 1, Balance: mockTransfer.Amount}
-	mockToWallet := &entity.Wallet{Number: 2, Balance: mockTransfer.Amount}
+	mockFromWallet := &entity.Wallet{Number: 100001, Balance: mockTransfer.Amount}
+	mockToWallet := &entity.Wallet{Number: 100002, Balance: mockTransfer.Amount}
 	mockOtherError := fmt.Errorf("error")
 	type repositories struct {
 		transactionRepository *mocks.ITransactionRepository
 		walletRepository      *mocks.IWalletRepository
+		ledgerRepository      *mocks.ILedgerRepository
 	}
 	tests := []struct {
 		name         string
 		repositories repositories
-		mock         func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository)
-		transfer     *entity.Transaction
-		want         *entity.Transaction
-		wantErr      bool
-		expectedErr  error
+		mock         func(
+			tr *mocks.ITransactionRepository,
+			wr *mocks.IWalletRepository,
+			lr *mocks.ILedgerRepository,
+		)
+		transfer    *entity.Transaction
+		want        *entity.Transaction
+		wantErr     bool
+		expectedErr error
 	}{
 		{
 			name: "Error | No source wallet found from wallet repository",
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(nil, 0, nil)
 			},
@@ -201,8 +102,13 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(nil, 1, mockOtherError)
 			},
@@ -211,15 +117,48 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			wantErr:     true,
 			expectedErr: mockOtherError,
 		},
+		{
+			name: "Error | Source wallet is frozen",
+			repositories: repositories{
+				transactionRepository: mocks.NewITransactionRepository(t),
+				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
+			},
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				wr.On("FindByNumber", mockTransfer.From).
+					Return(&entity.Wallet{
+						Number:  100001,
+						Balance: mockTransfer.Amount,
+						Status:  entity.WalletFrozen,
+					}, 1, nil)
+			},
+			transfer: mockTransfer,
+			want:     nil,
+			wantErr:  true,
+			expectedErr: &custom_error.WalletCannotSend{
+				Status: string(entity.WalletFrozen),
+			},
+		},
 		{
 			name: "Error | Source wallet's balance is insufficient",
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(&entity.Wallet{Balance: 0}, 1, nil)
+				wr.On("FindByNumber", mockTransfer.To).
+					Return(mockToWallet, 1, nil)
 			},
 			transfer:    mockTransfer,
 			want:        nil,
@@ -231,8 +170,13 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(mockFromWallet, 1, nil)
 				wr.On("FindByNumber", mockTransfer.To).
@@ -250,8 +194,13 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(mockFromWallet, 1, nil)
 				wr.On("FindByNumber", mockTransfer.To).
@@ -263,12 +212,17 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			expectedErr: mockOtherError,
 		},
 		{
-			name: "Error | Failed to decrement source wallet balance error",
+			name: "Error | Source wallet balance drained by a concurrent transfer",
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(mockFromWallet, 1, nil)
 				wr.On("FindByNumber", mockTransfer.To).
@@ -276,20 +230,23 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 				wr.On("DecrementBalanceByValue", mockTransfer.From, mockTransfer.Amount).
 					Return(nil, 0, nil)
 			},
-			transfer: mockTransfer,
-			want:     nil,
-			wantErr:  true,
-			expectedErr: &custom_error.FailedToUpdateData{
-				DataType: "source wallet balance",
-			},
+			transfer:    mockTransfer,
+			want:        nil,
+			wantErr:     true,
+			expectedErr: &custom_error.InsufficientBalance{},
 		},
 		{
 			name: "Error | Other error from repository when decrement source wallet balance",
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(mockFromWallet, 1, nil)
 				wr.On("FindByNumber", mockTransfer.To).
@@ -307,8 +264,13 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(mockFromWallet, 1, nil)
 				wr.On("FindByNumber", mockTransfer.To).
@@ -325,13 +287,47 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 				DataType: "destination wallet balance",
 			},
 		},
+		{
+			name: "Error | Destination wallet is suspended",
+			repositories: repositories{
+				transactionRepository: mocks.NewITransactionRepository(t),
+				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
+			},
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				wr.On("FindByNumber", mockTransfer.From).
+					Return(mockFromWallet, 1, nil)
+				wr.On("FindByNumber", mockTransfer.To).
+					Return(mockToWallet, 1, nil)
+				wr.On("DecrementBalanceByValue", mockTransfer.From, mockTransfer.Amount).
+					Return(mockFromWallet, 1, nil)
+				wr.On("IncrementBalanceByValue", mockTransfer.To, mockTransfer.Amount).
+					Return(&entity.Wallet{
+						Number: 100002,
+						Status: entity.WalletSuspended,
+					}, 1, nil)
+			},
+			transfer:    mockTransfer,
+			want:        nil,
+			wantErr:     true,
+			expectedErr: &custom_error.DestinationWalletCannotReceive{},
+		},
 		{
 			name: "Error | Other error from repository when decrementing destination wallet balance",
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(mockFromWallet, 1, nil)
 				wr.On("FindByNumber", mockTransfer.To).
@@ -351,8 +347,13 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(mockFromWallet, 1, nil)
 				wr.On("FindByNumber", mockTransfer.To).
@@ -376,8 +377,13 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(mockFromWallet, 1, nil)
 				wr.On("FindByNumber", mockTransfer.To).
@@ -394,13 +400,48 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			wantErr:     true,
 			expectedErr: mockOtherError,
 		},
+		{
+			name: "Error | Other error when creating journal entry",
+			repositories: repositories{
+				transactionRepository: mocks.NewITransactionRepository(t),
+				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
+			},
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				wr.On("FindByNumber", mockTransfer.From).
+					Return(mockFromWallet, 1, nil)
+				wr.On("FindByNumber", mockTransfer.To).
+					Return(mockToWallet, 1, nil)
+				wr.On("DecrementBalanceByValue", mockTransfer.From, mockTransfer.Amount).
+					Return(mockFromWallet, 1, nil)
+				wr.On("IncrementBalanceByValue", mockTransfer.To, mockTransfer.Amount).
+					Return(mockToWallet, 1, nil)
+				tr.On("CreateTransaction", mockTransfer).
+					Return(mockTransfer, 1, nil)
+				lr.On("CreateJournalEntry", ledger.NewTransferEntry(mockTransfer)).
+					Return(nil, 1, mockOtherError)
+			},
+			transfer:    mockTransfer,
+			want:        nil,
+			wantErr:     true,
+			expectedErr: mockOtherError,
+		},
 		{
 			name: "Success",
 			repositories: repositories{
 				transactionRepository: mocks.NewITransactionRepository(t),
 				walletRepository:      mocks.NewIWalletRepository(t),
+				ledgerRepository:      mocks.NewILedgerRepository(t),
 			},
-			mock: func(tr *mocks.ITransactionRepository, wr *mocks.IWalletRepository) {
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
 				wr.On("FindByNumber", mockTransfer.From).
 					Return(mockFromWallet, 1, nil)
 				wr.On("FindByNumber", mockTransfer.To).
@@ -411,16 +452,20 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 					Return(mockToWallet, 1, nil)
 				tr.On("CreateTransaction", mockTransfer).
 					Return(mockTransfer, 1, nil)
+				lr.On("CreateJournalEntry", ledger.NewTransferEntry(mockTransfer)).
+					Return(&entity.JournalEntry{}, 1, nil)
 			},
 			transfer: mockTransfer,
 			want: &entity.Transaction{
-				Amount:      mockTransfer.Amount,
-				Description: mockTransfer.Description,
-				Type:        mockTransfer.Type,
-				From:        mockTransfer.From,
-				To:          mockTransfer.To,
-				FromWallet:  *mockFromWallet,
-				ToWallet:    *mockToWallet,
+				Amount:           mockTransfer.Amount,
+				Description:      mockTransfer.Description,
+				Type:             mockTransfer.Type,
+				From:             mockTransfer.From,
+				To:               mockTransfer.To,
+				FromWallet:       *mockFromWallet,
+				ToWallet:         *mockToWallet,
+				FromBalanceAfter: &mockFromWallet.Balance,
+				ToBalanceAfter:   &mockToWallet.Balance,
 			},
 			wantErr:     false,
 			expectedErr: nil,
@@ -431,11 +476,18 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 			s := &transactionService{
 				transactionRepository: tt.repositories.transactionRepository,
 				walletRepository:      tt.repositories.walletRepository,
+				unitOfWork: MockUnitOfWork(
+					t,
+					tt.repositories.transactionRepository,
+					tt.repositories.walletRepository,
+					tt.repositories.ledgerRepository,
+				),
 			}
 
 			tt.mock(
 				tt.repositories.transactionRepository,
 				tt.repositories.walletRepository,
+				tt.repositories.ledgerRepository,
 			)
 
 			got, err := s.CreateTransaction(tt.transfer)
@@ -453,6 +505,7 @@ func Test_transactionService_CreateTransaction(t *testing.T) {
 }
 
 func Test_transactionService_FindByWalletNumber(t *testing.T) {
+	filter := &entity.TransactionFilter{Direction: entity.Incoming}
 
 	tests := []struct {
 		name                  string
@@ -469,7 +522,7 @@ func Test_transactionService_FindByWalletNumber(t *testing.T) {
 			name:                  "Error | No Data Found",
 			transactionRepository: mocks.NewITransactionRepository(t),
 			mock: func(tr *mocks.ITransactionRepository) {
-				tr.On("FindByWalletNumberWithQuery", 1, &entity.Pagination{}).
+				tr.On("FindByWalletNumberWithQuery", 1, filter, &entity.Pagination{}).
 					Return(nil, 0, nil)
 			},
 			walletNumber: 1,
@@ -482,7 +535,7 @@ func Test_transactionService_FindByWalletNumber(t *testing.T) {
 			name:                  "Error | Other error from repository",
 			transactionRepository: mocks.NewITransactionRepository(t),
 			mock: func(tr *mocks.ITransactionRepository) {
-				tr.On("FindByWalletNumberWithQuery", 1, &entity.Pagination{}).
+				tr.On("FindByWalletNumberWithQuery", 1, filter, &entity.Pagination{}).
 					Return(nil, 1, fmt.Errorf("error"))
 			},
 			walletNumber: 1,
@@ -495,9 +548,9 @@ func Test_transactionService_FindByWalletNumber(t *testing.T) {
 			name:                  "Success",
 			transactionRepository: mocks.NewITransactionRepository(t),
 			mock: func(tr *mocks.ITransactionRepository) {
-				tr.On("FindByWalletNumberWithQuery", 1, &entity.Pagination{Limit: 1}).
+				tr.On("FindByWalletNumberWithQuery", 1, filter, &entity.Pagination{Limit: 1}).
 					Return([]*entity.Transaction{}, 1, nil)
-				tr.On("CountTransactionByWalletNumber", 1, "").
+				tr.On("CountTransactionByWalletNumber", 1, filter).
 					Return(10)
 			},
 			walletNumber: 1,
@@ -524,6 +577,7 @@ func Test_transactionService_FindByWalletNumber(t *testing.T) {
 
 			got, got1, err := s.FindByWalletNumber(
 				tt.walletNumber,
+				filter,
 				tt.pagination,
 			)
 
@@ -539,3 +593,540 @@ func Test_transactionService_FindByWalletNumber(t *testing.T) {
 		})
 	}
 }
+
+func Test_transactionService_UnitOfWorkError(t *testing.T) {
+	mockCommitError := fmt.Errorf("commit error")
+	mockTransfer := &entity.Transaction{
+		Amount: 1000,
+		Type:   entity.Transfer,
+		From:   100001,
+		To:     100002,
+	}
+	mockWallet := &entity.Wallet{Balance: mockTransfer.Amount}
+
+	tr := mocks.NewITransactionRepository(t)
+	wr := mocks.NewIWalletRepository(t)
+	lr := mocks.NewILedgerRepository(t)
+	uow := mocks.NewIUnitOfWork(t)
+	uow.On("WithinTransaction", mock.Anything).
+		Return(func(fn func(*repository.Repositories) error) error {
+			err := fn(&repository.Repositories{
+				Transactions: tr,
+				Wallets:      wr,
+				Ledger:       lr,
+				Limits:       MockPermissiveLimits(t),
+				FeeRules:     MockNoFees(t),
+			})
+			if err != nil {
+				return err
+			}
+
+			return mockCommitError
+		})
+
+	wr.On("FindByNumber", mock.Anything).Return(mockWallet, 1, nil)
+	wr.On("DecrementBalanceByValue", mockTransfer.From, mockTransfer.Amount).
+		Return(mockWallet, 1, nil)
+	wr.On("IncrementBalanceByValue", mock.Anything, mock.Anything).
+		Return(mockWallet, 1, nil)
+	tr.On("CreateTransaction", mock.Anything).Return(mockTransfer, 1, nil)
+	lr.On("CreateJournalEntry", mock.Anything).
+		Return(&entity.JournalEntry{}, 1, nil)
+
+	s := &transactionService{
+		transactionRepository: tr,
+		walletRepository:      wr,
+		unitOfWork:            uow,
+	}
+
+	got, err := s.CreateTransaction(mockTransfer)
+	assert.EqualError(t, err, mockCommitError.Error())
+	assert.Nil(t, got)
+}
+
+func Test_transactionService_CreateRefund(t *testing.T) {
+	referenceID := 1
+	mockOriginal := &entity.Transaction{
+		Base:           entity.Base{ID: referenceID},
+		Amount:         1000,
+		Type:           entity.Transfer,
+		From:           100001,
+		To:             100002,
+		RefundedAmount: 400,
+	}
+	newRefund := func(amount int) *entity.Transaction {
+		return &entity.Transaction{
+			Amount:      amount,
+			Type:        entity.Refund,
+			From:        100002,
+			ReferenceID: &referenceID,
+		}
+	}
+	mockWallet := &entity.Wallet{Number: 100002, Balance: 1000}
+
+	tests := []struct {
+		name string
+		mock func(
+			tr *mocks.ITransactionRepository,
+			wr *mocks.IWalletRepository,
+			lr *mocks.ILedgerRepository,
+		)
+		refund      *entity.Transaction
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Transaction not found",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByID", referenceID).Return(nil, 0, nil)
+			},
+			refund:      newRefund(100),
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "transaction"},
+		},
+		{
+			name: "Error | Transaction was not received by the wallet",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByID", referenceID).Return(mockOriginal, 1, nil)
+			},
+			refund: &entity.Transaction{
+				Amount:      100,
+				From:        100001,
+				ReferenceID: &referenceID,
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "transaction"},
+		},
+		{
+			name: "Error | Refund cannot be refunded",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByID", referenceID).Return(&entity.Transaction{
+					Base: entity.Base{ID: referenceID},
+					Type: entity.Refund,
+					To:   100002,
+				}, 1, nil)
+			},
+			refund:      newRefund(100),
+			wantErr:     true,
+			expectedErr: &custom_error.TransactionNotRefundable{},
+		},
+		{
+			name: "Error | Refund exceeds what is left of the transfer",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByID", referenceID).Return(mockOriginal, 1, nil)
+				tr.On("AddRefundedAmount", referenceID, 700).Return(nil, 0, nil)
+			},
+			refund:      newRefund(700),
+			wantErr:     true,
+			expectedErr: &custom_error.RefundExceedsAmount{Refundable: 600},
+		},
+		{
+			name: "Error | Recipient wallet's balance is insufficient",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByID", referenceID).Return(mockOriginal, 1, nil)
+				tr.On("AddRefundedAmount", referenceID, 600).
+					Return(&entity.Transaction{}, 1, nil)
+				wr.On("FindByNumber", 100002).
+					Return(&entity.Wallet{Number: 100002, Balance: 500}, 1, nil)
+				wr.On("FindByNumber", 100001).
+					Return(&entity.Wallet{Number: 100001}, 1, nil)
+			},
+			refund:      newRefund(600),
+			wantErr:     true,
+			expectedErr: &custom_error.InsufficientBalance{},
+		},
+		{
+			name: "Success",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+			) {
+				tr.On("FindByID", referenceID).Return(mockOriginal, 1, nil)
+				tr.On("AddRefundedAmount", referenceID, 600).
+					Return(&entity.Transaction{}, 1, nil)
+				wr.On("FindByNumber", mock.Anything).Return(mockWallet, 1, nil)
+				wr.On("DecrementBalanceByValue", 100002, 600).Return(mockWallet, 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 600).Return(mockWallet, 1, nil)
+				tr.On("CreateTransaction", mock.MatchedBy(
+					func(refund *entity.Transaction) bool {
+						return refund.Type == entity.Refund &&
+							refund.From == 100002 &&
+							refund.To == 100001 &&
+							*refund.ReferenceID == referenceID
+					},
+				)).Return(newRefund(600), 1, nil)
+				lr.On("CreateJournalEntry", mock.Anything).
+					Return(&entity.JournalEntry{}, 1, nil)
+			},
+			refund:      newRefund(600),
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := mocks.NewITransactionRepository(t)
+			wr := mocks.NewIWalletRepository(t)
+			lr := mocks.NewILedgerRepository(t)
+			s := NewTransactionService(
+				tr,
+				wr,
+				mocks.NewIUserRepository(t),
+				MockUnitOfWork(t, tr, wr, lr),
+				mocks.NewIRateProvider(t),
+			)
+
+			tt.mock(tr, wr, lr)
+
+			got, err := s.CreateRefund(tt.refund)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.Refund, got.Type)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_createTransfer_conversion(t *testing.T) {
+	newTransfer := func(amount int) *entity.Transaction {
+		return &entity.Transaction{
+			Amount: amount,
+			Type:   entity.Transfer,
+			From:   100001,
+			To:     100002,
+		}
+	}
+	mockFromWallet := &entity.Wallet{Number: 100001, Balance: 100000, Currency: entity.IDR}
+	mockToWallet := &entity.Wallet{Number: 100002, Currency: entity.USD}
+	mockRate := "0.0000645161"
+
+	tests := []struct {
+		name string
+		mock func(
+			tr *mocks.ITransactionRepository,
+			wr *mocks.IWalletRepository,
+			lr *mocks.ILedgerRepository,
+			rp *mocks.IRateProvider,
+		)
+		transfer          *entity.Transaction
+		wantErr           bool
+		expectedErr       error
+		wantConverted     int
+		wantExchangeRate  string
+		wantConvertedInto entity.Currency
+	}{
+		{
+			name: "Error | No exchange rate between the currencies",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+				rp *mocks.IRateProvider,
+			) {
+				rp.On("Rate", entity.IDR, entity.USD).
+					Return("", &custom_error.ExchangeRateUnavailable{From: "IDR", To: "USD"})
+			},
+			transfer:    newTransfer(100000),
+			wantErr:     true,
+			expectedErr: &custom_error.ExchangeRateUnavailable{From: "IDR", To: "USD"},
+		},
+		{
+			name: "Error | Amount worth nothing in the receiver's currency",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+				rp *mocks.IRateProvider,
+			) {
+				rp.On("Rate", entity.IDR, entity.USD).Return("0.000001", nil)
+			},
+			transfer:    newTransfer(1000),
+			wantErr:     true,
+			expectedErr: &custom_error.ConvertedAmountTooSmall{},
+		},
+		{
+			name: "Success | Receiver gets the converted amount",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+				rp *mocks.IRateProvider,
+			) {
+				rp.On("Rate", entity.IDR, entity.USD).Return(mockRate, nil)
+				wr.On("DecrementBalanceByValue", 100001, 100000).
+					Return(&entity.Wallet{Number: 100001}, 1, nil)
+				wr.On("IncrementBalanceByValue", 100002, 645).
+					Return(&entity.Wallet{Number: 100002, Balance: 645}, 1, nil)
+				tr.On("CreateTransaction", mock.Anything).
+					Return(func(transfer *entity.Transaction) *entity.Transaction {
+						return transfer
+					}, 1, nil)
+				lr.On("CreateJournalEntry", mock.Anything).
+					Return(&entity.JournalEntry{}, 1, nil)
+			},
+			transfer:          newTransfer(100000),
+			wantConverted:     645,
+			wantExchangeRate:  mockRate,
+			wantConvertedInto: entity.USD,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := mocks.NewITransactionRepository(t)
+			wr := mocks.NewIWalletRepository(t)
+			lr := mocks.NewILedgerRepository(t)
+			rp := mocks.NewIRateProvider(t)
+			wr.On("FindByNumber", 100001).Return(mockFromWallet, 1, nil)
+			wr.On("FindByNumber", 100002).Return(mockToWallet, 1, nil)
+
+			tt.mock(tr, wr, lr, rp)
+
+			got, err := createTransfer(&repository.Repositories{
+				Transactions: tr,
+				Wallets:      wr,
+				Ledger:       lr,
+				Limits:       MockPermissiveLimits(t),
+				FeeRules:     MockNoFees(t),
+			}, rp, tt.transfer)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.IDR, got.Currency)
+				assert.Equal(t, tt.wantConverted, *got.ConvertedAmount)
+				assert.Equal(t, tt.wantConvertedInto, got.ConvertedCurrency)
+				assert.Equal(t, tt.wantExchangeRate, got.ExchangeRate)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_transactionService_FindByWalletNumberAfterCursor(t *testing.T) {
+	filter := &entity.TransactionFilter{}
+	datetime := time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC)
+	newTransactions := func(count int) []*entity.Transaction {
+		transactions := []*entity.Transaction{}
+		for id := count; id > 0; id-- {
+			transactions = append(transactions, &entity.Transaction{
+				Base:     entity.Base{ID: id},
+				Datetime: datetime,
+			})
+		}
+		return transactions
+	}
+
+	tests := []struct {
+		name           string
+		mock           func(tr *mocks.ITransactionRepository)
+		wantRows       int
+		wantNextCursor string
+		wantErr        bool
+		expectedErr    error
+	}{
+		{
+			name: "Error | No Data Found",
+			mock: func(tr *mocks.ITransactionRepository) {
+				tr.On("FindByWalletNumberAfterCursor", 1, filter, mock.Anything).
+					Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "transaction"},
+		},
+		{
+			name: "Error | Other error from repository",
+			mock: func(tr *mocks.ITransactionRepository) {
+				tr.On("FindByWalletNumberAfterCursor", 1, filter, mock.Anything).
+					Return(nil, 1, fmt.Errorf("error"))
+			},
+			wantErr:     true,
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name: "Success | Last page",
+			mock: func(tr *mocks.ITransactionRepository) {
+				tr.On("FindByWalletNumberAfterCursor", 1, filter, mock.Anything).
+					Return(newTransactions(2), 2, nil)
+			},
+			wantRows:       2,
+			wantNextCursor: "",
+			wantErr:        false,
+			expectedErr:    nil,
+		},
+		{
+			name: "Success | Another page follows",
+			mock: func(tr *mocks.ITransactionRepository) {
+				tr.On("FindByWalletNumberAfterCursor", 1, filter, mock.Anything).
+					Return(newTransactions(3), 3, nil)
+			},
+			wantRows: 2,
+			wantNextCursor: (&entity.TransactionCursor{
+				Datetime: datetime,
+				ID:       2,
+			}).Encode(),
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := mocks.NewITransactionRepository(t)
+			s := &transactionService{
+				transactionRepository: tr,
+			}
+
+			tt.mock(tr)
+
+			got, got1, err := s.FindByWalletNumberAfterCursor(
+				1,
+				filter,
+				&entity.CursorPagination{Limit: 2, Sort: "desc"},
+			)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Len(t, got, tt.wantRows)
+				assert.Equal(t, tt.wantNextCursor, got1.NextCursor)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_transactionService_FindDetailByID(t *testing.T) {
+	senderID, recipientID := 1, 2
+	newTransaction := func(transactionType entity.TransactionType) *entity.Transaction {
+		return &entity.Transaction{
+			Base:       entity.Base{ID: 7},
+			Type:       transactionType,
+			From:       100001,
+			FromWallet: entity.Wallet{Number: 100001, UserID: &senderID},
+			To:         100002,
+			ToWallet:   entity.Wallet{Number: 100002, UserID: &recipientID},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		userID      int
+		mock        func(*mocks.ITransactionRepository, *mocks.IUserRepository)
+		want        *entity.TransactionDetail
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:   "Error | No data found from repository",
+			userID: senderID,
+			mock: func(tr *mocks.ITransactionRepository, ur *mocks.IUserRepository) {
+				tr.On("FindByIDWithWallets", 7).Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "transaction"},
+		},
+		{
+			name:   "Error | Other error from repository",
+			userID: senderID,
+			mock: func(tr *mocks.ITransactionRepository, ur *mocks.IUserRepository) {
+				tr.On("FindByIDWithWallets", 7).Return(nil, 1, fmt.Errorf("error"))
+			},
+			wantErr:     true,
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name:   "Error | Neither wallet is the user's",
+			userID: 3,
+			mock: func(tr *mocks.ITransactionRepository, ur *mocks.IUserRepository) {
+				tr.On("FindByIDWithWallets", 7).Return(newTransaction(entity.Transfer), 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "transaction"},
+		},
+		{
+			name:   "Error | Other error from user repository",
+			userID: recipientID,
+			mock: func(tr *mocks.ITransactionRepository, ur *mocks.IUserRepository) {
+				tr.On("FindByIDWithWallets", 7).Return(newTransaction(entity.Transfer), 1, nil)
+				ur.On("FindByID", senderID).Return(nil, 1, fmt.Errorf("error"))
+			},
+			wantErr:     true,
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name:   "Success | Transfer with the names of both users",
+			userID: recipientID,
+			mock: func(tr *mocks.ITransactionRepository, ur *mocks.IUserRepository) {
+				tr.On("FindByIDWithWallets", 7).Return(newTransaction(entity.Transfer), 1, nil)
+				ur.On("FindByID", senderID).Return(&entity.User{Name: "Sender"}, 1, nil)
+				ur.On("FindByID", recipientID).Return(nil, 0, nil)
+			},
+			want: &entity.TransactionDetail{
+				Transaction: newTransaction(entity.Transfer),
+				SenderName:  "Sender",
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+		{
+			name:   "Success | Top up without names",
+			userID: senderID,
+			mock: func(tr *mocks.ITransactionRepository, ur *mocks.IUserRepository) {
+				tr.On("FindByIDWithWallets", 7).Return(newTransaction(entity.TopUp), 1, nil)
+			},
+			want: &entity.TransactionDetail{
+				Transaction: newTransaction(entity.TopUp),
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := mocks.NewITransactionRepository(t)
+			ur := mocks.NewIUserRepository(t)
+			s := &transactionService{
+				transactionRepository: tr,
+				userRepository:        ur,
+			}
+
+			tt.mock(tr, ur)
+
+			got, err := s.FindDetailByID(7, tt.userID)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
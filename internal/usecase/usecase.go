@@ -1,17 +1,54 @@
 package usecase
 
-import "assignment-golang-backend/internal/repository"
+import (
+	"assignment-golang-backend/internal/config"
+	"assignment-golang-backend/internal/fx"
+	"assignment-golang-backend/internal/payout"
+	"assignment-golang-backend/internal/repository"
+	"assignment-golang-backend/internal/storage"
+	"assignment-golang-backend/internal/topup"
+)
 
 type Services struct {
-	Auth        IAuthService
-	User        IUserService
-	Transaction ITransactionService
+	Auth           IAuthService
+	User           IUserService
+	Transaction    ITransactionService
+	Idempotency    IIdempotencyService
+	Reconciliation IReconciliationService
+	Pin            IPinService
+	PaymentRequest IPaymentRequestService
+	Schedule       IScheduledTransferService
+	BankAccount    IBankAccountService
+	Withdrawal     IWithdrawalService
+	Topup          ITopupService
+	SourceOfFunds  ISourceOfFundsService
+	Kyc            IKycService
+	Fee            IFeeService
+	Wallet         IWalletService
+	Statement      IStatementService
 }
 
 func New(r *repository.Repositories) *Services {
+	rates := fx.NewStaticFileProvider(fx.RatesFileFromEnv())
+	transaction := NewTransactionService(r.Transactions, r.Wallets, r.Users, r.UnitOfWork, rates)
+	withdrawal := NewWithdrawalService(r.Transactions, r.BankAccounts, r.UnitOfWork, payout.NewStubProvider())
+
 	return &Services{
-		Auth:        NewAuthService(r.Users, r.Wallets),
-		User:        NewUserService(r.Users),
-		Transaction: NewTransactionService(r.Transactions, r.Wallets),
+		Auth:           NewAuthService(r.Users, r.Wallets, r.RefreshTokens, r.RevokedTokens),
+		User:           NewUserService(r.Users),
+		Transaction:    transaction,
+		Idempotency:    NewIdempotencyService(r.IdempotencyKeys),
+		Reconciliation: NewReconciliationService(r.Wallets, r.UnitOfWork),
+		Pin:            NewPinService(r.Users, r.UnitOfWork),
+		PaymentRequest: NewPaymentRequestService(r.PaymentRequests, r.Wallets, r.Limits, r.UnitOfWork, rates),
+		Schedule:       NewScheduledTransferService(r.Schedules, r.Wallets, r.Limits, transaction),
+		BankAccount:    NewBankAccountService(r.BankAccounts),
+		Withdrawal:     withdrawal,
+		Topup:          NewTopupService(r.Transactions, r.SourcesOfFunds, r.Wallets, r.Limits, r.FeeRules, r.UnitOfWork, topup.NewRegistry(topup.NewFakeProvider(config.GetEnv("TOPUP_WEBHOOK_SECRET")))),
+		SourceOfFunds:  NewSourceOfFundsService(r.SourcesOfFunds),
+		Kyc:            NewKycService(r.Users, r.KycSubmissions, r.UnitOfWork, storage.NewLocalStorage(storage.DirFromEnv())),
+		Fee:            NewFeeService(r.FeeRules),
+		Wallet:         NewWalletService(r.Wallets, withdrawal),
+		Statement:      NewStatementService(r.Wallets, r.Ledger),
 	}
 }
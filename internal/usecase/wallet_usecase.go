@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/repository"
+)
+
+const (
+	WALLET_CLOSED_BY_USER_REASON = "Closed by its user"
+)
+
+type IWalletService interface {
+	FindByUserID(int) ([]*entity.Wallet, error)
+	CreateWallet(*entity.Wallet) (*entity.Wallet, error)
+	FindOwned(int, int) (*entity.Wallet, error)
+	CloseWallet(int, int) error
+	UpdateStatus(*entity.Wallet, *int) (*entity.Wallet, error)
+}
+
+type walletService struct {
+	walletRepository  repository.IWalletRepository
+	withdrawalService IWithdrawalService
+}
+
+func NewWalletService(
+	wr repository.IWalletRepository,
+	ws IWithdrawalService,
+) IWalletService {
+	return &walletService{
+		walletRepository:  wr,
+		withdrawalService: ws,
+	}
+}
+
+func (s *walletService) FindByUserID(userID int) ([]*entity.Wallet, error) {
+	wallets, _, err := s.walletRepository.FindByUserID(userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return wallets, nil
+}
+
+// CreateWallet opens a pocket for wallet.UserID, in the base currency
+// unless wallet.Currency is set.
+func (s *walletService) CreateWallet(
+	wallet *entity.Wallet,
+) (*entity.Wallet, error) {
+	if wallet.Currency == "" {
+		wallet.Currency = entity.BaseCurrency
+	}
+
+	if !wallet.Currency.IsSupported() {
+		return nil, &custom_error.CurrencyNotSupported{
+			Currency: string(wallet.Currency),
+		}
+	}
+
+	if wallet.GoalDate != nil && !wallet.GoalDate.After(time.Now()) {
+		return nil, &custom_error.GoalDateInPast{}
+	}
+
+	wallet, rowsAffected, err := s.walletRepository.CreateWallet(wallet)
+
+	if rowsAffected == 0 || err != nil {
+		return nil, &custom_error.FailedToCreateData{DataType: "Wallet"}
+	}
+
+	return wallet, nil
+}
+
+// FindOwned returns the wallet with the number only when the user owns it.
+func (s *walletService) FindOwned(number, userID int) (*entity.Wallet, error) {
+	wallet, rowsAffected, err := s.walletRepository.FindByNumberAndUserID(
+		number,
+		userID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "wallet"}
+	}
+
+	return wallet, nil
+}
+
+// CloseWallet closes an empty pocket of the user the same way an admin
+// closes a wallet, by setting its status to CLOSED. Callers keep the
+// user's primary wallet from being closed.
+func (s *walletService) CloseWallet(number, userID int) error {
+	wallet, err := s.FindOwned(number, userID)
+	if err != nil {
+		return err
+	}
+
+	if wallet.Status == entity.WalletClosed {
+		return &custom_error.WalletClosed{}
+	}
+
+	if wallet.Balance != 0 {
+		return &custom_error.WalletNotEmpty{}
+	}
+
+	now := time.Now()
+	_, rowsAffected, err := s.walletRepository.UpdateStatus(
+		&entity.Wallet{
+			Number:          number,
+			Status:          entity.WalletClosed,
+			StatusReason:    WALLET_CLOSED_BY_USER_REASON,
+			StatusChangedBy: &userID,
+			StatusChangedAt: &now,
+		},
+		wallet.Status,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	// Money arrived, or a withdrawal is still being paid out, since the
+	// wallet was read.
+	if rowsAffected == 0 {
+		return &custom_error.WalletNotEmpty{}
+	}
+
+	return nil
+}
+
+// UpdateStatus sets the status of the user's wallet change.Number to
+// change.Status, for the reason and by the admin in change. A closed wallet
+// stays closed. A wallet is only closed once it is empty; when it is not,
+// its balance is first paid out to the bank account with the ID
+// bankAccountID of its user, if given. The payout cannot be undone, so when
+// the wallet still cannot be closed after it, because the payout is still
+// in flight or money arrived meanwhile, the wallet is left open and the
+// close is to be tried again.
+func (s *walletService) UpdateStatus(
+	change *entity.Wallet,
+	bankAccountID *int,
+) (*entity.Wallet, error) {
+	wallet, rowsAffected, err := s.walletRepository.FindByNumber(change.Number)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "wallet"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// House wallets belong to no user and are not managed here.
+	if wallet.UserID == nil {
+		return nil, &custom_error.NoDataFound{DataType: "wallet"}
+	}
+
+	if wallet.Status == entity.WalletClosed {
+		return nil, &custom_error.WalletClosed{}
+	}
+
+	isPaidOut := false
+	if change.Status == entity.WalletClosed && wallet.Balance != 0 {
+		err = s.payOutBalance(wallet, bankAccountID)
+		if err != nil {
+			return nil, err
+		}
+
+		isPaidOut = true
+	}
+
+	now := time.Now()
+	change.StatusChangedAt = &now
+	updated, rowsAffected, err := s.walletRepository.UpdateStatus(
+		change,
+		wallet.Status,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 && isPaidOut {
+		return nil, &custom_error.PayoutPending{}
+	}
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.FailedToUpdateData{DataType: "wallet status"}
+	}
+
+	return updated, nil
+}
+
+// payOutBalance empties a wallet that is being closed into a bank account
+// of its user.
+func (s *walletService) payOutBalance(
+	wallet *entity.Wallet,
+	bankAccountID *int,
+) error {
+	if bankAccountID == nil {
+		return &custom_error.WalletNotEmpty{}
+	}
+
+	if wallet.Currency != entity.BaseCurrency {
+		return &custom_error.CurrencyNotSupported{
+			Currency: string(wallet.Currency),
+		}
+	}
+
+	withdrawal, err := s.withdrawalService.PayOutBalance(
+		*wallet.UserID,
+		&entity.Transaction{
+			From:          wallet.Number,
+			Amount:        wallet.Balance,
+			BankAccountID: bankAccountID,
+			Description:   "Payout of a closed wallet",
+			Datetime:      time.Now(),
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if withdrawal.Status == entity.TransactionFailed {
+		return &custom_error.PayoutFailed{Reason: withdrawal.FailureReason}
+	}
+
+	return nil
+}
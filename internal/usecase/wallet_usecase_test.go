@@ -0,0 +1,466 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewWalletService(t *testing.T) {
+	NewWalletService(
+		mocks.NewIWalletRepository(t),
+		mocks.NewIWithdrawalService(t),
+	)
+}
+
+func Test_walletService_CreateWallet(t *testing.T) {
+	userID := 1
+	goalAmount := 5000000
+	pastDate := time.Now().Add(-24 * time.Hour)
+	futureDate := time.Now().Add(30 * 24 * time.Hour)
+	mockWallet := &entity.Wallet{
+		Number:     100002,
+		Currency:   entity.IDR,
+		UserID:     &userID,
+		Name:       "Holiday",
+		GoalAmount: &goalAmount,
+		GoalDate:   &futureDate,
+	}
+
+	tests := []struct {
+		name        string
+		wallet      *entity.Wallet
+		mock        func(*mocks.IWalletRepository)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Currency not supported",
+			wallet: &entity.Wallet{
+				UserID:   &userID,
+				Name:     "Holiday",
+				Currency: "JPY",
+			},
+			mock:        func(wr *mocks.IWalletRepository) {},
+			wantErr:     true,
+			expectedErr: &custom_error.CurrencyNotSupported{Currency: "JPY"},
+		},
+		{
+			name: "Error | Goal date in the past",
+			wallet: &entity.Wallet{
+				UserID:   &userID,
+				Name:     "Holiday",
+				GoalDate: &pastDate,
+			},
+			mock:        func(wr *mocks.IWalletRepository) {},
+			wantErr:     true,
+			expectedErr: &custom_error.GoalDateInPast{},
+		},
+		{
+			name: "Error | Failed to create the wallet",
+			wallet: &entity.Wallet{
+				UserID: &userID,
+				Name:   "Holiday",
+			},
+			mock: func(wr *mocks.IWalletRepository) {
+				wr.On("CreateWallet", mock.Anything).
+					Return(nil, 0, fmt.Errorf("error"))
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToCreateData{DataType: "Wallet"},
+		},
+		{
+			name: "Success | Pocket in the base currency by default",
+			wallet: &entity.Wallet{
+				UserID:     &userID,
+				Name:       "Holiday",
+				GoalAmount: &goalAmount,
+				GoalDate:   &futureDate,
+			},
+			mock: func(wr *mocks.IWalletRepository) {
+				wr.On("CreateWallet", &entity.Wallet{
+					UserID:     &userID,
+					Currency:   entity.IDR,
+					Name:       "Holiday",
+					GoalAmount: &goalAmount,
+					GoalDate:   &futureDate,
+				}).Return(mockWallet, 1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wr := mocks.NewIWalletRepository(t)
+			s := NewWalletService(wr, mocks.NewIWithdrawalService(t))
+
+			tt.mock(wr)
+
+			got, err := s.CreateWallet(tt.wallet)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, mockWallet, got)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func Test_walletService_CloseWallet(t *testing.T) {
+	isClosing := mock.MatchedBy(func(wallet *entity.Wallet) bool {
+		return wallet.Number == 100002 &&
+			wallet.Status == entity.WalletClosed &&
+			wallet.StatusReason == WALLET_CLOSED_BY_USER_REASON &&
+			*wallet.StatusChangedBy == 1 &&
+			wallet.StatusChangedAt != nil
+	})
+
+	tests := []struct {
+		name        string
+		mock        func(*mocks.IWalletRepository)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Wallet of another user",
+			mock: func(wr *mocks.IWalletRepository) {
+				wr.On("FindByNumberAndUserID", 100002, 1).
+					Return(&entity.Wallet{}, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "wallet"},
+		},
+		{
+			name: "Error | Wallet still holds money",
+			mock: func(wr *mocks.IWalletRepository) {
+				wr.On("FindByNumberAndUserID", 100002, 1).
+					Return(&entity.Wallet{Number: 100002, Balance: 1000}, 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.WalletNotEmpty{},
+		},
+		{
+			name: "Error | Wallet already closed",
+			mock: func(wr *mocks.IWalletRepository) {
+				wr.On("FindByNumberAndUserID", 100002, 1).
+					Return(&entity.Wallet{Number: 100002, Status: entity.WalletClosed}, 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.WalletClosed{},
+		},
+		{
+			name: "Error | Money arrived while closing",
+			mock: func(wr *mocks.IWalletRepository) {
+				wr.On("FindByNumberAndUserID", 100002, 1).
+					Return(&entity.Wallet{Number: 100002, Status: entity.WalletActive}, 1, nil)
+				wr.On("UpdateStatus", isClosing, entity.WalletActive).
+					Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.WalletNotEmpty{},
+		},
+		{
+			name: "Error | Error from repository",
+			mock: func(wr *mocks.IWalletRepository) {
+				wr.On("FindByNumberAndUserID", 100002, 1).
+					Return(&entity.Wallet{Number: 100002, Status: entity.WalletActive}, 1, nil)
+				wr.On("UpdateStatus", isClosing, entity.WalletActive).
+					Return(nil, 0, fmt.Errorf("error"))
+			},
+			wantErr:     true,
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name: "Success",
+			mock: func(wr *mocks.IWalletRepository) {
+				wr.On("FindByNumberAndUserID", 100002, 1).
+					Return(&entity.Wallet{Number: 100002, Status: entity.WalletActive}, 1, nil)
+				wr.On("UpdateStatus", isClosing, entity.WalletActive).
+					Return(&entity.Wallet{Number: 100002, Status: entity.WalletClosed}, 1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wr := mocks.NewIWalletRepository(t)
+			s := NewWalletService(wr, mocks.NewIWithdrawalService(t))
+
+			tt.mock(wr)
+
+			err := s.CloseWallet(100002, 1)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func Test_walletService_FindOwned(t *testing.T) {
+	mockWallet := &entity.Wallet{Number: 100002, Currency: entity.USD}
+
+	tests := []struct {
+		name        string
+		mock        func(*mocks.IWalletRepository)
+		want        *entity.Wallet
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "Error | Wallet of another user",
+			mock: func(wr *mocks.IWalletRepository) {
+				wr.On("FindByNumberAndUserID", 100002, 1).
+					Return(&entity.Wallet{}, 0, nil)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "wallet"},
+		},
+		{
+			name: "Error | Error from repository",
+			mock: func(wr *mocks.IWalletRepository) {
+				wr.On("FindByNumberAndUserID", 100002, 1).
+					Return(nil, 0, fmt.Errorf("error"))
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: fmt.Errorf("error"),
+		},
+		{
+			name: "Success",
+			mock: func(wr *mocks.IWalletRepository) {
+				wr.On("FindByNumberAndUserID", 100002, 1).
+					Return(mockWallet, 1, nil)
+			},
+			want:        mockWallet,
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wr := mocks.NewIWalletRepository(t)
+			s := NewWalletService(wr, mocks.NewIWithdrawalService(t))
+
+			tt.mock(wr)
+
+			got, err := s.FindOwned(100002, 1)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_walletService_UpdateStatus(t *testing.T) {
+	userID := 1
+	adminID := 2
+	bankAccountID := 1
+	newWallet := func(
+		status entity.WalletStatus,
+		balance int,
+		currency entity.Currency,
+	) *entity.Wallet {
+		return &entity.Wallet{
+			Number:   100002,
+			Balance:  balance,
+			Currency: currency,
+			UserID:   &userID,
+			Status:   status,
+		}
+	}
+	isStatusChange := func(status entity.WalletStatus) interface{} {
+		return mock.MatchedBy(func(wallet *entity.Wallet) bool {
+			return wallet.Number == 100002 &&
+				wallet.Status == status &&
+				wallet.StatusReason == "reason" &&
+				*wallet.StatusChangedBy == adminID &&
+				wallet.StatusChangedAt != nil
+		})
+	}
+
+	tests := []struct {
+		name          string
+		status        entity.WalletStatus
+		bankAccountID *int
+		mock          func(*mocks.IWalletRepository, *mocks.IWithdrawalService)
+		wantErr       bool
+		expectedErr   error
+	}{
+		{
+			name:   "Error | Wallet not found",
+			status: entity.WalletFrozen,
+			mock: func(wr *mocks.IWalletRepository, ws *mocks.IWithdrawalService) {
+				wr.On("FindByNumber", 100002).Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "wallet"},
+		},
+		{
+			name:   "Error | House wallet",
+			status: entity.WalletFrozen,
+			mock: func(wr *mocks.IWalletRepository, ws *mocks.IWithdrawalService) {
+				wr.On("FindByNumber", 100002).
+					Return(&entity.Wallet{Number: 100002}, 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.NoDataFound{DataType: "wallet"},
+		},
+		{
+			name:   "Error | Wallet already closed",
+			status: entity.WalletActive,
+			mock: func(wr *mocks.IWalletRepository, ws *mocks.IWithdrawalService) {
+				wr.On("FindByNumber", 100002).
+					Return(newWallet(entity.WalletClosed, 0, entity.IDR), 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.WalletClosed{},
+		},
+		{
+			name:   "Error | Closing a wallet with a balance and no bank account",
+			status: entity.WalletClosed,
+			mock: func(wr *mocks.IWalletRepository, ws *mocks.IWithdrawalService) {
+				wr.On("FindByNumber", 100002).
+					Return(newWallet(entity.WalletSuspended, 5000, entity.IDR), 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.WalletNotEmpty{},
+		},
+		{
+			name:          "Error | Balance in a currency that cannot be paid out",
+			status:        entity.WalletClosed,
+			bankAccountID: &bankAccountID,
+			mock: func(wr *mocks.IWalletRepository, ws *mocks.IWithdrawalService) {
+				wr.On("FindByNumber", 100002).
+					Return(newWallet(entity.WalletActive, 5000, entity.USD), 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.CurrencyNotSupported{Currency: "USD"},
+		},
+		{
+			name:          "Error | Payout rejected",
+			status:        entity.WalletClosed,
+			bankAccountID: &bankAccountID,
+			mock: func(wr *mocks.IWalletRepository, ws *mocks.IWithdrawalService) {
+				wr.On("FindByNumber", 100002).
+					Return(newWallet(entity.WalletActive, 5000, entity.IDR), 1, nil)
+				ws.On("PayOutBalance", userID, mock.Anything).
+					Return(&entity.Transaction{
+						Status:        entity.TransactionFailed,
+						FailureReason: "rejected",
+					}, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.PayoutFailed{Reason: "rejected"},
+		},
+		{
+			name:   "Error | Status changed meanwhile",
+			status: entity.WalletFrozen,
+			mock: func(wr *mocks.IWalletRepository, ws *mocks.IWithdrawalService) {
+				wr.On("FindByNumber", 100002).
+					Return(newWallet(entity.WalletActive, 5000, entity.IDR), 1, nil)
+				wr.On("UpdateStatus", isStatusChange(entity.WalletFrozen), entity.WalletActive).
+					Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToUpdateData{DataType: "wallet status"},
+		},
+		{
+			name:   "Success | Freeze",
+			status: entity.WalletFrozen,
+			mock: func(wr *mocks.IWalletRepository, ws *mocks.IWithdrawalService) {
+				wr.On("FindByNumber", 100002).
+					Return(newWallet(entity.WalletActive, 5000, entity.IDR), 1, nil)
+				wr.On("UpdateStatus", isStatusChange(entity.WalletFrozen), entity.WalletActive).
+					Return(newWallet(entity.WalletFrozen, 5000, entity.IDR), 1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+		{
+			name:          "Error | Wallet not closed as its payout is still in flight",
+			status:        entity.WalletClosed,
+			bankAccountID: &bankAccountID,
+			mock: func(wr *mocks.IWalletRepository, ws *mocks.IWithdrawalService) {
+				wr.On("FindByNumber", 100002).
+					Return(newWallet(entity.WalletSuspended, 5000, entity.IDR), 1, nil)
+				ws.On("PayOutBalance", userID, mock.Anything).
+					Return(&entity.Transaction{
+						Status: entity.TransactionProcessing,
+					}, nil)
+				wr.On("UpdateStatus", isStatusChange(entity.WalletClosed), entity.WalletSuspended).
+					Return(&entity.Wallet{}, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.PayoutPending{},
+		},
+		{
+			name:          "Success | Close after paying the balance out",
+			status:        entity.WalletClosed,
+			bankAccountID: &bankAccountID,
+			mock: func(wr *mocks.IWalletRepository, ws *mocks.IWithdrawalService) {
+				wr.On("FindByNumber", 100002).
+					Return(newWallet(entity.WalletSuspended, 5000, entity.IDR), 1, nil)
+				ws.On("PayOutBalance", userID, mock.MatchedBy(
+					func(withdrawal *entity.Transaction) bool {
+						return withdrawal.From == 100002 &&
+							withdrawal.Amount == 5000 &&
+							*withdrawal.BankAccountID == bankAccountID
+					},
+				)).Return(&entity.Transaction{
+					Status: entity.TransactionCompleted,
+				}, nil)
+				wr.On("UpdateStatus", isStatusChange(entity.WalletClosed), entity.WalletSuspended).
+					Return(newWallet(entity.WalletClosed, 0, entity.IDR), 1, nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wr := mocks.NewIWalletRepository(t)
+			ws := mocks.NewIWithdrawalService(t)
+			s := NewWalletService(wr, ws)
+
+			tt.mock(wr, ws)
+
+			got, err := s.UpdateStatus(
+				&entity.Wallet{
+					Number:          100002,
+					Status:          tt.status,
+					StatusReason:    "reason",
+					StatusChangedBy: &adminID,
+				},
+				tt.bankAccountID,
+			)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.status, got.Status)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"time"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/internal/ledger"
+	"assignment-golang-backend/internal/payout"
+	"assignment-golang-backend/internal/repository"
+)
+
+type IWithdrawalService interface {
+	CreateWithdrawal(int, *entity.Transaction) (*entity.Transaction, error)
+	PayOutBalance(int, *entity.Transaction) (*entity.Transaction, error)
+}
+
+type withdrawalService struct {
+	transactionRepository repository.ITransactionRepository
+	bankAccountRepository repository.IBankAccountRepository
+	unitOfWork            repository.IUnitOfWork
+	payoutProvider        payout.IPayoutProvider
+}
+
+func NewWithdrawalService(
+	tr repository.ITransactionRepository,
+	br repository.IBankAccountRepository,
+	uow repository.IUnitOfWork,
+	pp payout.IPayoutProvider,
+) IWithdrawalService {
+	return &withdrawalService{
+		transactionRepository: tr,
+		bankAccountRepository: br,
+		unitOfWork:            uow,
+		payoutProvider:        pp,
+	}
+}
+
+// CreateWithdrawal takes the amount out of the wallet as a PENDING
+// withdrawal and then pays it out to a bank account of the user. The
+// returned withdrawal is COMPLETED, or FAILED with the money back in the
+// wallet when the payout provider rejected it. Withdrawals count towards
+// the daily and monthly outgoing limits like transfers do.
+func (s *withdrawalService) CreateWithdrawal(
+	userID int,
+	withdrawal *entity.Transaction,
+) (*entity.Transaction, error) {
+	return s.withdraw(userID, withdrawal, true)
+}
+
+// PayOutBalance withdraws the balance of a wallet that is being closed. It
+// is not held to the transaction limits or the status of the wallet, so
+// the balance of a frozen or suspended wallet can be paid out too.
+func (s *withdrawalService) PayOutBalance(
+	userID int,
+	withdrawal *entity.Transaction,
+) (*entity.Transaction, error) {
+	return s.withdraw(userID, withdrawal, false)
+}
+
+func (s *withdrawalService) withdraw(
+	userID int,
+	withdrawal *entity.Transaction,
+	isLimited bool,
+) (*entity.Transaction, error) {
+	bankAccount, rowsAffected, err := s.bankAccountRepository.FindByIDAndUserID(
+		*withdrawal.BankAccountID,
+		userID,
+	)
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.NoDataFound{DataType: "bank account"}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawal.Type = entity.Withdrawal
+	withdrawal.Currency = entity.BaseCurrency
+	withdrawal.Status = entity.TransactionPending
+	withdrawal.To = withdrawal.From
+
+	err = s.unitOfWork.WithinTransaction(func(r *repository.Repositories) error {
+		var limit *entity.TransactionLimit
+		var err error
+		if isLimited {
+			limit, err = findTransactionLimit(r.Limits, withdrawal.From)
+			if err != nil {
+				return err
+			}
+
+			err = checkWithdrawalAmount(limit, withdrawal.Amount)
+			if err != nil {
+				return err
+			}
+		}
+
+		wallet, rowsAffected, err := r.Wallets.DecrementBalanceByValue(
+			withdrawal.From,
+			withdrawal.Amount,
+		)
+
+		if rowsAffected == 0 {
+			return &custom_error.InsufficientBalance{}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if isLimited {
+			if !wallet.Status.CanSend() {
+				return &custom_error.WalletCannotSend{
+					Status: string(wallet.Status),
+				}
+			}
+
+			err = checkOutgoingLimits(
+				r.Limits,
+				limit,
+				withdrawal.From,
+				withdrawal.Amount,
+				withdrawal.Datetime,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		withdrawal.FromBalanceAfter = &wallet.Balance
+		withdrawal, rowsAffected, err = r.Transactions.CreateTransaction(
+			withdrawal,
+		)
+
+		if rowsAffected == 0 {
+			return &custom_error.FailedToCreateData{DataType: "transaction"}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = postJournalEntry(r, ledger.NewWithdrawalEntry(withdrawal))
+		if err != nil {
+			return err
+		}
+
+		withdrawal.FromWallet = *wallet
+		withdrawal.ToWallet = *wallet
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.processWithdrawal(withdrawal, bankAccount)
+}
+
+// processWithdrawal claims a pending withdrawal before calling the payout
+// provider, so it is never paid out twice. A withdrawal left PROCESSING
+// means the outcome of its payout is unknown and must be checked with the
+// provider.
+func (s *withdrawalService) processWithdrawal(
+	withdrawal *entity.Transaction,
+	bankAccount *entity.BankAccount,
+) (*entity.Transaction, error) {
+	withdrawal.Status = entity.TransactionProcessing
+	_, rowsAffected, err := s.transactionRepository.UpdateStatus(
+		withdrawal,
+		entity.TransactionPending,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.FailedToUpdateData{DataType: "withdrawal"}
+	}
+
+	reference, err := s.payoutProvider.Payout(withdrawal, bankAccount)
+	if err != nil {
+		return s.failWithdrawal(withdrawal, err.Error())
+	}
+
+	withdrawal.Status = entity.TransactionCompleted
+	withdrawal.ProviderReference = reference
+	_, rowsAffected, err = s.transactionRepository.UpdateStatus(
+		withdrawal,
+		entity.TransactionProcessing,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, &custom_error.FailedToUpdateData{DataType: "withdrawal"}
+	}
+
+	return withdrawal, nil
+}
+
+// failWithdrawal marks a withdrawal the provider rejected as FAILED and
+// returns its amount to the wallet, unless the wallet was closed.
+func (s *withdrawalService) failWithdrawal(
+	withdrawal *entity.Transaction,
+	reason string,
+) (*entity.Transaction, error) {
+	withdrawal.Status = entity.TransactionFailed
+	withdrawal.FailureReason = reason
+
+	err := s.unitOfWork.WithinTransaction(func(r *repository.Repositories) error {
+		_, rowsAffected, err := r.Transactions.UpdateStatus(
+			withdrawal,
+			entity.TransactionProcessing,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return &custom_error.FailedToUpdateData{DataType: "withdrawal"}
+		}
+
+		wallet, rowsAffected, err := r.Wallets.IncrementBalanceByValue(
+			withdrawal.From,
+			withdrawal.Amount,
+		)
+
+		if rowsAffected == 0 {
+			return &custom_error.FailedToUpdateData{
+				DataType: "wallet balance",
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// A closed wallet stays empty. It cannot be closed while one of its
+		// withdrawals is paid out, so this one is left PROCESSING to be
+		// looked into rather than credited.
+		if wallet.Status == entity.WalletClosed {
+			return &custom_error.WalletClosed{}
+		}
+
+		err = postJournalEntry(
+			r,
+			ledger.NewFailedWithdrawalEntry(withdrawal, time.Now()),
+		)
+		if err != nil {
+			return err
+		}
+
+		withdrawal.FromWallet = *wallet
+		withdrawal.ToWallet = *wallet
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return withdrawal, nil
+}
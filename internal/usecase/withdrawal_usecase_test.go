@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+
+	"assignment-golang-backend/internal/custom_error"
+	"assignment-golang-backend/internal/entity"
+	"assignment-golang-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_withdrawalService_CreateWithdrawal(t *testing.T) {
+	bankAccountID := 1
+	mockBankAccount := &entity.BankAccount{
+		Base:          entity.Base{ID: bankAccountID},
+		UserID:        1,
+		BankCode:      "BCA",
+		AccountNumber: "1234567890",
+	}
+	mockWallet := &entity.Wallet{Number: 100001, Balance: 40000}
+	newWithdrawal := func() *entity.Transaction {
+		return &entity.Transaction{
+			Amount:        10000,
+			From:          100001,
+			BankAccountID: &bankAccountID,
+		}
+	}
+	isStatus := func(status entity.TransactionStatus) interface{} {
+		return mock.MatchedBy(func(withdrawal *entity.Transaction) bool {
+			return withdrawal.Status == status
+		})
+	}
+
+	tests := []struct {
+		name string
+		mock func(
+			tr *mocks.ITransactionRepository,
+			wr *mocks.IWalletRepository,
+			lr *mocks.ILedgerRepository,
+			br *mocks.IBankAccountRepository,
+			pp *mocks.IPayoutProvider,
+		)
+		noUnitOfWork bool
+		wantStatus   entity.TransactionStatus
+		wantErr      bool
+		expectedErr  error
+	}{
+		{
+			name: "Error | Bank account not found",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+				br *mocks.IBankAccountRepository,
+				pp *mocks.IPayoutProvider,
+			) {
+				br.On("FindByIDAndUserID", bankAccountID, 1).Return(nil, 0, nil)
+			},
+			noUnitOfWork: true,
+			wantErr:      true,
+			expectedErr:  &custom_error.NoDataFound{DataType: "bank account"},
+		},
+		{
+			name: "Error | Wallet's balance is insufficient",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+				br *mocks.IBankAccountRepository,
+				pp *mocks.IPayoutProvider,
+			) {
+				br.On("FindByIDAndUserID", bankAccountID, 1).
+					Return(mockBankAccount, 1, nil)
+				wr.On("DecrementBalanceByValue", 100001, 10000).
+					Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.InsufficientBalance{},
+		},
+		{
+			name: "Error | Wallet is frozen",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+				br *mocks.IBankAccountRepository,
+				pp *mocks.IPayoutProvider,
+			) {
+				br.On("FindByIDAndUserID", bankAccountID, 1).
+					Return(mockBankAccount, 1, nil)
+				wr.On("DecrementBalanceByValue", 100001, 10000).
+					Return(&entity.Wallet{
+						Number:  100001,
+						Balance: 30000,
+						Status:  entity.WalletFrozen,
+					}, 1, nil)
+			},
+			wantErr: true,
+			expectedErr: &custom_error.WalletCannotSend{
+				Status: string(entity.WalletFrozen),
+			},
+		},
+		{
+			name: "Success | Payout completed",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+				br *mocks.IBankAccountRepository,
+				pp *mocks.IPayoutProvider,
+			) {
+				br.On("FindByIDAndUserID", bankAccountID, 1).
+					Return(mockBankAccount, 1, nil)
+				wr.On("DecrementBalanceByValue", 100001, 10000).
+					Return(mockWallet, 1, nil)
+				tr.On("CreateTransaction", mock.MatchedBy(
+					func(withdrawal *entity.Transaction) bool {
+						return withdrawal.Type == entity.Withdrawal &&
+							withdrawal.Status == entity.TransactionPending &&
+							withdrawal.To == withdrawal.From
+					},
+				)).Return(newWithdrawal(), 1, nil)
+				lr.On("CreateJournalEntry", mock.Anything).
+					Return(&entity.JournalEntry{}, 1, nil)
+				tr.On(
+					"UpdateStatus",
+					isStatus(entity.TransactionProcessing),
+					entity.TransactionPending,
+				).Return(&entity.Transaction{}, 1, nil)
+				pp.On("Payout", mock.Anything, mockBankAccount).
+					Return("REF-1", nil)
+				tr.On("UpdateStatus", mock.MatchedBy(
+					func(withdrawal *entity.Transaction) bool {
+						return withdrawal.Status == entity.TransactionCompleted &&
+							withdrawal.ProviderReference == "REF-1"
+					},
+				), entity.TransactionProcessing).
+					Return(&entity.Transaction{}, 1, nil)
+			},
+			wantStatus:  entity.TransactionCompleted,
+			wantErr:     false,
+			expectedErr: nil,
+		},
+		{
+			name: "Success | Rejected payout returns the money",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+				br *mocks.IBankAccountRepository,
+				pp *mocks.IPayoutProvider,
+			) {
+				br.On("FindByIDAndUserID", bankAccountID, 1).
+					Return(mockBankAccount, 1, nil)
+				wr.On("DecrementBalanceByValue", 100001, 10000).
+					Return(mockWallet, 1, nil)
+				tr.On("CreateTransaction", mock.Anything).
+					Return(newWithdrawal(), 1, nil)
+				lr.On("CreateJournalEntry", mock.Anything).
+					Return(&entity.JournalEntry{}, 1, nil).Twice()
+				tr.On(
+					"UpdateStatus",
+					isStatus(entity.TransactionProcessing),
+					entity.TransactionPending,
+				).Return(&entity.Transaction{}, 1, nil)
+				pp.On("Payout", mock.Anything, mockBankAccount).
+					Return("", fmt.Errorf("rejected"))
+				tr.On("UpdateStatus", mock.MatchedBy(
+					func(withdrawal *entity.Transaction) bool {
+						return withdrawal.Status == entity.TransactionFailed &&
+							withdrawal.FailureReason == "rejected"
+					},
+				), entity.TransactionProcessing).
+					Return(&entity.Transaction{}, 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 10000).
+					Return(mockWallet, 1, nil)
+			},
+			wantStatus:  entity.TransactionFailed,
+			wantErr:     false,
+			expectedErr: nil,
+		},
+		{
+			name: "Error | Rejected payout is not returned to a closed wallet",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+				br *mocks.IBankAccountRepository,
+				pp *mocks.IPayoutProvider,
+			) {
+				br.On("FindByIDAndUserID", bankAccountID, 1).
+					Return(mockBankAccount, 1, nil)
+				wr.On("DecrementBalanceByValue", 100001, 10000).
+					Return(mockWallet, 1, nil)
+				tr.On("CreateTransaction", mock.Anything).
+					Return(newWithdrawal(), 1, nil)
+				lr.On("CreateJournalEntry", mock.Anything).
+					Return(&entity.JournalEntry{}, 1, nil).Once()
+				tr.On(
+					"UpdateStatus",
+					isStatus(entity.TransactionProcessing),
+					entity.TransactionPending,
+				).Return(&entity.Transaction{}, 1, nil)
+				pp.On("Payout", mock.Anything, mockBankAccount).
+					Return("", fmt.Errorf("rejected"))
+				tr.On(
+					"UpdateStatus",
+					isStatus(entity.TransactionFailed),
+					entity.TransactionProcessing,
+				).Return(&entity.Transaction{}, 1, nil)
+				wr.On("IncrementBalanceByValue", 100001, 10000).
+					Return(&entity.Wallet{
+						Number:  100001,
+						Balance: 10000,
+						Status:  entity.WalletClosed,
+					}, 1, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.WalletClosed{},
+		},
+		{
+			name: "Error | Withdrawal already claimed",
+			mock: func(
+				tr *mocks.ITransactionRepository,
+				wr *mocks.IWalletRepository,
+				lr *mocks.ILedgerRepository,
+				br *mocks.IBankAccountRepository,
+				pp *mocks.IPayoutProvider,
+			) {
+				br.On("FindByIDAndUserID", bankAccountID, 1).
+					Return(mockBankAccount, 1, nil)
+				wr.On("DecrementBalanceByValue", 100001, 10000).
+					Return(mockWallet, 1, nil)
+				tr.On("CreateTransaction", mock.Anything).
+					Return(newWithdrawal(), 1, nil)
+				lr.On("CreateJournalEntry", mock.Anything).
+					Return(&entity.JournalEntry{}, 1, nil)
+				tr.On(
+					"UpdateStatus",
+					isStatus(entity.TransactionProcessing),
+					entity.TransactionPending,
+				).Return(nil, 0, nil)
+			},
+			wantErr:     true,
+			expectedErr: &custom_error.FailedToUpdateData{DataType: "withdrawal"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := mocks.NewITransactionRepository(t)
+			wr := mocks.NewIWalletRepository(t)
+			lr := mocks.NewILedgerRepository(t)
+			br := mocks.NewIBankAccountRepository(t)
+			pp := mocks.NewIPayoutProvider(t)
+			uow := mocks.NewIUnitOfWork(t)
+			if !tt.noUnitOfWork {
+				uow = MockUnitOfWork(t, tr, wr, lr)
+			}
+			s := NewWithdrawalService(tr, br, uow, pp)
+
+			tt.mock(tr, wr, lr, br, pp)
+
+			got, err := s.CreateWithdrawal(1, newWithdrawal())
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, got.Status)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	entity "assignment-golang-backend/internal/entity"
+	helper "assignment-golang-backend/internal/helper"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,6 +14,27 @@ type IAuthService struct {
 	mock.Mock
 }
 
+// IsTokenRevoked provides a mock function with given fields: _a0
+func (_m *IAuthService) IsTokenRevoked(_a0 string) (bool, error) {
+	ret := _m.Called(_a0)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: _a0, _a1
 func (_m *IAuthService) Login(_a0 string, _a1 string) (*entity.Token, error) {
 	ret := _m.Called(_a0, _a1)
@@ -36,6 +58,43 @@ func (_m *IAuthService) Login(_a0 string, _a1 string) (*entity.Token, error) {
 	return r0, r1
 }
 
+// Logout provides a mock function with given fields: _a0, _a1
+func (_m *IAuthService) Logout(_a0 *helper.IdTokenClaims, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*helper.IdTokenClaims, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Refresh provides a mock function with given fields: _a0
+func (_m *IAuthService) Refresh(_a0 string) (*entity.Token, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.Token
+	if rf, ok := ret.Get(0).(func(string) *entity.Token); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Token)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: _a0
 func (_m *IAuthService) Register(_a0 *entity.User) (*entity.Token, error) {
 	ret := _m.Called(_a0)
@@ -0,0 +1,140 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IBankAccountRepository is an autogenerated mock type for the IBankAccountRepository type
+type IBankAccountRepository struct {
+	mock.Mock
+}
+
+// CreateBankAccount provides a mock function with given fields: _a0
+func (_m *IBankAccountRepository) CreateBankAccount(_a0 *entity.BankAccount) (*entity.BankAccount, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.BankAccount
+	if rf, ok := ret.Get(0).(func(*entity.BankAccount) *entity.BankAccount); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BankAccount)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.BankAccount) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*entity.BankAccount) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteByIDAndUserID provides a mock function with given fields: _a0, _a1
+func (_m *IBankAccountRepository) DeleteByIDAndUserID(_a0 int, _a1 int) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, int) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByIDAndUserID provides a mock function with given fields: _a0, _a1
+func (_m *IBankAccountRepository) FindByIDAndUserID(_a0 int, _a1 int) (*entity.BankAccount, int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.BankAccount
+	if rf, ok := ret.Get(0).(func(int, int) *entity.BankAccount); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BankAccount)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, int) int); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, int) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByUserID provides a mock function with given fields: _a0
+func (_m *IBankAccountRepository) FindByUserID(_a0 int) ([]*entity.BankAccount, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*entity.BankAccount
+	if rf, ok := ret.Get(0).(func(int) []*entity.BankAccount); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.BankAccount)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewIBankAccountRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIBankAccountRepository creates a new instance of IBankAccountRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIBankAccountRepository(t mockConstructorTestingTNewIBankAccountRepository) *IBankAccountRepository {
+	mock := &IBankAccountRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
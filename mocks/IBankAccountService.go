@@ -0,0 +1,89 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IBankAccountService is an autogenerated mock type for the IBankAccountService type
+type IBankAccountService struct {
+	mock.Mock
+}
+
+// CreateBankAccount provides a mock function with given fields: _a0
+func (_m *IBankAccountService) CreateBankAccount(_a0 *entity.BankAccount) (*entity.BankAccount, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.BankAccount
+	if rf, ok := ret.Get(0).(func(*entity.BankAccount) *entity.BankAccount); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BankAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.BankAccount) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteBankAccount provides a mock function with given fields: _a0, _a1
+func (_m *IBankAccountService) DeleteBankAccount(_a0 int, _a1 int) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByUserID provides a mock function with given fields: _a0
+func (_m *IBankAccountService) FindByUserID(_a0 int) ([]*entity.BankAccount, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*entity.BankAccount
+	if rf, ok := ret.Get(0).(func(int) []*entity.BankAccount); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.BankAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIBankAccountService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIBankAccountService creates a new instance of IBankAccountService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIBankAccountService(t mockConstructorTestingTNewIBankAccountService) *IBankAccountService {
+	mock := &IBankAccountService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
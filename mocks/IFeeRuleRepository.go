@@ -0,0 +1,59 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IFeeRuleRepository is an autogenerated mock type for the IFeeRuleRepository type
+type IFeeRuleRepository struct {
+	mock.Mock
+}
+
+// FindMatching provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *IFeeRuleRepository) FindMatching(_a0 entity.TransactionType, _a1 entity.Currency, _a2 *entity.SourceOfFundsID, _a3 int) (*entity.FeeRule, int, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *entity.FeeRule
+	if rf, ok := ret.Get(0).(func(entity.TransactionType, entity.Currency, *entity.SourceOfFundsID, int) *entity.FeeRule); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.FeeRule)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(entity.TransactionType, entity.Currency, *entity.SourceOfFundsID, int) int); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(entity.TransactionType, entity.Currency, *entity.SourceOfFundsID, int) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewIFeeRuleRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIFeeRuleRepository creates a new instance of IFeeRuleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIFeeRuleRepository(t mockConstructorTestingTNewIFeeRuleRepository) *IFeeRuleRepository {
+	mock := &IFeeRuleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,52 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IFeeService is an autogenerated mock type for the IFeeService type
+type IFeeService struct {
+	mock.Mock
+}
+
+// Quote provides a mock function with given fields: _a0
+func (_m *IFeeService) Quote(_a0 *entity.Transaction) (*entity.Transaction, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.Transaction
+	if rf, ok := ret.Get(0).(func(*entity.Transaction) *entity.Transaction); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.Transaction) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIFeeService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIFeeService creates a new instance of IFeeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIFeeService(t mockConstructorTestingTNewIFeeService) *IFeeService {
+	mock := &IFeeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
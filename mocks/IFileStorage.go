@@ -0,0 +1,80 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IFileStorage is an autogenerated mock type for the IFileStorage type
+type IFileStorage struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: _a0
+func (_m *IFileStorage) Delete(_a0 string) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Open provides a mock function with given fields: _a0
+func (_m *IFileStorage) Open(_a0 string) (io.ReadCloser, error) {
+	ret := _m.Called(_a0)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(string) io.ReadCloser); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: _a0, _a1
+func (_m *IFileStorage) Save(_a0 string, _a1 io.Reader) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, io.Reader) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIFileStorage interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIFileStorage creates a new instance of IFileStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIFileStorage(t mockConstructorTestingTNewIFileStorage) *IFileStorage {
+	mock := &IFileStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,140 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IIdempotencyKeyRepository is an autogenerated mock type for the IIdempotencyKeyRepository type
+type IIdempotencyKeyRepository struct {
+	mock.Mock
+}
+
+// CreateKey provides a mock function with given fields: _a0
+func (_m *IIdempotencyKeyRepository) CreateKey(_a0 *entity.IdempotencyKey) (*entity.IdempotencyKey, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.IdempotencyKey
+	if rf, ok := ret.Get(0).(func(*entity.IdempotencyKey) *entity.IdempotencyKey); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.IdempotencyKey)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.IdempotencyKey) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*entity.IdempotencyKey) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteKey provides a mock function with given fields: _a0
+func (_m *IIdempotencyKeyRepository) DeleteKey(_a0 *entity.IdempotencyKey) (int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*entity.IdempotencyKey) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.IdempotencyKey) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByUserIDAndKey provides a mock function with given fields: _a0, _a1
+func (_m *IIdempotencyKeyRepository) FindByUserIDAndKey(_a0 int, _a1 string) (*entity.IdempotencyKey, int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.IdempotencyKey
+	if rf, ok := ret.Get(0).(func(int, string) *entity.IdempotencyKey); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.IdempotencyKey)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, string) int); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, string) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateResponse provides a mock function with given fields: _a0
+func (_m *IIdempotencyKeyRepository) UpdateResponse(_a0 *entity.IdempotencyKey) (*entity.IdempotencyKey, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.IdempotencyKey
+	if rf, ok := ret.Get(0).(func(*entity.IdempotencyKey) *entity.IdempotencyKey); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.IdempotencyKey)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.IdempotencyKey) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*entity.IdempotencyKey) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewIIdempotencyKeyRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIIdempotencyKeyRepository creates a new instance of IIdempotencyKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIIdempotencyKeyRepository(t mockConstructorTestingTNewIIdempotencyKeyRepository) *IIdempotencyKeyRepository {
+	mock := &IIdempotencyKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,80 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IIdempotencyService is an autogenerated mock type for the IIdempotencyService type
+type IIdempotencyService struct {
+	mock.Mock
+}
+
+// Complete provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IIdempotencyService) Complete(_a0 *entity.IdempotencyKey, _a1 int, _a2 []byte) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.IdempotencyKey, int, []byte) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Release provides a mock function with given fields: _a0
+func (_m *IIdempotencyService) Release(_a0 *entity.IdempotencyKey) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.IdempotencyKey) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reserve provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IIdempotencyService) Reserve(_a0 int, _a1 string, _a2 string) (*entity.IdempotencyKey, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *entity.IdempotencyKey
+	if rf, ok := ret.Get(0).(func(int, string, string) *entity.IdempotencyKey); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.IdempotencyKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIIdempotencyService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIIdempotencyService creates a new instance of IIdempotencyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIIdempotencyService(t mockConstructorTestingTNewIIdempotencyService) *IIdempotencyService {
+	mock := &IIdempotencyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
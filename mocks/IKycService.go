@@ -0,0 +1,175 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IKycService is an autogenerated mock type for the IKycService type
+type IKycService struct {
+	mock.Mock
+}
+
+// Approve provides a mock function with given fields: _a0, _a1
+func (_m *IKycService) Approve(_a0 int, _a1 int) (*entity.KycSubmission, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.KycSubmission
+	if rf, ok := ret.Get(0).(func(int, int) *entity.KycSubmission); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.KycSubmission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLatest provides a mock function with given fields: _a0
+func (_m *IKycService) FindLatest(_a0 int) (*entity.KycSubmission, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.KycSubmission
+	if rf, ok := ret.Get(0).(func(int) *entity.KycSubmission); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.KycSubmission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPending provides a mock function with given fields:
+func (_m *IKycService) FindPending() ([]*entity.KycSubmission, error) {
+	ret := _m.Called()
+
+	var r0 []*entity.KycSubmission
+	if rf, ok := ret.Get(0).(func() []*entity.KycSubmission); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.KycSubmission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenDocument provides a mock function with given fields: _a0, _a1
+func (_m *IKycService) OpenDocument(_a0 int, _a1 entity.KycDocument) (io.ReadCloser, string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(int, entity.KycDocument) io.ReadCloser); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(int, entity.KycDocument) string); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, entity.KycDocument) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Reject provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IKycService) Reject(_a0 int, _a1 int, _a2 string) (*entity.KycSubmission, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *entity.KycSubmission
+	if rf, ok := ret.Get(0).(func(int, int, string) *entity.KycSubmission); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.KycSubmission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Submit provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IKycService) Submit(_a0 *entity.KycSubmission, _a1 io.Reader, _a2 io.Reader) (*entity.KycSubmission, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *entity.KycSubmission
+	if rf, ok := ret.Get(0).(func(*entity.KycSubmission, io.Reader, io.Reader) *entity.KycSubmission); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.KycSubmission)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.KycSubmission, io.Reader, io.Reader) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIKycService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIKycService creates a new instance of IKycService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIKycService(t mockConstructorTestingTNewIKycService) *IKycService {
+	mock := &IKycService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,170 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IKycSubmissionRepository is an autogenerated mock type for the IKycSubmissionRepository type
+type IKycSubmissionRepository struct {
+	mock.Mock
+}
+
+// CreateKycSubmission provides a mock function with given fields: _a0
+func (_m *IKycSubmissionRepository) CreateKycSubmission(_a0 *entity.KycSubmission) (*entity.KycSubmission, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.KycSubmission
+	if rf, ok := ret.Get(0).(func(*entity.KycSubmission) *entity.KycSubmission); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.KycSubmission)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.KycSubmission) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*entity.KycSubmission) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByID provides a mock function with given fields: _a0
+func (_m *IKycSubmissionRepository) FindByID(_a0 int) (*entity.KycSubmission, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.KycSubmission
+	if rf, ok := ret.Get(0).(func(int) *entity.KycSubmission); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.KycSubmission)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByStatus provides a mock function with given fields: _a0
+func (_m *IKycSubmissionRepository) FindByStatus(_a0 entity.KycStatus) ([]*entity.KycSubmission, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*entity.KycSubmission
+	if rf, ok := ret.Get(0).(func(entity.KycStatus) []*entity.KycSubmission); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.KycSubmission)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(entity.KycStatus) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(entity.KycStatus) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindLatestByUserID provides a mock function with given fields: _a0
+func (_m *IKycSubmissionRepository) FindLatestByUserID(_a0 int) (*entity.KycSubmission, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.KycSubmission
+	if rf, ok := ret.Get(0).(func(int) *entity.KycSubmission); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.KycSubmission)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateReview provides a mock function with given fields: _a0
+func (_m *IKycSubmissionRepository) UpdateReview(_a0 *entity.KycSubmission) (int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*entity.KycSubmission) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.KycSubmission) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIKycSubmissionRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIKycSubmissionRepository creates a new instance of IKycSubmissionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIKycSubmissionRepository(t mockConstructorTestingTNewIKycSubmissionRepository) *IKycSubmissionRepository {
+	mock := &IKycSubmissionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,112 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ILedgerRepository is an autogenerated mock type for the ILedgerRepository type
+type ILedgerRepository struct {
+	mock.Mock
+}
+
+// CreateJournalEntry provides a mock function with given fields: _a0
+func (_m *ILedgerRepository) CreateJournalEntry(_a0 *entity.JournalEntry) (*entity.JournalEntry, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.JournalEntry
+	if rf, ok := ret.Get(0).(func(*entity.JournalEntry) *entity.JournalEntry); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.JournalEntry)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.JournalEntry) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*entity.JournalEntry) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindStatementLinesAfter provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
+func (_m *ILedgerRepository) FindStatementLinesAfter(_a0 int, _a1 time.Time, _a2 time.Time, _a3 *entity.StatementLine, _a4 int) ([]*entity.StatementLine, int, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
+
+	var r0 []*entity.StatementLine
+	if rf, ok := ret.Get(0).(func(int, time.Time, time.Time, *entity.StatementLine, int) []*entity.StatementLine); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.StatementLine)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, time.Time, time.Time, *entity.StatementLine, int) int); ok {
+		r1 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, time.Time, time.Time, *entity.StatementLine, int) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SumBalanceByAccountBefore provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ILedgerRepository) SumBalanceByAccountBefore(_a0 entity.LedgerAccountType, _a1 int, _a2 time.Time) (int, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(entity.LedgerAccountType, int, time.Time) int); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(entity.LedgerAccountType, int, time.Time) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewILedgerRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewILedgerRepository creates a new instance of ILedgerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewILedgerRepository(t mockConstructorTestingTNewILedgerRepository) *ILedgerRepository {
+	mock := &ILedgerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
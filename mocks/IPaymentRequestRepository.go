@@ -0,0 +1,202 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IPaymentRequestRepository is an autogenerated mock type for the IPaymentRequestRepository type
+type IPaymentRequestRepository struct {
+	mock.Mock
+}
+
+// CreatePaymentRequest provides a mock function with given fields: _a0
+func (_m *IPaymentRequestRepository) CreatePaymentRequest(_a0 *entity.PaymentRequest) (*entity.PaymentRequest, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.PaymentRequest
+	if rf, ok := ret.Get(0).(func(*entity.PaymentRequest) *entity.PaymentRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentRequest)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.PaymentRequest) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*entity.PaymentRequest) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByID provides a mock function with given fields: _a0
+func (_m *IPaymentRequestRepository) FindByID(_a0 int) (*entity.PaymentRequest, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.PaymentRequest
+	if rf, ok := ret.Get(0).(func(int) *entity.PaymentRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentRequest)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByRequesterNumber provides a mock function with given fields: _a0
+func (_m *IPaymentRequestRepository) FindByRequesterNumber(_a0 int) ([]*entity.PaymentRequest, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*entity.PaymentRequest
+	if rf, ok := ret.Get(0).(func(int) []*entity.PaymentRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PaymentRequest)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindPendingByPayerNumber provides a mock function with given fields: _a0, _a1
+func (_m *IPaymentRequestRepository) FindPendingByPayerNumber(_a0 int, _a1 time.Time) ([]*entity.PaymentRequest, int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*entity.PaymentRequest
+	if rf, ok := ret.Get(0).(func(int, time.Time) []*entity.PaymentRequest); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PaymentRequest)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, time.Time) int); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, time.Time) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdatePendingStatus provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *IPaymentRequestRepository) UpdatePendingStatus(_a0 int, _a1 int, _a2 entity.PaymentRequestStatus, _a3 time.Time) (*entity.PaymentRequest, int, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *entity.PaymentRequest
+	if rf, ok := ret.Get(0).(func(int, int, entity.PaymentRequestStatus, time.Time) *entity.PaymentRequest); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentRequest)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, int, entity.PaymentRequestStatus, time.Time) int); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, int, entity.PaymentRequestStatus, time.Time) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateTransactionID provides a mock function with given fields: _a0, _a1
+func (_m *IPaymentRequestRepository) UpdateTransactionID(_a0 int, _a1 int) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, int) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIPaymentRequestRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIPaymentRequestRepository creates a new instance of IPaymentRequestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIPaymentRequestRepository(t mockConstructorTestingTNewIPaymentRequestRepository) *IPaymentRequestRepository {
+	mock := &IPaymentRequestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
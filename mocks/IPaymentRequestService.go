@@ -0,0 +1,153 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPaymentRequestService is an autogenerated mock type for the IPaymentRequestService type
+type IPaymentRequestService struct {
+	mock.Mock
+}
+
+// Approve provides a mock function with given fields: _a0, _a1
+func (_m *IPaymentRequestService) Approve(_a0 int, _a1 int) (*entity.PaymentRequest, *entity.Transaction, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.PaymentRequest
+	if rf, ok := ret.Get(0).(func(int, int) *entity.PaymentRequest); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentRequest)
+		}
+	}
+
+	var r1 *entity.Transaction
+	if rf, ok := ret.Get(1).(func(int, int) *entity.Transaction); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.Transaction)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, int) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreatePaymentRequest provides a mock function with given fields: _a0
+func (_m *IPaymentRequestService) CreatePaymentRequest(_a0 *entity.PaymentRequest) (*entity.PaymentRequest, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.PaymentRequest
+	if rf, ok := ret.Get(0).(func(*entity.PaymentRequest) *entity.PaymentRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.PaymentRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Decline provides a mock function with given fields: _a0, _a1
+func (_m *IPaymentRequestService) Decline(_a0 int, _a1 int) (*entity.PaymentRequest, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.PaymentRequest
+	if rf, ok := ret.Get(0).(func(int, int) *entity.PaymentRequest); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindIncoming provides a mock function with given fields: _a0
+func (_m *IPaymentRequestService) FindIncoming(_a0 int) ([]*entity.PaymentRequest, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*entity.PaymentRequest
+	if rf, ok := ret.Get(0).(func(int) []*entity.PaymentRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PaymentRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOutgoing provides a mock function with given fields: _a0
+func (_m *IPaymentRequestService) FindOutgoing(_a0 int) ([]*entity.PaymentRequest, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*entity.PaymentRequest
+	if rf, ok := ret.Get(0).(func(int) []*entity.PaymentRequest); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PaymentRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIPaymentRequestService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIPaymentRequestService creates a new instance of IPaymentRequestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIPaymentRequestService(t mockConstructorTestingTNewIPaymentRequestService) *IPaymentRequestService {
+	mock := &IPaymentRequestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,50 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPayoutProvider is an autogenerated mock type for the IPayoutProvider type
+type IPayoutProvider struct {
+	mock.Mock
+}
+
+// Payout provides a mock function with given fields: _a0, _a1
+func (_m *IPayoutProvider) Payout(_a0 *entity.Transaction, _a1 *entity.BankAccount) (string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*entity.Transaction, *entity.BankAccount) string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.Transaction, *entity.BankAccount) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIPayoutProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIPayoutProvider creates a new instance of IPayoutProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIPayoutProvider(t mockConstructorTestingTNewIPayoutProvider) *IPayoutProvider {
+	mock := &IPayoutProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
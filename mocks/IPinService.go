@@ -0,0 +1,81 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// IPinService is an autogenerated mock type for the IPinService type
+type IPinService struct {
+	mock.Mock
+}
+
+// ChangePin provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IPinService) ChangePin(_a0 int, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPin provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IPinService) ResetPin(_a0 int, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPin provides a mock function with given fields: _a0, _a1
+func (_m *IPinService) SetPin(_a0 int, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyPin provides a mock function with given fields: _a0, _a1
+func (_m *IPinService) VerifyPin(_a0 int, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIPinService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIPinService creates a new instance of IPinService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIPinService(t mockConstructorTestingTNewIPinService) *IPinService {
+	mock := &IPinService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,50 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRateProvider is an autogenerated mock type for the IRateProvider type
+type IRateProvider struct {
+	mock.Mock
+}
+
+// Rate provides a mock function with given fields: _a0, _a1
+func (_m *IRateProvider) Rate(_a0 entity.Currency, _a1 entity.Currency) (string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(entity.Currency, entity.Currency) string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(entity.Currency, entity.Currency) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIRateProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRateProvider creates a new instance of IRateProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRateProvider(t mockConstructorTestingTNewIRateProvider) *IRateProvider {
+	mock := &IRateProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
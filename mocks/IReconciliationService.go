@@ -0,0 +1,52 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IReconciliationService is an autogenerated mock type for the IReconciliationService type
+type IReconciliationService struct {
+	mock.Mock
+}
+
+// ReconcileWallets provides a mock function with given fields: _a0
+func (_m *IReconciliationService) ReconcileWallets(_a0 bool) ([]*entity.BalanceReconciliation, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*entity.BalanceReconciliation
+	if rf, ok := ret.Get(0).(func(bool) []*entity.BalanceReconciliation); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.BalanceReconciliation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIReconciliationService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIReconciliationService creates a new instance of IReconciliationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIReconciliationService(t mockConstructorTestingTNewIReconciliationService) *IReconciliationService {
+	mock := &IReconciliationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRefreshTokenRepository is an autogenerated mock type for the IRefreshTokenRepository type
+type IRefreshTokenRepository struct {
+	mock.Mock
+}
+
+// CreateRefreshToken provides a mock function with given fields: _a0
+func (_m *IRefreshTokenRepository) CreateRefreshToken(_a0 *entity.RefreshToken) (*entity.RefreshToken, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.RefreshToken
+	if rf, ok := ret.Get(0).(func(*entity.RefreshToken) *entity.RefreshToken); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RefreshToken)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.RefreshToken) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*entity.RefreshToken) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByTokenHash provides a mock function with given fields: _a0
+func (_m *IRefreshTokenRepository) FindByTokenHash(_a0 string) (*entity.RefreshToken, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.RefreshToken
+	if rf, ok := ret.Get(0).(func(string) *entity.RefreshToken); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RefreshToken)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RevokeAllByUserID provides a mock function with given fields: _a0
+func (_m *IRefreshTokenRepository) RevokeAllByUserID(_a0 int) (int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeByID provides a mock function with given fields: _a0
+func (_m *IRefreshTokenRepository) RevokeByID(_a0 int) (int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIRefreshTokenRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRefreshTokenRepository creates a new instance of IRefreshTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRefreshTokenRepository(t mockConstructorTestingTNewIRefreshTokenRepository) *IRefreshTokenRepository {
+	mock := &IRefreshTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
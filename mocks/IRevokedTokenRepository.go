@@ -0,0 +1,89 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRevokedTokenRepository is an autogenerated mock type for the IRevokedTokenRepository type
+type IRevokedTokenRepository struct {
+	mock.Mock
+}
+
+// CreateRevokedToken provides a mock function with given fields: _a0
+func (_m *IRevokedTokenRepository) CreateRevokedToken(_a0 *entity.RevokedToken) (*entity.RevokedToken, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.RevokedToken
+	if rf, ok := ret.Get(0).(func(*entity.RevokedToken) *entity.RevokedToken); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RevokedToken)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.RevokedToken) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*entity.RevokedToken) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByJTI provides a mock function with given fields: _a0
+func (_m *IRevokedTokenRepository) FindByJTI(_a0 string) (*entity.RevokedToken, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.RevokedToken
+	if rf, ok := ret.Get(0).(func(string) *entity.RevokedToken); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.RevokedToken)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewIRevokedTokenRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRevokedTokenRepository creates a new instance of IRevokedTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRevokedTokenRepository(t mockConstructorTestingTNewIRevokedTokenRepository) *IRevokedTokenRepository {
+	mock := &IRevokedTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,244 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IScheduledTransferRepository is an autogenerated mock type for the IScheduledTransferRepository type
+type IScheduledTransferRepository struct {
+	mock.Mock
+}
+
+// CancelScheduledTransfer provides a mock function with given fields: _a0, _a1
+func (_m *IScheduledTransferRepository) CancelScheduledTransfer(_a0 int, _a1 int) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, int) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimRun provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IScheduledTransferRepository) ClaimRun(_a0 *entity.ScheduledTransfer, _a1 *time.Time, _a2 int) (int, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*entity.ScheduledTransfer, *time.Time, int) int); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.ScheduledTransfer, *time.Time, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRun provides a mock function with given fields: _a0
+func (_m *IScheduledTransferRepository) CreateRun(_a0 *entity.ScheduledTransferRun) (*entity.ScheduledTransferRun, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.ScheduledTransferRun
+	if rf, ok := ret.Get(0).(func(*entity.ScheduledTransferRun) *entity.ScheduledTransferRun); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ScheduledTransferRun)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.ScheduledTransferRun) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*entity.ScheduledTransferRun) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateScheduledTransfer provides a mock function with given fields: _a0
+func (_m *IScheduledTransferRepository) CreateScheduledTransfer(_a0 *entity.ScheduledTransfer) (*entity.ScheduledTransfer, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.ScheduledTransfer
+	if rf, ok := ret.Get(0).(func(*entity.ScheduledTransfer) *entity.ScheduledTransfer); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ScheduledTransfer)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.ScheduledTransfer) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*entity.ScheduledTransfer) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByIDAndUserID provides a mock function with given fields: _a0, _a1
+func (_m *IScheduledTransferRepository) FindByIDAndUserID(_a0 int, _a1 int) (*entity.ScheduledTransfer, int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.ScheduledTransfer
+	if rf, ok := ret.Get(0).(func(int, int) *entity.ScheduledTransfer); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ScheduledTransfer)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, int) int); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, int) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByUserID provides a mock function with given fields: _a0
+func (_m *IScheduledTransferRepository) FindByUserID(_a0 int) ([]*entity.ScheduledTransfer, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*entity.ScheduledTransfer
+	if rf, ok := ret.Get(0).(func(int) []*entity.ScheduledTransfer); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.ScheduledTransfer)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindDue provides a mock function with given fields: _a0, _a1
+func (_m *IScheduledTransferRepository) FindDue(_a0 time.Time, _a1 int) ([]*entity.ScheduledTransfer, int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*entity.ScheduledTransfer
+	if rf, ok := ret.Get(0).(func(time.Time, int) []*entity.ScheduledTransfer); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.ScheduledTransfer)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(time.Time, int) int); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(time.Time, int) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateActiveScheduledTransfer provides a mock function with given fields: _a0
+func (_m *IScheduledTransferRepository) UpdateActiveScheduledTransfer(_a0 *entity.ScheduledTransfer) (int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*entity.ScheduledTransfer) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.ScheduledTransfer) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIScheduledTransferRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIScheduledTransferRepository creates a new instance of IScheduledTransferRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIScheduledTransferRepository(t mockConstructorTestingTNewIScheduledTransferRepository) *IScheduledTransferRepository {
+	mock := &IScheduledTransferRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
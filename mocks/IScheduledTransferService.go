@@ -0,0 +1,158 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IScheduledTransferService is an autogenerated mock type for the IScheduledTransferService type
+type IScheduledTransferService struct {
+	mock.Mock
+}
+
+// CancelScheduledTransfer provides a mock function with given fields: _a0, _a1
+func (_m *IScheduledTransferService) CancelScheduledTransfer(_a0 int, _a1 int) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateScheduledTransfer provides a mock function with given fields: _a0
+func (_m *IScheduledTransferService) CreateScheduledTransfer(_a0 *entity.ScheduledTransfer) (*entity.ScheduledTransfer, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.ScheduledTransfer
+	if rf, ok := ret.Get(0).(func(*entity.ScheduledTransfer) *entity.ScheduledTransfer); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ScheduledTransfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.ScheduledTransfer) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: _a0, _a1
+func (_m *IScheduledTransferService) FindByID(_a0 int, _a1 int) (*entity.ScheduledTransfer, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.ScheduledTransfer
+	if rf, ok := ret.Get(0).(func(int, int) *entity.ScheduledTransfer); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ScheduledTransfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByUserID provides a mock function with given fields: _a0
+func (_m *IScheduledTransferService) FindByUserID(_a0 int) ([]*entity.ScheduledTransfer, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*entity.ScheduledTransfer
+	if rf, ok := ret.Get(0).(func(int) []*entity.ScheduledTransfer); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.ScheduledTransfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunDueScheduledTransfers provides a mock function with given fields: _a0
+func (_m *IScheduledTransferService) RunDueScheduledTransfers(_a0 time.Time) (int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(time.Time) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateScheduledTransfer provides a mock function with given fields: _a0
+func (_m *IScheduledTransferService) UpdateScheduledTransfer(_a0 *entity.ScheduledTransfer) (*entity.ScheduledTransfer, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.ScheduledTransfer
+	if rf, ok := ret.Get(0).(func(*entity.ScheduledTransfer) *entity.ScheduledTransfer); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ScheduledTransfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.ScheduledTransfer) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIScheduledTransferService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIScheduledTransferService creates a new instance of IScheduledTransferService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIScheduledTransferService(t mockConstructorTestingTNewIScheduledTransferService) *IScheduledTransferService {
+	mock := &IScheduledTransferService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,89 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ISourceOfFundsRepository is an autogenerated mock type for the ISourceOfFundsRepository type
+type ISourceOfFundsRepository struct {
+	mock.Mock
+}
+
+// FindByID provides a mock function with given fields: _a0
+func (_m *ISourceOfFundsRepository) FindByID(_a0 entity.SourceOfFundsID) (*entity.SourceOfFunds, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.SourceOfFunds
+	if rf, ok := ret.Get(0).(func(entity.SourceOfFundsID) *entity.SourceOfFunds); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.SourceOfFunds)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(entity.SourceOfFundsID) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(entity.SourceOfFundsID) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindEnabled provides a mock function with given fields:
+func (_m *ISourceOfFundsRepository) FindEnabled() ([]*entity.SourceOfFunds, int, error) {
+	ret := _m.Called()
+
+	var r0 []*entity.SourceOfFunds
+	if rf, ok := ret.Get(0).(func() []*entity.SourceOfFunds); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.SourceOfFunds)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func() int); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewISourceOfFundsRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewISourceOfFundsRepository creates a new instance of ISourceOfFundsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewISourceOfFundsRepository(t mockConstructorTestingTNewISourceOfFundsRepository) *ISourceOfFundsRepository {
+	mock := &ISourceOfFundsRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,52 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ISourceOfFundsService is an autogenerated mock type for the ISourceOfFundsService type
+type ISourceOfFundsService struct {
+	mock.Mock
+}
+
+// FindEnabled provides a mock function with given fields:
+func (_m *ISourceOfFundsService) FindEnabled() ([]*entity.SourceOfFunds, error) {
+	ret := _m.Called()
+
+	var r0 []*entity.SourceOfFunds
+	if rf, ok := ret.Get(0).(func() []*entity.SourceOfFunds); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.SourceOfFunds)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewISourceOfFundsService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewISourceOfFundsService creates a new instance of ISourceOfFundsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewISourceOfFundsService(t mockConstructorTestingTNewISourceOfFundsService) *ISourceOfFundsService {
+	mock := &ISourceOfFundsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
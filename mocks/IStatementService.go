@@ -0,0 +1,68 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IStatementService is an autogenerated mock type for the IStatementService type
+type IStatementService struct {
+	mock.Mock
+}
+
+// EachLine provides a mock function with given fields: _a0, _a1
+func (_m *IStatementService) EachLine(_a0 *entity.Statement, _a1 func(*entity.StatementLine) error) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.Statement, func(*entity.StatementLine) error) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Open provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IStatementService) Open(_a0 int, _a1 time.Time, _a2 time.Time) (*entity.Statement, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *entity.Statement
+	if rf, ok := ret.Get(0).(func(int, time.Time, time.Time) *entity.Statement); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Statement)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, time.Time, time.Time) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIStatementService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIStatementService creates a new instance of IStatementService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIStatementService(t mockConstructorTestingTNewIStatementService) *IStatementService {
+	mock := &IStatementService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
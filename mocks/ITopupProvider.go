@@ -0,0 +1,90 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+
+	topup "assignment-golang-backend/internal/topup"
+)
+
+// ITopupProvider is an autogenerated mock type for the ITopupProvider type
+type ITopupProvider struct {
+	mock.Mock
+}
+
+// CreatePayment provides a mock function with given fields: _a0
+func (_m *ITopupProvider) CreatePayment(_a0 *entity.Transaction) (string, error) {
+	ret := _m.Called(_a0)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*entity.Transaction) string); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.Transaction) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with given fields:
+func (_m *ITopupProvider) Name() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ParseWebhook provides a mock function with given fields: _a0, _a1
+func (_m *ITopupProvider) ParseWebhook(_a0 []byte, _a1 http.Header) (*topup.WebhookEvent, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *topup.WebhookEvent
+	if rf, ok := ret.Get(0).(func([]byte, http.Header) *topup.WebhookEvent); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*topup.WebhookEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, http.Header) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewITopupProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewITopupProvider creates a new instance of ITopupProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewITopupProvider(t mockConstructorTestingTNewITopupProvider) *ITopupProvider {
+	mock := &ITopupProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,76 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ITopupService is an autogenerated mock type for the ITopupService type
+type ITopupService struct {
+	mock.Mock
+}
+
+// CreateTopup provides a mock function with given fields: _a0
+func (_m *ITopupService) CreateTopup(_a0 *entity.Transaction) (*entity.Transaction, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.Transaction
+	if rf, ok := ret.Get(0).(func(*entity.Transaction) *entity.Transaction); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.Transaction) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleWebhook provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITopupService) HandleWebhook(_a0 string, _a1 []byte, _a2 http.Header) (*entity.Transaction, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *entity.Transaction
+	if rf, ok := ret.Get(0).(func(string, []byte, http.Header) *entity.Transaction); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte, http.Header) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewITopupService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewITopupService creates a new instance of ITopupService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewITopupService(t mockConstructorTestingTNewITopupService) *ITopupService {
+	mock := &ITopupService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ITransactionLimitRepository is an autogenerated mock type for the ITransactionLimitRepository type
+type ITransactionLimitRepository struct {
+	mock.Mock
+}
+
+// FindByWalletNumber provides a mock function with given fields: _a0
+func (_m *ITransactionLimitRepository) FindByWalletNumber(_a0 int) (*entity.TransactionLimit, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.TransactionLimit
+	if rf, ok := ret.Get(0).(func(int) *entity.TransactionLimit); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TransactionLimit)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// LockOwnerOfWallet provides a mock function with given fields: _a0
+func (_m *ITransactionLimitRepository) LockOwnerOfWallet(_a0 int) (int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SumOutgoingSince provides a mock function with given fields: _a0, _a1
+func (_m *ITransactionLimitRepository) SumOutgoingSince(_a0 int, _a1 time.Time) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, time.Time) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, time.Time) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewITransactionLimitRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewITransactionLimitRepository creates a new instance of ITransactionLimitRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewITransactionLimitRepository(t mockConstructorTestingTNewITransactionLimitRepository) *ITransactionLimitRepository {
+	mock := &ITransactionLimitRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
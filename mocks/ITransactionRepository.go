@@ -13,12 +13,42 @@ type ITransactionRepository struct {
 	mock.Mock
 }
 
+// AddRefundedAmount provides a mock function with given fields: _a0, _a1
+func (_m *ITransactionRepository) AddRefundedAmount(_a0 int, _a1 int) (*entity.Transaction, int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.Transaction
+	if rf, ok := ret.Get(0).(func(int, int) *entity.Transaction); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, int) int); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, int) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // CountTransactionByWalletNumber provides a mock function with given fields: _a0, _a1
-func (_m *ITransactionRepository) CountTransactionByWalletNumber(_a0 int, _a1 string) int {
+func (_m *ITransactionRepository) CountTransactionByWalletNumber(_a0 int, _a1 *entity.TransactionFilter) int {
 	ret := _m.Called(_a0, _a1)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(int, string) int); ok {
+	if rf, ok := ret.Get(0).(func(int, *entity.TransactionFilter) int); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Get(0).(int)
@@ -57,13 +87,133 @@ func (_m *ITransactionRepository) CreateTransaction(_a0 *entity.Transaction) (*e
 	return r0, r1, r2
 }
 
-// FindByWalletNumberWithQuery provides a mock function with given fields: _a0, _a1
-func (_m *ITransactionRepository) FindByWalletNumberWithQuery(_a0 int, _a1 *entity.Pagination) ([]*entity.Transaction, int, error) {
+// FindByID provides a mock function with given fields: _a0
+func (_m *ITransactionRepository) FindByID(_a0 int) (*entity.Transaction, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.Transaction
+	if rf, ok := ret.Get(0).(func(int) *entity.Transaction); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByIDWithWallets provides a mock function with given fields: _a0
+func (_m *ITransactionRepository) FindByIDWithWallets(_a0 int) (*entity.Transaction, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.Transaction
+	if rf, ok := ret.Get(0).(func(int) *entity.Transaction); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByProviderReference provides a mock function with given fields: _a0, _a1
+func (_m *ITransactionRepository) FindByProviderReference(_a0 entity.TransactionType, _a1 string) (*entity.Transaction, int, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 []*entity.Transaction
-	if rf, ok := ret.Get(0).(func(int, *entity.Pagination) []*entity.Transaction); ok {
+	var r0 *entity.Transaction
+	if rf, ok := ret.Get(0).(func(entity.TransactionType, string) *entity.Transaction); ok {
 		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(entity.TransactionType, string) int); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(entity.TransactionType, string) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByWalletNumberAfterCursor provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITransactionRepository) FindByWalletNumberAfterCursor(_a0 int, _a1 *entity.TransactionFilter, _a2 *entity.CursorPagination) ([]*entity.Transaction, int, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []*entity.Transaction
+	if rf, ok := ret.Get(0).(func(int, *entity.TransactionFilter, *entity.CursorPagination) []*entity.Transaction); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Transaction)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, *entity.TransactionFilter, *entity.CursorPagination) int); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, *entity.TransactionFilter, *entity.CursorPagination) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByWalletNumberWithQuery provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITransactionRepository) FindByWalletNumberWithQuery(_a0 int, _a1 *entity.TransactionFilter, _a2 *entity.Pagination) ([]*entity.Transaction, int, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []*entity.Transaction
+	if rf, ok := ret.Get(0).(func(int, *entity.TransactionFilter, *entity.Pagination) []*entity.Transaction); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entity.Transaction)
@@ -71,14 +221,44 @@ func (_m *ITransactionRepository) FindByWalletNumberWithQuery(_a0 int, _a1 *enti
 	}
 
 	var r1 int
-	if rf, ok := ret.Get(1).(func(int, *entity.Pagination) int); ok {
+	if rf, ok := ret.Get(1).(func(int, *entity.TransactionFilter, *entity.Pagination) int); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, *entity.TransactionFilter, *entity.Pagination) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateStatus provides a mock function with given fields: _a0, _a1
+func (_m *ITransactionRepository) UpdateStatus(_a0 *entity.Transaction, _a1 entity.TransactionStatus) (*entity.Transaction, int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.Transaction
+	if rf, ok := ret.Get(0).(func(*entity.Transaction, entity.TransactionStatus) *entity.Transaction); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.Transaction, entity.TransactionStatus) int); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(int, *entity.Pagination) error); ok {
+	if rf, ok := ret.Get(2).(func(*entity.Transaction, entity.TransactionStatus) error); ok {
 		r2 = rf(_a0, _a1)
 	} else {
 		r2 = ret.Error(2)
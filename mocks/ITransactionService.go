@@ -13,8 +13,8 @@ type ITransactionService struct {
 	mock.Mock
 }
 
-// CreateTopup provides a mock function with given fields: _a0
-func (_m *ITransactionService) CreateTopup(_a0 *entity.Transaction) (*entity.Transaction, error) {
+// CreateRefund provides a mock function with given fields: _a0
+func (_m *ITransactionService) CreateRefund(_a0 *entity.Transaction) (*entity.Transaction, error) {
 	ret := _m.Called(_a0)
 
 	var r0 *entity.Transaction
@@ -59,13 +59,13 @@ func (_m *ITransactionService) CreateTransaction(_a0 *entity.Transaction) (*enti
 	return r0, r1
 }
 
-// FindByWalletNumber provides a mock function with given fields: _a0, _a1
-func (_m *ITransactionService) FindByWalletNumber(_a0 int, _a1 *entity.Pagination) ([]*entity.Transaction, *entity.Pagination, error) {
-	ret := _m.Called(_a0, _a1)
+// FindByWalletNumber provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITransactionService) FindByWalletNumber(_a0 int, _a1 *entity.TransactionFilter, _a2 *entity.Pagination) ([]*entity.Transaction, *entity.Pagination, error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	var r0 []*entity.Transaction
-	if rf, ok := ret.Get(0).(func(int, *entity.Pagination) []*entity.Transaction); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(int, *entity.TransactionFilter, *entity.Pagination) []*entity.Transaction); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entity.Transaction)
@@ -73,8 +73,8 @@ func (_m *ITransactionService) FindByWalletNumber(_a0 int, _a1 *entity.Paginatio
 	}
 
 	var r1 *entity.Pagination
-	if rf, ok := ret.Get(1).(func(int, *entity.Pagination) *entity.Pagination); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(int, *entity.TransactionFilter, *entity.Pagination) *entity.Pagination); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*entity.Pagination)
@@ -82,8 +82,8 @@ func (_m *ITransactionService) FindByWalletNumber(_a0 int, _a1 *entity.Paginatio
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(int, *entity.Pagination) error); ok {
-		r2 = rf(_a0, _a1)
+	if rf, ok := ret.Get(2).(func(int, *entity.TransactionFilter, *entity.Pagination) error); ok {
+		r2 = rf(_a0, _a1, _a2)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -91,6 +91,61 @@ func (_m *ITransactionService) FindByWalletNumber(_a0 int, _a1 *entity.Paginatio
 	return r0, r1, r2
 }
 
+// FindByWalletNumberAfterCursor provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ITransactionService) FindByWalletNumberAfterCursor(_a0 int, _a1 *entity.TransactionFilter, _a2 *entity.CursorPagination) ([]*entity.Transaction, *entity.CursorPagination, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []*entity.Transaction
+	if rf, ok := ret.Get(0).(func(int, *entity.TransactionFilter, *entity.CursorPagination) []*entity.Transaction); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Transaction)
+		}
+	}
+
+	var r1 *entity.CursorPagination
+	if rf, ok := ret.Get(1).(func(int, *entity.TransactionFilter, *entity.CursorPagination) *entity.CursorPagination); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*entity.CursorPagination)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, *entity.TransactionFilter, *entity.CursorPagination) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindDetailByID provides a mock function with given fields: _a0, _a1
+func (_m *ITransactionService) FindDetailByID(_a0 int, _a1 int) (*entity.TransactionDetail, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.TransactionDetail
+	if rf, ok := ret.Get(0).(func(int, int) *entity.TransactionDetail); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TransactionDetail)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewITransactionService interface {
 	mock.TestingT
 	Cleanup(func())
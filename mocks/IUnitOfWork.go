@@ -0,0 +1,43 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	repository "assignment-golang-backend/internal/repository"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IUnitOfWork is an autogenerated mock type for the IUnitOfWork type
+type IUnitOfWork struct {
+	mock.Mock
+}
+
+// WithinTransaction provides a mock function with given fields: _a0
+func (_m *IUnitOfWork) WithinTransaction(_a0 func(*repository.Repositories) error) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(func(*repository.Repositories) error) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIUnitOfWork interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIUnitOfWork creates a new instance of IUnitOfWork. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIUnitOfWork(t mockConstructorTestingTNewIUnitOfWork) *IUnitOfWork {
+	mock := &IUnitOfWork{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
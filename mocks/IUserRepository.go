@@ -6,6 +6,8 @@ import (
 	entity "assignment-golang-backend/internal/entity"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // IUserRepository is an autogenerated mock type for the IUserRepository type
@@ -103,6 +105,157 @@ func (_m *IUserRepository) FindByID(_a0 int) (*entity.User, int, error) {
 	return r0, r1, r2
 }
 
+// FindByIDForUpdate provides a mock function with given fields: _a0
+func (_m *IUserRepository) FindByIDForUpdate(_a0 int) (*entity.User, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.User
+	if rf, ok := ret.Get(0).(func(int) *entity.User); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IncrementPinFailedAttempts provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IUserRepository) IncrementPinFailedAttempts(_a0 int, _a1 int, _a2 time.Time) (*entity.User, int, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *entity.User
+	if rf, ok := ret.Get(0).(func(int, int, time.Time) *entity.User); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, int, time.Time) int); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, int, time.Time) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ResetPinFailedAttempts provides a mock function with given fields: _a0
+func (_m *IUserRepository) ResetPinFailedAttempts(_a0 int) (int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateKycStatus provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IUserRepository) UpdateKycStatus(_a0 int, _a1 entity.KycStatus, _a2 ...entity.KycStatus) (int, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, entity.KycStatus, ...entity.KycStatus) int); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, entity.KycStatus, ...entity.KycStatus) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePin provides a mock function with given fields: _a0, _a1
+func (_m *IUserRepository) UpdatePin(_a0 int, _a1 string) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, string) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTier provides a mock function with given fields: _a0, _a1
+func (_m *IUserRepository) UpdateTier(_a0 int, _a1 entity.UserTier) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, entity.UserTier) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, entity.UserTier) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewIUserRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -73,6 +73,36 @@ func (_m *IWalletRepository) DecrementBalanceByValue(_a0 int, _a1 int) (*entity.
 	return r0, r1, r2
 }
 
+// FindAllWithTransactionBalance provides a mock function with given fields:
+func (_m *IWalletRepository) FindAllWithTransactionBalance() ([]*entity.BalanceReconciliation, int, error) {
+	ret := _m.Called()
+
+	var r0 []*entity.BalanceReconciliation
+	if rf, ok := ret.Get(0).(func() []*entity.BalanceReconciliation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.BalanceReconciliation)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func() int); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // FindByNumber provides a mock function with given fields: _a0
 func (_m *IWalletRepository) FindByNumber(_a0 int) (*entity.Wallet, int, error) {
 	ret := _m.Called(_a0)
@@ -103,6 +133,66 @@ func (_m *IWalletRepository) FindByNumber(_a0 int) (*entity.Wallet, int, error)
 	return r0, r1, r2
 }
 
+// FindByNumberAndUserID provides a mock function with given fields: _a0, _a1
+func (_m *IWalletRepository) FindByNumberAndUserID(_a0 int, _a1 int) (*entity.Wallet, int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.Wallet
+	if rf, ok := ret.Get(0).(func(int, int) *entity.Wallet); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Wallet)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, int) int); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, int) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindByUserID provides a mock function with given fields: _a0
+func (_m *IWalletRepository) FindByUserID(_a0 int) ([]*entity.Wallet, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*entity.Wallet
+	if rf, ok := ret.Get(0).(func(int) []*entity.Wallet); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Wallet)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // IncrementBalanceByValue provides a mock function with given fields: _a0, _a1
 func (_m *IWalletRepository) IncrementBalanceByValue(_a0 int, _a1 int) (*entity.Wallet, int, error) {
 	ret := _m.Called(_a0, _a1)
@@ -133,6 +223,87 @@ func (_m *IWalletRepository) IncrementBalanceByValue(_a0 int, _a1 int) (*entity.
 	return r0, r1, r2
 }
 
+// UpdateBalance provides a mock function with given fields: _a0, _a1, _a2
+func (_m *IWalletRepository) UpdateBalance(_a0 int, _a1 int, _a2 int) (*entity.Wallet, int, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *entity.Wallet
+	if rf, ok := ret.Get(0).(func(int, int, int) *entity.Wallet); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Wallet)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int, int, int) int); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int, int, int) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateStatus provides a mock function with given fields: _a0, _a1
+func (_m *IWalletRepository) UpdateStatus(_a0 *entity.Wallet, _a1 entity.WalletStatus) (*entity.Wallet, int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.Wallet
+	if rf, ok := ret.Get(0).(func(*entity.Wallet, entity.WalletStatus) *entity.Wallet); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Wallet)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*entity.Wallet, entity.WalletStatus) int); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*entity.Wallet, entity.WalletStatus) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateUserID provides a mock function with given fields: _a0, _a1
+func (_m *IWalletRepository) UpdateUserID(_a0 int, _a1 int) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int, int) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewIWalletRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,135 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IWalletService is an autogenerated mock type for the IWalletService type
+type IWalletService struct {
+	mock.Mock
+}
+
+// CloseWallet provides a mock function with given fields: _a0, _a1
+func (_m *IWalletService) CloseWallet(_a0 int, _a1 int) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, int) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateWallet provides a mock function with given fields: _a0
+func (_m *IWalletService) CreateWallet(_a0 *entity.Wallet) (*entity.Wallet, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *entity.Wallet
+	if rf, ok := ret.Get(0).(func(*entity.Wallet) *entity.Wallet); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Wallet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.Wallet) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByUserID provides a mock function with given fields: _a0
+func (_m *IWalletService) FindByUserID(_a0 int) ([]*entity.Wallet, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*entity.Wallet
+	if rf, ok := ret.Get(0).(func(int) []*entity.Wallet); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Wallet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOwned provides a mock function with given fields: _a0, _a1
+func (_m *IWalletService) FindOwned(_a0 int, _a1 int) (*entity.Wallet, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.Wallet
+	if rf, ok := ret.Get(0).(func(int, int) *entity.Wallet); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Wallet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: _a0, _a1
+func (_m *IWalletService) UpdateStatus(_a0 *entity.Wallet, _a1 *int) (*entity.Wallet, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.Wallet
+	if rf, ok := ret.Get(0).(func(*entity.Wallet, *int) *entity.Wallet); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Wallet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*entity.Wallet, *int) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIWalletService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIWalletService creates a new instance of IWalletService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIWalletService(t mockConstructorTestingTNewIWalletService) *IWalletService {
+	mock := &IWalletService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
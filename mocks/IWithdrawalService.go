@@ -0,0 +1,75 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IWithdrawalService is an autogenerated mock type for the IWithdrawalService type
+type IWithdrawalService struct {
+	mock.Mock
+}
+
+// CreateWithdrawal provides a mock function with given fields: _a0, _a1
+func (_m *IWithdrawalService) CreateWithdrawal(_a0 int, _a1 *entity.Transaction) (*entity.Transaction, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.Transaction
+	if rf, ok := ret.Get(0).(func(int, *entity.Transaction) *entity.Transaction); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, *entity.Transaction) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PayOutBalance provides a mock function with given fields: _a0, _a1
+func (_m *IWithdrawalService) PayOutBalance(_a0 int, _a1 *entity.Transaction) (*entity.Transaction, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *entity.Transaction
+	if rf, ok := ret.Get(0).(func(int, *entity.Transaction) *entity.Transaction); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, *entity.Transaction) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIWithdrawalService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIWithdrawalService creates a new instance of IWithdrawalService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIWithdrawalService(t mockConstructorTestingTNewIWithdrawalService) *IWithdrawalService {
+	mock := &IWithdrawalService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "assignment-golang-backend/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	statement "assignment-golang-backend/internal/statement"
+)
+
+// IWriter is an autogenerated mock type for the IWriter type
+type IWriter struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields: _a0
+func (_m *IWriter) Close(_a0 int) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContentType provides a mock function with given fields:
+func (_m *IWriter) ContentType() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// WriteHeader provides a mock function with given fields: _a0
+func (_m *IWriter) WriteHeader(_a0 *entity.Statement) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entity.Statement) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WriteRow provides a mock function with given fields: _a0
+func (_m *IWriter) WriteRow(_a0 *statement.Row) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*statement.Row) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIWriter interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIWriter creates a new instance of IWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIWriter(t mockConstructorTestingTNewIWriter) *IWriter {
+	mock := &IWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}